@@ -0,0 +1,119 @@
+package dev_server
+
+import (
+	"fmt"
+	"go/token"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/launchdarkly/ldcli/cmd/cliflags"
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/output"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func NewCodegenCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "projects",
+		Args:    validators.Validate(),
+		Long: `Generate a typed accessor for each of a project's flags, so that flag keys and value types are checked at
+compile time.
+
+Value types are inferred from each flag's variations, string flags get a constant per variation, and each accessor
+defaults to the flag's off variation. Output only depends on the project's flags, so it can be checked in and
+regenerated in CI to catch changes.
+
+Examples:
+  ldcli dev-server codegen --project=my-project --lang=go --out=internal/flags
+  ldcli dev-server codegen --project=my-project --lang=typescript --out=src/flags`,
+		RunE:  codegen(client),
+		Short: "generate typed flag accessors",
+		Use:   "codegen",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().String(cliflags.ProjectFlag, "", "The project key")
+	_ = cmd.MarkFlagRequired(cliflags.ProjectFlag)
+	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+
+	cmd.Flags().String(CodegenLangFlag, "", "The language to generate: go or typescript")
+	_ = cmd.MarkFlagRequired(CodegenLangFlag)
+	_ = cmd.Flags().SetAnnotation(CodegenLangFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(CodegenLangFlag, cmd.Flags().Lookup(CodegenLangFlag))
+
+	cmd.Flags().String(CodegenOutFlag, "", "The directory to write flags.go or flags.ts to")
+	_ = cmd.MarkFlagRequired(CodegenOutFlag)
+	_ = cmd.Flags().SetAnnotation(CodegenOutFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(CodegenOutFlag, cmd.Flags().Lookup(CodegenOutFlag))
+
+	cmd.Flags().String(CodegenPackageFlag, "", "The Go package name, if not the name of --out")
+	_ = viper.BindPFlag(CodegenPackageFlag, cmd.Flags().Lookup(CodegenPackageFlag))
+
+	// Not bound to viper, since the config file's default environment isn't the one the project syncs from.
+	cmd.Flags().String(cliflags.EnvironmentFlag, "", "The source environment of the project, if not the one it was added with")
+
+	return cmd
+}
+
+func codegen(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		lang, err := model.ParseCodegenLanguage(viper.GetString(CodegenLangFlag))
+		if err != nil {
+			return err
+		}
+		out := viper.GetString(CodegenOutFlag)
+
+		query := url.Values{"lang": {string(lang)}}
+		fileName := "flags.ts"
+		if lang == model.CodegenLanguageGo {
+			query.Set("package", codegenPackage(out))
+			fileName = "flags.go"
+		}
+		projectKey := viper.GetString(cliflags.ProjectFlag)
+		if environmentKey, _ := cmd.Flags().GetString(cliflags.EnvironmentFlag); environmentKey != "" {
+			projectKey += ":" + environmentKey
+		}
+		path := fmt.Sprintf("%s/dev/projects/%s/codegen", getDevServerUrl(), projectKey)
+		res, err := client.MakeRequest("", "GET", path, "application/json", query, nil, false)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		if err := os.MkdirAll(out, 0o755); err != nil {
+			return fmt.Errorf("unable to create %s: %w", out, err)
+		}
+		file := filepath.Join(out, fileName)
+		if err := os.WriteFile(file, res, 0o644); err != nil {
+			return fmt.Errorf("unable to write %s: %w", file, err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", file)
+
+		return nil
+	}
+}
+
+// codegenPackage is the --package flag, or the name of the output directory when it makes a valid package name.
+func codegenPackage(out string) string {
+	if packageName := viper.GetString(CodegenPackageFlag); packageName != "" {
+		return packageName
+	}
+	abs, err := filepath.Abs(out)
+	if err != nil {
+		return model.DefaultCodegenPackage
+	}
+	packageName := strings.ToLower(strings.NewReplacer("-", "", ".", "").Replace(filepath.Base(abs)))
+	if !token.IsIdentifier(packageName) {
+		return model.DefaultCodegenPackage
+	}
+	return packageName
+}
@@ -0,0 +1,47 @@
+package dev_server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestCodegenCmd(t *testing.T) {
+	t.Run("writes the accessors to the output directory", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "feature-flags")
+		mockClient := &resources.MockClient{Response: []byte("package featureflags\n")}
+		output, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "codegen", "--access-token", "test-token", "--project", "test-proj", "--lang", "go", "--out", out},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "go", mockClient.Query.Get("lang"))
+		assert.Equal(t, "featureflags", mockClient.Query.Get("package"))
+		assert.Contains(t, string(output), filepath.Join(out, "flags.go"))
+		written, err := os.ReadFile(filepath.Join(out, "flags.go"))
+		require.NoError(t, err)
+		assert.Equal(t, "package featureflags\n", string(written))
+	})
+
+	t.Run("returns error for an unknown language", func(t *testing.T) {
+		mockClient := &resources.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "codegen", "--access-token", "test-token", "--project", "test-proj", "--lang", "rust", "--out", t.TempDir()},
+		)
+
+		require.ErrorContains(t, err, `unsupported language "rust"`)
+	})
+}
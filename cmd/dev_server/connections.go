@@ -0,0 +1,50 @@
+package dev_server
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/launchdarkly/ldcli/cmd/cliflags"
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/output"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func NewConnectionsCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "server",
+		Args:    validators.Validate(),
+		Long:    "list the SDKs connected to the dev server, oldest first: open streams, and SDKs that polled in the last 10 minutes, with their user agent, address and the payload version they were last sent",
+		RunE:    listConnections(client),
+		Short:   "list connected SDKs",
+		Use:     "connections",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().String(cliflags.ProjectFlag, "", "Only list the SDKs connected to this project")
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+
+	return cmd
+}
+
+func listConnections(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		query := url.Values{}
+		if projectKey := viper.GetString(cliflags.ProjectFlag); projectKey != "" {
+			query.Set("projectKey", projectKey)
+		}
+		res, err := client.MakeRequest("", "GET", fmt.Sprintf("%s/dev/connections", getDevServerUrl()), "application/json", query, nil, false)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
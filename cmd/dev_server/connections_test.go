@@ -0,0 +1,41 @@
+package dev_server_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestConnectionsCmd(t *testing.T) {
+	t.Run("lists every project's connections", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`[]`)}
+		out, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "connections", "--access-token", "test-token"},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "[]", string(out))
+		assert.Empty(t, mockClient.Query.Get("projectKey"))
+	})
+
+	t.Run("lists the project's connections", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`[]`)}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "connections", "--access-token", "test-token", "--project", "test-proj"},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "test-proj", mockClient.Query.Get("projectKey"))
+	})
+}
@@ -0,0 +1,246 @@
+package dev_server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/launchdarkly/ldcli/cmd/cliflags"
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/dev_server/daemon"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+const (
+	detachTimeout = time.Minute
+	stopTimeout   = 15 * time.Second
+)
+
+// serverClient is the client of the commands that call the dev server, which explains connection failures.
+type serverClient struct {
+	resources.Client
+}
+
+func (c serverClient) MakeRequest(
+	accessToken, method, path, contentType string,
+	query url.Values,
+	data []byte,
+	isBeta bool,
+) ([]byte, error) {
+	res, err := c.Client.MakeRequest(accessToken, method, path, contentType, query, data, isBeta)
+	return res, explainServerError(err)
+}
+
+func (c serverClient) MakeUnauthenticatedRequest(method string, path string, data []byte) ([]byte, error) {
+	res, err := c.Client.MakeUnauthenticatedRequest(method, path, data)
+	return res, explainServerError(err)
+}
+
+func explainServerError(err error) error {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return fmt.Errorf(
+			"the dev server isn't running on port %s. Start it with `ldcli dev-server start`, or pass the --%s it runs on",
+			getDevServerPort(),
+			cliflags.PortFlag,
+		)
+	}
+	return err
+}
+
+// getDevServerPort is the port given with --port, or the one the running dev server recorded.
+func getDevServerPort() string {
+	if !viper.IsSet(cliflags.PortFlag) {
+		if state, err := daemon.Running(viper.GetString(DataDirFlag)); err == nil {
+			return state.Port
+		}
+	}
+	return viper.GetString(cliflags.PortFlag)
+}
+
+// startDetached starts the dev server in the background with the arguments start was called with, recording its state
+// in stateDir.
+func startDetached(cmd *cobra.Command, stateDir string) (err error) {
+	args := daemon.WithoutArg(os.Args[1:], DetachFlag)
+	if viper.GetBool(EphemeralFlag) {
+		// The directory was made for this server, which removes it when it stops.
+		defer func() {
+			if err != nil {
+				_ = daemon.RemoveDir(stateDir)
+			}
+		}()
+		args = append(args, "--"+StateDirFlag, stateDir)
+	} else if state, err := daemon.Running(stateDir); err == nil {
+		return fmt.Errorf("the dev server is already running on port %s (pid %d)", state.Port, state.PID)
+	}
+	logPath, err := daemon.LogPath(stateDir)
+	if err != nil {
+		return err
+	}
+	state, err := daemon.Start(args, stateDir, logPath, detachTimeout)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "dev server running at %s (pid %d), logging to %s\n", state.URL(), state.PID, logPath)
+	if stateDir != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "pass --%s %s to status, logs and stop to manage it\n", DataDirFlag, stateDir)
+	}
+	return nil
+}
+
+func NewStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "server",
+		Args:    validateWithoutAccessToken(),
+		Long:    "show whether the dev server is running, and where",
+		RunE:    status(),
+		Short:   "show the dev server's status",
+		Use:     "status",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().String(DataDirFlag, "", ServerDataDirDescription)
+	_ = viper.BindPFlag(DataDirFlag, cmd.Flags().Lookup(DataDirFlag))
+
+	return cmd
+}
+
+func status() func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		dataDir := viper.GetString(DataDirFlag)
+		state, err := daemon.Running(dataDir)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "dev server is running (pid %d) since %s\n", state.PID, state.StartedAt.Format(time.RFC3339))
+		fmt.Fprintf(cmd.OutOrStdout(), "  URL: %s\n", state.URL())
+		if state.TLS && state.AllowPlain {
+			fmt.Fprintf(cmd.OutOrStdout(), "  HTTPS: https://localhost:%s\n", state.Port)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "  UI: %s/ui\n", state.URL())
+		if logPath, err := daemon.LogPath(dataDir); err == nil {
+			if _, err := os.Stat(logPath); err == nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "  Logs: %s\n", logPath)
+			}
+		}
+
+		return nil
+	}
+}
+
+func NewStopCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "server",
+		Args:    validateWithoutAccessToken(),
+		Long:    "stop the running dev server, letting it finish requests, close SDK streams and close its databases",
+		RunE:    stopServer(),
+		Short:   "stop the dev server",
+		Use:     "stop",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().String(DataDirFlag, "", ServerDataDirDescription)
+	_ = viper.BindPFlag(DataDirFlag, cmd.Flags().Lookup(DataDirFlag))
+
+	return cmd
+}
+
+func stopServer() func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		dataDir := viper.GetString(DataDirFlag)
+		state, err := daemon.Running(dataDir)
+		if err != nil {
+			return err
+		}
+		if err := daemon.Stop(dataDir, state, stopTimeout); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "dev server stopped (pid %d)\n", state.PID)
+
+		return nil
+	}
+}
+
+func NewLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "server",
+		Args:    validateWithoutAccessToken(),
+		Long:    "print the logs of the dev server started with --detach",
+		RunE:    logs(),
+		Short:   "print the dev server's logs",
+		Use:     "logs",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().BoolP(FollowFlag, "f", false, "Keep printing new log lines until interrupted")
+	_ = viper.BindPFlag(FollowFlag, cmd.Flags().Lookup(FollowFlag))
+
+	cmd.Flags().String(DataDirFlag, "", ServerDataDirDescription)
+	_ = viper.BindPFlag(DataDirFlag, cmd.Flags().Lookup(DataDirFlag))
+
+	return cmd
+}
+
+func logs() func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		logPath, err := daemon.LogPath(viper.GetString(DataDirFlag))
+		if err != nil {
+			return err
+		}
+		logFile, err := os.Open(logPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			return errors.New("there are no dev server logs. The dev server only logs to a file when started with --detach")
+		}
+		if err != nil {
+			return err
+		}
+		defer logFile.Close()
+
+		if _, err := io.Copy(cmd.OutOrStdout(), logFile); err != nil {
+			return err
+		}
+		if !viper.GetBool(FollowFlag) {
+			return nil
+		}
+
+		interrupted := make(chan os.Signal, 1)
+		signal.Notify(interrupted, os.Interrupt)
+		defer signal.Stop(interrupted)
+		ticker := time.NewTicker(500 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-interrupted:
+				return nil
+			case <-ticker.C:
+				if _, err := io.Copy(cmd.OutOrStdout(), logFile); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// validateWithoutAccessToken drops the access token requirement of commands that only deal with the local server.
+func validateWithoutAccessToken() cobra.PositionalArgs {
+	validate := validators.Validate()
+	return func(cmd *cobra.Command, args []string) error {
+		_ = cmd.Flags().SetAnnotation(cliflags.AccessTokenFlag, cobra.BashCompOneRequiredFlag, []string{"false"})
+		return validate(cmd, args)
+	}
+}
@@ -0,0 +1,85 @@
+package dev_server_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/dev_server"
+	"github.com/launchdarkly/ldcli/internal/dev_server/daemon"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestDaemonCmds(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	t.Run("status returns error when the server isn't running", func(t *testing.T) {
+		_, err := cmd.CallCmd(t, cmd.APIClients{}, analytics.NoopClientFn{}.Tracker(), []string{"dev-server", "status"})
+
+		assert.ErrorContains(t, err, "the dev server isn't running")
+	})
+
+	t.Run("stop returns error when the server isn't running", func(t *testing.T) {
+		_, err := cmd.CallCmd(t, cmd.APIClients{}, analytics.NoopClientFn{}.Tracker(), []string{"dev-server", "stop"})
+
+		assert.ErrorContains(t, err, "the dev server isn't running")
+	})
+
+	t.Run("client commands explain that the server isn't running", func(t *testing.T) {
+		mockClient := &resources.MockClient{
+			StatusCode: http.StatusServiceUnavailable,
+			Err:        &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+		}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "list-projects", "--access-token", "test-token"},
+		)
+
+		assert.ErrorContains(t, err, "the dev server isn't running on port 8765")
+	})
+
+	t.Run("status finds a server by its data dir", func(t *testing.T) {
+		dataDir := t.TempDir()
+		require.NoError(t, daemon.Record(dataDir, daemon.State{PID: os.Getpid(), Port: "9000"}))
+
+		out, err := cmd.CallCmd(t, cmd.APIClients{}, analytics.NoopClientFn{}.Tracker(), []string{"dev-server", "status", "--data-dir", dataDir})
+
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "URL: http://localhost:9000")
+	})
+
+	require.NoError(t, daemon.Record("", daemon.State{PID: os.Getpid(), Port: "9999"}))
+	t.Cleanup(func() { daemon.Forget("", os.Getpid()) })
+
+	t.Run("status shows the running server", func(t *testing.T) {
+		out, err := cmd.CallCmd(t, cmd.APIClients{}, analytics.NoopClientFn{}.Tracker(), []string{"dev-server", "status"})
+
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "URL: http://localhost:9999")
+	})
+
+	t.Run("start --detach returns error when the server is already running", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "start", "--access-token", "test-token", "--detach"},
+		)
+
+		assert.ErrorContains(t, err, "already running on port 9999")
+		assert.False(t, mockClient.RunServerCalled)
+	})
+}
@@ -15,6 +15,7 @@ import (
 )
 
 func NewDevServerCmd(client resources.Client, analyticsTrackerFn analytics.TrackerFn, ldClient dev_server.Client) *cobra.Command {
+	client = serverClient{client}
 	cmd := &cobra.Command{
 		Use:   "dev-server",
 		Short: "Development server",
@@ -24,7 +25,8 @@ func NewDevServerCmd(client resources.Client, analyticsTrackerFn analytics.Track
 			tracker := analyticsTrackerFn(
 				viper.GetString(cliflags.AccessTokenFlag),
 				viper.GetString(cliflags.BaseURIFlag),
-				viper.GetBool(cliflags.AnalyticsOptOut),
+				// offline mode makes no LaunchDarkly calls, analytics included
+				viper.GetBool(cliflags.AnalyticsOptOut) || viper.GetString(OfflineFileFlag) != "",
 			)
 			tracker.SendCommandRunEvent(cmdAnalytics.CmdRunEventProperties(
 				cmd,
@@ -72,16 +74,33 @@ func NewDevServerCmd(client resources.Client, analyticsTrackerFn analytics.Track
 	cmd.AddCommand(NewRemoveProjectCmd(client))
 	cmd.AddCommand(NewAddProjectCmd(client))
 	cmd.AddCommand(NewUpdateProjectCmd(client))
+	cmd.AddCommand(NewAddEnvironmentCmd(client))
+	cmd.AddCommand(NewRemoveEnvironmentCmd(client))
 	cmd.AddCommand(NewImportProjectCmd())
+	cmd.AddCommand(NewExportProjectCmd(client))
+	cmd.AddCommand(NewCodegenCmd(client))
+	cmd.AddCommand(NewEvaluationsCmd(client))
+	cmd.AddCommand(NewFaultsCmd(client))
+	cmd.AddCommand(NewSnapshotCmd(client))
+	cmd.AddCommand(NewWebhooksCmd(client))
 
 	cmd.AddGroup(&cobra.Group{ID: "overrides", Title: "Override commands:"})
 	cmd.AddCommand(NewAddOverrideCmd(client))
 	cmd.AddCommand(NewRemoveOverrideCmd(client))
 	cmd.AddCommand(NewDeleteOverridesCmd(client))
+	cmd.AddCommand(NewScenarioCmd(client))
+	cmd.AddCommand(NewHistoryCmd(client))
+	cmd.AddCommand(NewUndoCmd(client))
+	cmd.AddCommand(NewRedoCmd(client))
 	cmd.AddGroup(&cobra.Group{ID: "server", Title: "Server commands:"})
 
 	cmd.AddCommand(NewStartServerCmd(ldClient))
+	cmd.AddCommand(NewStatusCmd())
+	cmd.AddCommand(NewStopCmd())
+	cmd.AddCommand(NewLogsCmd())
+	cmd.AddCommand(NewConnectionsCmd(client))
 	cmd.AddCommand(NewUICmd())
+	cmd.AddCommand(NewTrustInfoCmd())
 
 	cmd.SetUsageTemplate(resourcecmd.SubcommandUsageTemplate())
 
@@ -89,5 +108,5 @@ func NewDevServerCmd(client resources.Client, analyticsTrackerFn analytics.Track
 }
 
 func getDevServerUrl() string {
-	return fmt.Sprintf("http://localhost:%s", viper.GetString(cliflags.PortFlag))
+	return fmt.Sprintf("http://localhost:%s", getDevServerPort())
 }
@@ -0,0 +1,95 @@
+package dev_server
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/launchdarkly/ldcli/cmd/cliflags"
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/output"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func NewAddEnvironmentCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "projects",
+		Args:    validators.Validate(),
+		Long: `sync another source environment into a project, evaluated for the project's context. The environment gets its
+own overrides, and SDKs reach it with its SDK key, mobile key or client-side ID, or with project:environment in
+place of a credential.`,
+		RunE:  addEnvironment(client),
+		Short: "add a source environment to a project",
+		Use:   "add-environment",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addEnvironmentFlags(cmd)
+
+	return cmd
+}
+
+func addEnvironment(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		res, err := client.MakeUnauthenticatedRequest("POST", sourceEnvironmentPath(), nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func NewRemoveEnvironmentCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "projects",
+		Args:    validators.Validate(),
+		Long:    "remove a source environment that was added to a project, along with its overrides",
+		RunE:    removeEnvironment(client),
+		Short:   "remove a source environment from a project",
+		Use:     "remove-environment",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addEnvironmentFlags(cmd)
+
+	return cmd
+}
+
+func removeEnvironment(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		_, err := client.MakeUnauthenticatedRequest("DELETE", sourceEnvironmentPath(), nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "'%s' environment removed from '%s' project\n", viper.GetString(SourceEnvironmentFlag), viper.GetString(cliflags.ProjectFlag))
+
+		return nil
+	}
+}
+
+func addEnvironmentFlags(cmd *cobra.Command) {
+	cmd.Flags().String(cliflags.ProjectFlag, "", "The project key")
+	_ = cmd.MarkFlagRequired(cliflags.ProjectFlag)
+	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+
+	cmd.Flags().String(SourceEnvironmentFlag, "", "The environment key to copy flag values from")
+	_ = cmd.MarkFlagRequired(SourceEnvironmentFlag)
+	_ = cmd.Flags().SetAnnotation(SourceEnvironmentFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(SourceEnvironmentFlag, cmd.Flags().Lookup(SourceEnvironmentFlag))
+}
+
+func sourceEnvironmentPath() string {
+	return fmt.Sprintf(
+		"%s/dev/projects/%s/source-environments/%s",
+		getDevServerUrl(),
+		viper.GetString(cliflags.ProjectFlag),
+		viper.GetString(SourceEnvironmentFlag),
+	)
+}
@@ -0,0 +1,52 @@
+package dev_server_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestAddEnvironmentCmd(t *testing.T) {
+	t.Run("prints the added environment", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`{"sourceEnvironmentKey":"staging"}`)}
+		out, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "add-environment", "--access-token", "test-token", "--project", "test-proj", "--source", "staging"},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, `{"sourceEnvironmentKey":"staging"}`, string(out))
+	})
+
+	t.Run("returns error without a source environment", func(t *testing.T) {
+		mockClient := &resources.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "add-environment", "--access-token", "test-token", "--project", "test-proj"},
+		)
+
+		require.Error(t, err)
+	})
+}
+
+func TestRemoveEnvironmentCmd(t *testing.T) {
+	mockClient := &resources.MockClient{}
+	out, err := cmd.CallCmd(
+		t,
+		cmd.APIClients{ResourcesClient: mockClient},
+		analytics.NoopClientFn{}.Tracker(),
+		[]string{"dev-server", "remove-environment", "--access-token", "test-token", "--project", "test-proj", "--source", "staging"},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "'staging' environment removed from 'test-proj' project\n", string(out))
+}
@@ -0,0 +1,105 @@
+package dev_server
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/launchdarkly/ldcli/cmd/cliflags"
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/output"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func NewEvaluationsCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "projects",
+		Long:    "show which flags the project's SDKs evaluated, for which contexts, and the values served, as reported in their events",
+		Short:   "summarize flag evaluations",
+		Use:     "evaluations",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	cmd.AddCommand(newListEvaluationsCmd(client))
+	cmd.AddCommand(newClearEvaluationsCmd(client))
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	return cmd
+}
+
+func newListEvaluationsCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "list the flags evaluated since the dev server started or the evaluations were cleared. With --flag, show just that flag.",
+		RunE:  listEvaluations(client),
+		Short: "list evaluations",
+		Use:   "list",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addEvaluationsProjectFlag(cmd)
+
+	cmd.Flags().String(cliflags.FlagFlag, "", "The flag key")
+	_ = viper.BindPFlag(cliflags.FlagFlag, cmd.Flags().Lookup(cliflags.FlagFlag))
+
+	return cmd
+}
+
+func listEvaluations(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		path := evaluationsPath()
+		if flagKey := viper.GetString(cliflags.FlagFlag); flagKey != "" {
+			path += "/" + url.PathEscape(flagKey)
+		}
+		res, err := client.MakeUnauthenticatedRequest("GET", path, nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func newClearEvaluationsCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "forget the evaluations recorded for a project, so that only new ones are listed",
+		RunE:  clearEvaluations(client),
+		Short: "clear evaluations",
+		Use:   "clear",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addEvaluationsProjectFlag(cmd)
+
+	return cmd
+}
+
+func clearEvaluations(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		res, err := client.MakeUnauthenticatedRequest("DELETE", evaluationsPath(), nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func addEvaluationsProjectFlag(cmd *cobra.Command) {
+	cmd.Flags().String(cliflags.ProjectFlag, "", "The project key")
+	_ = cmd.MarkFlagRequired(cliflags.ProjectFlag)
+	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+}
+
+func evaluationsPath() string {
+	return fmt.Sprintf("%s/dev/projects/%s/evaluations", getDevServerUrl(), viper.GetString(cliflags.ProjectFlag))
+}
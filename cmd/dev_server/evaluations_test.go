@@ -0,0 +1,39 @@
+package dev_server_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestEvaluationsListCmd(t *testing.T) {
+	t.Run("prints the project's evaluations", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`{"flags":[{"flagKey":"new-checkout","count":3}],"contexts":[]}`)}
+		output, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "evaluations", "list", "--access-token", "test-token", "--project", "test-proj"},
+		)
+
+		require.NoError(t, err)
+		assert.Contains(t, string(output), "new-checkout")
+	})
+
+	t.Run("returns error without a project", func(t *testing.T) {
+		mockClient := &resources.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "evaluations", "list", "--access-token", "test-token"},
+		)
+
+		require.Error(t, err)
+	})
+}
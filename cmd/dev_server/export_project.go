@@ -0,0 +1,79 @@
+package dev_server
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/launchdarkly/ldcli/cmd/cliflags"
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/output"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func NewExportProjectCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "projects",
+		Args:    validators.Validate(),
+		Long: `Export a project's flag values, with its active overrides applied, as a fixture for SDK tests.
+
+Formats:
+  json, yaml  the flagValues form of the SDKs' file data source
+  import      the format that import-project and --offline-file read
+  go          a function that sets the flags up on a Go SDK ldtestdata data source
+
+Examples:
+  # Load the flags with the file data source in unit tests
+  ldcli dev-server export-project --project=my-project --format=yaml > testdata/flags.yaml
+
+  # Recreate the tuned project on another machine
+  ldcli dev-server export-project --project=my-project --format=import > project.json
+  ldcli dev-server import-project --project=my-project --file=project.json`,
+		RunE:  exportProject(client),
+		Short: "export project as SDK test fixtures",
+		Use:   "export-project",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().String(cliflags.ProjectFlag, "", "The project key")
+	_ = cmd.MarkFlagRequired(cliflags.ProjectFlag)
+	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+
+	cmd.Flags().String(ExportFormatFlag, string(model.ExportFormatJSON), "The fixture format: json, yaml, import or go")
+	_ = viper.BindPFlag(ExportFormatFlag, cmd.Flags().Lookup(ExportFormatFlag))
+
+	// Not bound to viper, since the config file's default environment isn't the one the project syncs from.
+	cmd.Flags().String(cliflags.EnvironmentFlag, "", "The source environment of the project to export, if not the one it was added with")
+
+	return cmd
+}
+
+func exportProject(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		format, err := model.ParseExportFormat(viper.GetString(ExportFormatFlag))
+		if err != nil {
+			return err
+		}
+
+		projectKey := viper.GetString(cliflags.ProjectFlag)
+		if environmentKey, _ := cmd.Flags().GetString(cliflags.EnvironmentFlag); environmentKey != "" {
+			projectKey += ":" + environmentKey
+		}
+		path := fmt.Sprintf("%s/dev/projects/%s/export", getDevServerUrl(), projectKey)
+		query := url.Values{"format": {string(format)}}
+		res, err := client.MakeRequest("", "GET", path, "application/json", query, nil, false)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
@@ -0,0 +1,40 @@
+package dev_server_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestExportProjectCmd(t *testing.T) {
+	t.Run("prints the fixture in the requested format", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte("flagValues:\n    new-checkout: true\n")}
+		output, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "export-project", "--access-token", "test-token", "--project", "test-proj", "--format", "yaml"},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "yaml", mockClient.Query.Get("format"))
+		assert.Equal(t, "flagValues:\n    new-checkout: true\n", string(output))
+	})
+
+	t.Run("returns error for an unknown format", func(t *testing.T) {
+		mockClient := &resources.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "export-project", "--access-token", "test-token", "--project", "test-proj", "--format", "xml"},
+		)
+
+		require.ErrorContains(t, err, `unsupported export format "xml"`)
+	})
+}
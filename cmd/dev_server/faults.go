@@ -0,0 +1,174 @@
+package dev_server
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/launchdarkly/ldcli/cmd/cliflags"
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/output"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func NewFaultsCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "projects",
+		Long:    "make the dev server's SDK endpoints slow or flaky for a project, to exercise SDK retry and fallback paths",
+		Short:   "inject faults into SDK endpoints",
+		Use:     "faults",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	cmd.AddCommand(newListFaultsCmd(client))
+	cmd.AddCommand(newAddFaultCmd(client))
+	cmd.AddCommand(newClearFaultsCmd(client))
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	return cmd
+}
+
+func newListFaultsCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "list the faults injected into a project's SDK endpoints",
+		RunE:  listFaults(client),
+		Short: "list faults",
+		Use:   "list",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addFaultsProjectFlag(cmd)
+
+	return cmd
+}
+
+func listFaults(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		res, err := client.MakeUnauthenticatedRequest("GET", faultsPath(), nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func newAddFaultCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args: validators.Validate(),
+		Long: `inject a fault into a project's SDK endpoints. Each request is hit with --probability. Kinds of fault:
+  latency           delay responses by --latency
+  error             respond with --status, 429 or 5xx
+  drop-stream       end streams after --drop-after
+  stall-heartbeats  stop sending stream heartbeats
+  malformed         corrupt the JSON of the first payload
+  truncated         cut off the first payload halfway`,
+		RunE:  addFault(client),
+		Short: "add a fault",
+		Use:   "add",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addFaultsProjectFlag(cmd)
+
+	cmd.Flags().String(FaultKindFlag, "", "The kind of fault: latency, error, drop-stream, stall-heartbeats, malformed or truncated")
+	_ = cmd.MarkFlagRequired(FaultKindFlag)
+	_ = cmd.Flags().SetAnnotation(FaultKindFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(FaultKindFlag, cmd.Flags().Lookup(FaultKindFlag))
+
+	cmd.Flags().Float64(ProbabilityFlag, 1, "the chance that the fault hits a request, e.g. 0.25")
+	_ = viper.BindPFlag(ProbabilityFlag, cmd.Flags().Lookup(ProbabilityFlag))
+
+	cmd.Flags().Duration(FaultDurationFlag, 0, "remove the fault after this long, e.g. 5m. The fault stays until cleared without it.")
+	_ = viper.BindPFlag(FaultDurationFlag, cmd.Flags().Lookup(FaultDurationFlag))
+
+	cmd.Flags().Duration(LatencyFlag, 0, "the delay added by latency faults, e.g. 2s")
+	_ = viper.BindPFlag(LatencyFlag, cmd.Flags().Lookup(LatencyFlag))
+
+	cmd.Flags().Int(StatusFlag, 0, "the status of responses from error faults, e.g. 503 or 429")
+	_ = viper.BindPFlag(StatusFlag, cmd.Flags().Lookup(StatusFlag))
+
+	cmd.Flags().Duration(DropAfterFlag, 0, "how long streams stay open before drop-stream faults end them, e.g. 30s")
+	_ = viper.BindPFlag(DropAfterFlag, cmd.Flags().Lookup(DropAfterFlag))
+
+	return cmd
+}
+
+func addFault(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		fault := map[string]interface{}{
+			"kind":        viper.GetString(FaultKindFlag),
+			"probability": viper.GetFloat64(ProbabilityFlag),
+		}
+		if duration := viper.GetDuration(FaultDurationFlag); duration != 0 {
+			fault["durationMs"] = duration.Milliseconds()
+		}
+		if latency := viper.GetDuration(LatencyFlag); latency != 0 {
+			fault["latencyMs"] = latency.Milliseconds()
+		}
+		if status := viper.GetInt(StatusFlag); status != 0 {
+			fault["status"] = status
+		}
+		if dropAfter := viper.GetDuration(DropAfterFlag); dropAfter != 0 {
+			fault["dropAfterMs"] = dropAfter.Milliseconds()
+		}
+		body, err := json.Marshal(fault)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		res, err := client.MakeUnauthenticatedRequest("POST", faultsPath(), body)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func newClearFaultsCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "remove the faults injected into a project's SDK endpoints",
+		RunE:  clearFaults(client),
+		Short: "clear faults",
+		Use:   "clear",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addFaultsProjectFlag(cmd)
+
+	return cmd
+}
+
+func clearFaults(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		res, err := client.MakeUnauthenticatedRequest("DELETE", faultsPath(), nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func addFaultsProjectFlag(cmd *cobra.Command) {
+	cmd.Flags().String(cliflags.ProjectFlag, "", "The project key")
+	_ = cmd.MarkFlagRequired(cliflags.ProjectFlag)
+	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+}
+
+func faultsPath() string {
+	return fmt.Sprintf("%s/dev/projects/%s/faults", getDevServerUrl(), viper.GetString(cliflags.ProjectFlag))
+}
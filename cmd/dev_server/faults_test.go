@@ -0,0 +1,42 @@
+package dev_server_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestFaultsAddCmd(t *testing.T) {
+	t.Run("sends the fault with durations in milliseconds", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`[{"kind":"error","probability":0.5,"status":503}]`)}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{
+				"dev-server", "faults", "add", "--access-token", "test-token", "--project", "test-proj",
+				"--kind", "drop-stream", "--probability", "0.5", "--drop-after", "30s", "--duration", "5m",
+			},
+		)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"kind":"drop-stream","probability":0.5,"dropAfterMs":30000,"durationMs":300000}`, string(mockClient.Input))
+	})
+
+	t.Run("returns error without a kind", func(t *testing.T) {
+		mockClient := &resources.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "faults", "add", "--access-token", "test-token", "--project", "test-proj"},
+		)
+
+		require.Error(t, err)
+	})
+}
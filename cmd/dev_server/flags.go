@@ -1,9 +1,86 @@
 package dev_server
 
 const (
+	AgainstFlag           = "against"
+	CodegenLangFlag       = "lang"
+	CodegenOutFlag        = "out"
+	CodegenPackageFlag    = "package"
 	ContextFlag           = "context"
+	ContextMatcherFlag    = "context-matcher"
+	DataDirFlag           = "data-dir"
+	DetachFlag            = "detach"
+	DropAfterFlag         = "drop-after"
+	ExportFormatFlag      = "format"
+	FaultDurationFlag     = "duration"
+	FaultKindFlag         = "kind"
+	FollowFlag            = "follow"
+	LatencyFlag           = "latency"
+	LimitFlag             = "limit"
 	OverrideFlag          = "override"
+	ProbabilityFlag       = "probability"
+	ScenarioNameFlag      = "name"
+	SnapshotNameFlag      = "name"
 	SourceEnvironmentFlag = "source"
+	StatusFlag            = "status"
+	TrustInfoExportFlag   = "export"
+	TTLFlag               = "ttl"
+	UntilFlag             = "until"
+	WebhookIDFlag         = "id"
+	WebhookSecretFlag     = "secret"
+	WebhookURLFlag        = "url"
+
+	OfflineFileFlag        = "offline-file"
+	OfflineFileDescription = "Seed --project from a local JSON or YAML flag file and run without an access token or any " +
+		"calls to LaunchDarkly. The file uses the import-project format, or lists flags by key with a value and " +
+		"optional variations."
+
+	LiveSyncFlag        = "live-sync"
+	LiveSyncDescription = "Keep a streaming connection open to each project's source environment so that flag changes " +
+		"made in LaunchDarkly are applied as they happen. Local overrides still take precedence."
+
+	ForwardEventsFlag        = "forward-events"
+	ForwardEventsDescription = "Also send the analytics and diagnostic events that SDKs post to the dev server on to " +
+		"LaunchDarkly, using the SDK key of each project's source environment, so they show up there as they would " +
+		"in production. Events are still captured locally."
+
+	EventsURIFlag        = "events-uri"
+	EventsURIDescription = "The events URI to forward SDK events to with --forward-events"
+
+	EphemeralFlag        = "ephemeral"
+	EphemeralDescription = "Keep all of the dev server's state in memory and discard it when the server stops, so " +
+		"that parallel CI jobs don't share databases and each run starts clean."
+
+	DataDirDescription = "The directory to keep the dev server's databases, state, logs and local CA in, instead of " +
+		"the ldcli state directory"
+	ServerDataDirDescription = "The data directory of the dev server, if it was started with --data-dir or with " +
+		"--ephemeral --detach"
+
+	// StateDirFlag hands a detached ephemeral dev server the directory that the command starting it created for it.
+	StateDirFlag = "state-dir"
+
+	TLSFlag        = "tls"
+	TLSDescription = "Serve HTTPS instead of plain HTTP on --port, with a certificate for localhost and this " +
+		"machine's LAN addresses issued by a local CA. Run `ldcli dev-server trust-info` to trust the CA on devices " +
+		"and in browsers."
+
+	TLSAllowPlainFlag        = "tls-allow-plain"
+	TLSAllowPlainDescription = "With --tls, also accept plain HTTP on --port. The other dev-server commands talk " +
+		"plain HTTP to the dev server, so they need this to reach a dev server started with --tls."
+
+	TLSCertFlag        = "tls-cert"
+	TLSCertDescription = "Serve HTTPS with this PEM certificate instead of one from the local CA. Requires --tls-key."
+
+	TLSKeyFlag        = "tls-key"
+	TLSKeyDescription = "The PEM private key of --tls-cert"
+
+	WatchFileFlag        = "watch-file"
+	WatchFileDescription = "Apply a local flag file to --project, then apply every saved change to connected SDKs " +
+		"without restarting. The project is synced from --source first, unless --offline-file seeds it from the " +
+		"same file; synced values the file changes are replaced until the next sync."
+
+	MetricsFlag        = "metrics"
+	MetricsDescription = "Serve Prometheus metrics at /metrics: open SDK streams, updates broadcast, SDK events " +
+		"received, syncs and database sizes."
 
 	StreamFlagStartupFlag        = "stream-flag-startup"
 	StreamFlagStartupDescription = "Load flag values from the streaming connection at startup and resolve variation " +
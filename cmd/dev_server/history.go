@@ -0,0 +1,108 @@
+package dev_server
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/launchdarkly/ldcli/cmd/cliflags"
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/output"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func NewHistoryCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "overrides",
+		Args:    validators.Validate(),
+		Long:    "list the changes made to a project's overrides and flag values, most recent first, with where each one came from",
+		RunE:    listHistory(client),
+		Short:   "list override history",
+		Use:     "history",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addHistoryProjectFlag(cmd)
+
+	cmd.Flags().Int(LimitFlag, 0, "The maximum number of entries to list")
+	_ = viper.BindPFlag(LimitFlag, cmd.Flags().Lookup(LimitFlag))
+
+	return cmd
+}
+
+func listHistory(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		query := url.Values{}
+		if limit := viper.GetInt(LimitFlag); limit > 0 {
+			query.Set("limit", strconv.Itoa(limit))
+		}
+		res, err := client.MakeRequest("", "GET", historyPath(), "application/json", query, nil, false)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func NewUndoCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "overrides",
+		Args:    validators.Validate(),
+		Long:    "revert the project's most recent override change, sending connected SDKs the restored values",
+		RunE:    replayHistory(client, "undo"),
+		Short:   "undo an override change",
+		Use:     "undo",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addHistoryProjectFlag(cmd)
+
+	return cmd
+}
+
+func NewRedoCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "overrides",
+		Args:    validators.Validate(),
+		Long:    "replay the project's most recently undone override change, as long as no other change was made since",
+		RunE:    replayHistory(client, "redo"),
+		Short:   "redo an undone override change",
+		Use:     "redo",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addHistoryProjectFlag(cmd)
+
+	return cmd
+}
+
+func replayHistory(client resources.Client, action string) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		res, err := client.MakeUnauthenticatedRequest("POST", historyPath()+"/"+action, nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func addHistoryProjectFlag(cmd *cobra.Command) {
+	cmd.Flags().String(cliflags.ProjectFlag, "", "The project key")
+	_ = cmd.MarkFlagRequired(cliflags.ProjectFlag)
+	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+}
+
+func historyPath() string {
+	return fmt.Sprintf("%s/dev/projects/%s/history", getDevServerUrl(), viper.GetString(cliflags.ProjectFlag))
+}
@@ -0,0 +1,57 @@
+package dev_server_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestHistoryCmd(t *testing.T) {
+	t.Run("limits the entries listed", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`[{"id":2,"changeId":2,"flagKey":"new-checkout","action":"override","source":"cli"}]`)}
+		output, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "history", "--access-token", "test-token", "--project", "test-proj", "--limit", "1"},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, url.Values{"limit": {"1"}}, mockClient.Query)
+		assert.Contains(t, string(output), "new-checkout")
+	})
+
+	t.Run("requires a project", func(t *testing.T) {
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: &resources.MockClient{}},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "history", "--access-token", "test-token"},
+		)
+
+		require.Error(t, err)
+	})
+}
+
+func TestUndoRedoCmd(t *testing.T) {
+	for _, action := range []string{"undo", "redo"} {
+		t.Run(action+" prints the entries recorded", func(t *testing.T) {
+			mockClient := &resources.MockClient{Response: []byte(`[{"id":3,"changeId":3,"flagKey":"new-checkout","action":"` + action + `","source":"cli","reverts":2}]`)}
+			output, err := cmd.CallCmd(
+				t,
+				cmd.APIClients{ResourcesClient: mockClient},
+				analytics.NoopClientFn{}.Tracker(),
+				[]string{"dev-server", action, "--access-token", "test-token", "--project", "test-proj"},
+			)
+
+			require.NoError(t, err)
+			assert.Contains(t, string(output), `"reverts":2`)
+		})
+	}
+}
@@ -5,13 +5,13 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/adrg/xdg"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/launchdarkly/ldcli/cmd/cliflags"
 	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
 	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/dev_server/daemon"
 	"github.com/launchdarkly/ldcli/internal/dev_server/db"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 )
@@ -22,12 +22,14 @@ func NewImportProjectCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		GroupID: "projects",
 		Args:    validators.Validate(),
-		Long: `Import a project into the dev server database from a JSON file.
+		Long: `Import a project into the dev server database from a JSON or YAML file.
 
-The JSON file format matches the output from:
+The file format matches the output from:
   ldcli dev-server get-project --project=<key> \
     --expand=overrides --expand=availableVariations
 
+Flags can also be listed by key under "flags", each with a "value" and optional "variations".
+
 Examples:
   # Export project data (while dev server is running)
   ldcli dev-server get-project --project=my-project \
@@ -47,11 +49,14 @@ Examples:
 	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
 	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
 
-	cmd.Flags().String(ImportFileFlag, "", "Path to JSON file containing project data")
+	cmd.Flags().String(ImportFileFlag, "", "Path to JSON or YAML file containing project data")
 	_ = cmd.MarkFlagRequired(ImportFileFlag)
 	_ = cmd.Flags().SetAnnotation(ImportFileFlag, "required", []string{"true"})
 	_ = viper.BindPFlag(ImportFileFlag, cmd.Flags().Lookup(ImportFileFlag))
 
+	cmd.Flags().String(DataDirFlag, "", DataDirDescription)
+	_ = viper.BindPFlag(DataDirFlag, cmd.Flags().Lookup(DataDirFlag))
+
 	return cmd
 }
 
@@ -62,7 +67,7 @@ func importProject() func(*cobra.Command, []string) error {
 		filepath := viper.GetString(ImportFileFlag)
 
 		// Get database path (same logic as dev_server.go)
-		dbFilePath, err := xdg.StateFile("ldcli/dev_server.db")
+		dbFilePath, err := daemon.File(viper.GetString(DataDirFlag), "dev_server.db")
 		if err != nil {
 			return fmt.Errorf("unable to get database path: %w", err)
 		}
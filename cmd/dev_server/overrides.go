@@ -3,6 +3,8 @@ package dev_server
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -41,6 +43,17 @@ func NewAddOverrideCmd(client resources.Client) *cobra.Command {
 	_ = cmd.Flags().SetAnnotation(cliflags.DataFlag, "required", []string{"true"})
 	_ = viper.BindPFlag(cliflags.DataFlag, cmd.Flags().Lookup(cliflags.DataFlag))
 
+	cmd.Flags().Duration(TTLFlag, 0, "remove the override after this long, e.g. 2h or 30m")
+	_ = viper.BindPFlag(TTLFlag, cmd.Flags().Lookup(TTLFlag))
+
+	cmd.Flags().String(UntilFlag, "", "remove the override at this RFC 3339 time, e.g. 2025-06-01T17:00:00Z")
+	_ = viper.BindPFlag(UntilFlag, cmd.Flags().Lookup(UntilFlag))
+
+	cmd.MarkFlagsMutuallyExclusive(TTLFlag, UntilFlag)
+
+	cmd.Flags().String(ContextMatcherFlag, "", `only override the flag for matching contexts, e.g. 'user.key == "alice"' or 'org.tier in [enterprise, startup]'`)
+	_ = viper.BindPFlag(ContextMatcherFlag, cmd.Flags().Lookup(ContextMatcherFlag))
+
 	return cmd
 }
 
@@ -58,11 +71,18 @@ func addOverride(client resources.Client) func(*cobra.Command, []string) error {
 		}
 
 		path := fmt.Sprintf("%s/dev/projects/%s/overrides/%s", getDevServerUrl(), viper.GetString(cliflags.ProjectFlag), viper.GetString(cliflags.FlagFlag))
-		res, err := client.MakeUnauthenticatedRequest(
-			"PUT",
-			path,
-			jsonData,
-		)
+		expiresAt, err := overrideExpiry()
+		if err != nil {
+			return err
+		}
+		query := url.Values{}
+		if !expiresAt.IsZero() {
+			query.Set("expiresAt", expiresAt.Format(time.RFC3339))
+		}
+		if contextMatcher := viper.GetString(ContextMatcherFlag); contextMatcher != "" {
+			query.Set("contextMatcher", contextMatcher)
+		}
+		res, err := client.MakeRequest("", "PUT", path, "application/json", query, jsonData, false)
 		if err != nil {
 			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
 		}
@@ -73,6 +93,24 @@ func addOverride(client resources.Client) func(*cobra.Command, []string) error {
 	}
 }
 
+// overrideExpiry returns when the override should expire from --ttl or --until, or the zero time if it shouldn't.
+func overrideExpiry() (time.Time, error) {
+	if ttl := viper.GetDuration(TTLFlag); ttl != 0 {
+		if ttl < 0 {
+			return time.Time{}, fmt.Errorf("--%s must be positive", TTLFlag)
+		}
+		return time.Now().Add(ttl), nil
+	}
+	if until := viper.GetString(UntilFlag); until != "" {
+		expiresAt, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("--%s must be an RFC 3339 time: %w", UntilFlag, err)
+		}
+		return expiresAt, nil
+	}
+	return time.Time{}, nil
+}
+
 func NewDeleteOverridesCmd(client resources.Client) *cobra.Command {
 	cmd := &cobra.Command{
 		GroupID: "overrides",
@@ -0,0 +1,91 @@
+package dev_server_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestAddOverrideCmd(t *testing.T) {
+	baseArgs := []string{
+		"dev-server", "add-override",
+		"--access-token", "test-token",
+		"--project", "test-proj",
+		"--flag", "new-checkout",
+		"--data", "true",
+	}
+
+	t.Run("sends an expiring override with --ttl", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`{"override":true,"value":true}`)}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--ttl", "2h"),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "true", string(mockClient.Input))
+		expiresAt, err := time.Parse(time.RFC3339, mockClient.Query.Get("expiresAt"))
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(2*time.Hour), expiresAt, time.Minute)
+	})
+
+	t.Run("sends the expiry given with --until", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`{"override":true,"value":true}`)}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--until", "2030-01-01T00:00:00Z"),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2030-01-01T00:00:00Z", mockClient.Query.Get("expiresAt"))
+	})
+
+	t.Run("sends a scoped override with --context-matcher", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`{"override":true,"value":true}`)}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--context-matcher", `user.key == "alice"`),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, `user.key == "alice"`, mockClient.Query.Get("contextMatcher"))
+		assert.Empty(t, mockClient.Query.Get("expiresAt"))
+	})
+
+	t.Run("returns error for a malformed --until", func(t *testing.T) {
+		mockClient := &resources.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--until", "tomorrow"),
+		)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "RFC 3339")
+	})
+
+	t.Run("returns error when both --ttl and --until are set", func(t *testing.T) {
+		mockClient := &resources.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--ttl", "2h", "--until", "2030-01-01T00:00:00Z"),
+		)
+
+		require.Error(t, err)
+	})
+}
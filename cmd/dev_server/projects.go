@@ -60,7 +60,10 @@ Examples:
 
   # Get project with all data (for import/backup)
   ldcli dev-server get-project --project=my-project \
-    --expand=overrides --expand=availableVariations > backup.json`,
+    --expand=overrides --expand=availableVariations > backup.json
+
+  # Get one of the source environments added to the project
+  ldcli dev-server get-project --project=my-project --environment=staging`,
 		RunE:  getProject(client),
 		Short: "get a project",
 		Use:   "get-project",
@@ -76,13 +79,20 @@ Examples:
 	cmd.Flags().StringSlice("expand", []string{}, "Expand options: overrides, availableVariations")
 	_ = viper.BindPFlag("expand", cmd.Flags().Lookup("expand"))
 
+	// Not bound to viper, since the config file's default environment isn't the one the project syncs from.
+	cmd.Flags().String(cliflags.EnvironmentFlag, "", "The source environment of the project to get, if not the one it was added with")
+
 	return cmd
 }
 
 func getProject(client resources.Client) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 
-		path := getDevServerUrl() + "/dev/projects/" + viper.GetString(cliflags.ProjectFlag)
+		projectKey := viper.GetString(cliflags.ProjectFlag)
+		if environmentKey, _ := cmd.Flags().GetString(cliflags.EnvironmentFlag); environmentKey != "" {
+			projectKey += ":" + environmentKey
+		}
+		path := getDevServerUrl() + "/dev/projects/" + projectKey
 
 		// Add expand query parameters if specified
 		// Try to get from command flags first, then fall back to viper
@@ -0,0 +1,190 @@
+package dev_server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/launchdarkly/ldcli/cmd/cliflags"
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/output"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func NewScenarioCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "overrides",
+		Long:    "save named sets of overrides and switch between them in a single update",
+		Short:   "manage override scenarios",
+		Use:     "scenario",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	cmd.AddCommand(newSaveScenarioCmd(client))
+	cmd.AddCommand(newApplyScenarioCmd(client))
+	cmd.AddCommand(newListScenariosCmd(client))
+	cmd.AddCommand(newDeleteScenarioCmd(client))
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	return cmd
+}
+
+func newSaveScenarioCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "save a named set of overrides. Without --data, the project's current overrides are saved.",
+		RunE:  saveScenario(client),
+		Short: "save a scenario",
+		Use:   "save",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addScenarioFlags(cmd)
+
+	cmd.Flags().String(cliflags.DataFlag, "", `overrides to save, as a JSON object of flag keys to values, e.g. '{"new-checkout": true}'`)
+	_ = viper.BindPFlag(cliflags.DataFlag, cmd.Flags().Lookup(cliflags.DataFlag))
+
+	return cmd
+}
+
+func saveScenario(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		body := map[string]interface{}{}
+		if data := viper.GetString(cliflags.DataFlag); data != "" {
+			var overrides map[string]interface{}
+			err := json.Unmarshal([]byte(data), &overrides)
+			if err != nil {
+				return err
+			}
+			body["overrides"] = overrides
+		}
+
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		res, err := client.MakeUnauthenticatedRequest("PUT", scenarioPath(), jsonData)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func newApplyScenarioCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "replace the project's overrides with the scenario's, sending connected SDKs a single update",
+		RunE:  applyScenario(client),
+		Short: "apply a scenario",
+		Use:   "apply",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addScenarioFlags(cmd)
+
+	return cmd
+}
+
+func applyScenario(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		res, err := client.MakeUnauthenticatedRequest("POST", scenarioPath()+"/apply", nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func newListScenariosCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "list the saved scenarios for a project",
+		RunE:  listScenarios(client),
+		Short: "list scenarios",
+		Use:   "list",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().String(cliflags.ProjectFlag, "", "The project key")
+	_ = cmd.MarkFlagRequired(cliflags.ProjectFlag)
+	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+
+	return cmd
+}
+
+func listScenarios(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		path := fmt.Sprintf("%s/dev/projects/%s/scenarios", getDevServerUrl(), viper.GetString(cliflags.ProjectFlag))
+		res, err := client.MakeUnauthenticatedRequest("GET", path, nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func newDeleteScenarioCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "delete a saved scenario. The project's overrides are left as they are.",
+		RunE:  deleteScenario(client),
+		Short: "delete a scenario",
+		Use:   "delete",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addScenarioFlags(cmd)
+
+	return cmd
+}
+
+func deleteScenario(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		res, err := client.MakeUnauthenticatedRequest("DELETE", scenarioPath(), nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func addScenarioFlags(cmd *cobra.Command) {
+	cmd.Flags().String(cliflags.ProjectFlag, "", "The project key")
+	_ = cmd.MarkFlagRequired(cliflags.ProjectFlag)
+	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+
+	cmd.Flags().String(ScenarioNameFlag, "", "The scenario name")
+	_ = cmd.MarkFlagRequired(ScenarioNameFlag)
+	_ = cmd.Flags().SetAnnotation(ScenarioNameFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(ScenarioNameFlag, cmd.Flags().Lookup(ScenarioNameFlag))
+}
+
+func scenarioPath() string {
+	return fmt.Sprintf(
+		"%s/dev/projects/%s/scenarios/%s",
+		getDevServerUrl(),
+		viper.GetString(cliflags.ProjectFlag),
+		url.PathEscape(viper.GetString(ScenarioNameFlag)),
+	)
+}
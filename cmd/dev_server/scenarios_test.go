@@ -0,0 +1,60 @@
+package dev_server_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestScenarioSaveCmd(t *testing.T) {
+	baseArgs := []string{
+		"dev-server", "scenario", "save",
+		"--access-token", "test-token",
+		"--project", "test-proj",
+		"--name", "checkout-on",
+	}
+
+	t.Run("sends the overrides given as data", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`{"name":"checkout-on","overrides":{"new-checkout":true}}`)}
+		output, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--data", `{"new-checkout": true}`),
+		)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"overrides":{"new-checkout":true}}`, string(mockClient.Input))
+		assert.Contains(t, string(output), "checkout-on")
+	})
+
+	t.Run("sends an empty body to save the current overrides", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`{"name":"checkout-on","overrides":{}}`)}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			baseArgs,
+		)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{}`, string(mockClient.Input))
+	})
+
+	t.Run("returns error for malformed data", func(t *testing.T) {
+		mockClient := &resources.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--data", `not-valid-json`),
+		)
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,214 @@
+package dev_server
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/launchdarkly/ldcli/cmd/cliflags"
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/output"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func NewSnapshotCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "projects",
+		Long:    "save a project's flag state, overrides, context and available variations under a name, compare snapshots and restore one without touching other projects",
+		Short:   "manage project snapshots",
+		Use:     "snapshot",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	cmd.AddCommand(newCreateSnapshotCmd(client))
+	cmd.AddCommand(newListSnapshotsCmd(client))
+	cmd.AddCommand(newDiffSnapshotCmd(client))
+	cmd.AddCommand(newRestoreSnapshotCmd(client))
+	cmd.AddCommand(newDeleteSnapshotCmd(client))
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	return cmd
+}
+
+func newCreateSnapshotCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "save the project's current flag state, overrides, context and available variations as a named snapshot",
+		RunE:  createSnapshot(client),
+		Short: "create a snapshot",
+		Use:   "create",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addSnapshotFlags(cmd)
+
+	return cmd
+}
+
+func createSnapshot(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		res, err := client.MakeUnauthenticatedRequest("POST", snapshotPath(), nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func newListSnapshotsCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "list the snapshots of a project, oldest first",
+		RunE:  listSnapshots(client),
+		Short: "list snapshots",
+		Use:   "list",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().String(cliflags.ProjectFlag, "", "The project key")
+	_ = cmd.MarkFlagRequired(cliflags.ProjectFlag)
+	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+
+	return cmd
+}
+
+func listSnapshots(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		path := fmt.Sprintf("%s/dev/projects/%s/snapshots", getDevServerUrl(), viper.GetString(cliflags.ProjectFlag))
+		res, err := client.MakeUnauthenticatedRequest("GET", path, nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func newDiffSnapshotCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "list the differences in context, flag values, overrides and variations between a snapshot and the project's live state, or another snapshot with --against",
+		RunE:  diffSnapshot(client),
+		Short: "compare a snapshot",
+		Use:   "diff",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addSnapshotFlags(cmd)
+
+	cmd.Flags().String(AgainstFlag, "", "The snapshot to compare with, instead of the project's live state")
+	_ = viper.BindPFlag(AgainstFlag, cmd.Flags().Lookup(AgainstFlag))
+
+	return cmd
+}
+
+func diffSnapshot(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		query := url.Values{}
+		if against := viper.GetString(AgainstFlag); against != "" {
+			query.Set("against", against)
+		}
+		res, err := client.MakeRequest("", "GET", snapshotPath()+"/diff", "application/json", query, nil, false)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		out, err := output.CmdOutput("", cliflags.GetOutputKind(cmd), res, output.CmdOutputOpts{
+			ResourceName: "snapshot-diffs",
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), out+"\n")
+
+		return nil
+	}
+}
+
+func newRestoreSnapshotCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "put the project back in the state the snapshot captured, sending connected SDKs a single update. Other projects are left as they are.",
+		RunE:  restoreSnapshot(client),
+		Short: "restore a snapshot",
+		Use:   "restore",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addSnapshotFlags(cmd)
+
+	return cmd
+}
+
+func restoreSnapshot(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		res, err := client.MakeUnauthenticatedRequest("POST", snapshotPath()+"/restore", nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func newDeleteSnapshotCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "delete a snapshot. The project is left as it is.",
+		RunE:  deleteSnapshot(client),
+		Short: "delete a snapshot",
+		Use:   "delete",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+	addSnapshotFlags(cmd)
+
+	return cmd
+}
+
+func deleteSnapshot(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		res, err := client.MakeUnauthenticatedRequest("DELETE", snapshotPath(), nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func addSnapshotFlags(cmd *cobra.Command) {
+	cmd.Flags().String(cliflags.ProjectFlag, "", "The project key")
+	_ = cmd.MarkFlagRequired(cliflags.ProjectFlag)
+	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+
+	cmd.Flags().String(SnapshotNameFlag, "", "The snapshot name")
+	_ = cmd.MarkFlagRequired(SnapshotNameFlag)
+	_ = cmd.Flags().SetAnnotation(SnapshotNameFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(SnapshotNameFlag, cmd.Flags().Lookup(SnapshotNameFlag))
+}
+
+func snapshotPath() string {
+	return fmt.Sprintf(
+		"%s/dev/projects/%s/snapshots/%s",
+		getDevServerUrl(),
+		viper.GetString(cliflags.ProjectFlag),
+		url.PathEscape(viper.GetString(SnapshotNameFlag)),
+	)
+}
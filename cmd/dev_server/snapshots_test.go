@@ -0,0 +1,71 @@
+package dev_server_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestSnapshotDiffCmd(t *testing.T) {
+	baseArgs := []string{
+		"dev-server", "snapshot", "diff",
+		"--access-token", "test-token",
+		"--project", "test-proj",
+		"--name", "base",
+	}
+	response := []byte(`{
+		"from": "base",
+		"to": "live",
+		"items": [
+			{"field": "context", "from": {"kind": "user", "key": "dev"}, "to": {"kind": "user", "key": "ci"}},
+			{"flagKey": "new-checkout", "field": "override", "to": true}
+		],
+		"totalCount": 2
+	}`)
+
+	t.Run("shows the differences as a table", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: response}
+		output, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--output", "plaintext"),
+		)
+
+		require.NoError(t, err)
+		assert.Empty(t, mockClient.Query)
+		assert.Contains(t, string(output), "FLAG")
+		assert.Contains(t, string(output), `{"key":"ci","kind":"user"}`)
+		assert.Contains(t, string(output), "new-checkout")
+	})
+
+	t.Run("compares with another snapshot", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: response}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--against", "other"),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "other", mockClient.Query.Get("against"))
+	})
+
+	t.Run("returns error without a name", func(t *testing.T) {
+		mockClient := &resources.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "snapshot", "diff", "--access-token", "test-token", "--project", "test-proj"},
+		)
+
+		require.Error(t, err)
+	})
+}
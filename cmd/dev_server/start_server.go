@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
+	"os"
 	"os/exec"
 	"runtime"
 
@@ -22,7 +24,7 @@ import (
 func NewStartServerCmd(client dev_server.Client) *cobra.Command {
 	cmd := &cobra.Command{
 		GroupID: "server",
-		Args:    validators.Validate(),
+		Args:    validateStartServer(),
 		Long:    "start the dev server",
 		RunE:    startServer(client),
 		Short:   "start the dev server",
@@ -49,22 +51,107 @@ func NewStartServerCmd(client dev_server.Client) *cobra.Command {
 	cmd.Flags().Bool(StreamFlagStartupFlag, false, StreamFlagStartupDescription)
 	_ = viper.BindPFlag(StreamFlagStartupFlag, cmd.Flags().Lookup(StreamFlagStartupFlag))
 
+	cmd.Flags().Bool(LiveSyncFlag, false, LiveSyncDescription)
+	_ = viper.BindPFlag(LiveSyncFlag, cmd.Flags().Lookup(LiveSyncFlag))
+
+	cmd.Flags().Bool(ForwardEventsFlag, false, ForwardEventsDescription)
+	_ = viper.BindPFlag(ForwardEventsFlag, cmd.Flags().Lookup(ForwardEventsFlag))
+
+	cmd.Flags().String(EventsURIFlag, model.DefaultEventsURI, EventsURIDescription)
+	_ = viper.BindPFlag(EventsURIFlag, cmd.Flags().Lookup(EventsURIFlag))
+
+	cmd.Flags().Bool(DetachFlag, false, "Run the dev server in the background. Use status, logs and stop to manage it.")
+	_ = viper.BindPFlag(DetachFlag, cmd.Flags().Lookup(DetachFlag))
+
+	cmd.Flags().Bool(EphemeralFlag, false, EphemeralDescription)
+	_ = viper.BindPFlag(EphemeralFlag, cmd.Flags().Lookup(EphemeralFlag))
+
+	cmd.Flags().String(DataDirFlag, "", DataDirDescription)
+	_ = viper.BindPFlag(DataDirFlag, cmd.Flags().Lookup(DataDirFlag))
+
+	cmd.Flags().String(StateDirFlag, "", "")
+	_ = cmd.Flags().MarkHidden(StateDirFlag)
+	_ = viper.BindPFlag(StateDirFlag, cmd.Flags().Lookup(StateDirFlag))
+
+	cmd.Flags().Bool(MetricsFlag, false, MetricsDescription)
+	_ = viper.BindPFlag(MetricsFlag, cmd.Flags().Lookup(MetricsFlag))
+
+	cmd.Flags().Bool(TLSFlag, false, TLSDescription)
+	_ = viper.BindPFlag(TLSFlag, cmd.Flags().Lookup(TLSFlag))
+
+	cmd.Flags().String(TLSCertFlag, "", TLSCertDescription)
+	_ = viper.BindPFlag(TLSCertFlag, cmd.Flags().Lookup(TLSCertFlag))
+
+	cmd.Flags().String(TLSKeyFlag, "", TLSKeyDescription)
+	_ = viper.BindPFlag(TLSKeyFlag, cmd.Flags().Lookup(TLSKeyFlag))
+
+	cmd.Flags().Bool(TLSAllowPlainFlag, false, TLSAllowPlainDescription)
+	_ = viper.BindPFlag(TLSAllowPlainFlag, cmd.Flags().Lookup(TLSAllowPlainFlag))
+
+	cmd.Flags().String(OfflineFileFlag, "", OfflineFileDescription)
+	_ = viper.BindPFlag(OfflineFileFlag, cmd.Flags().Lookup(OfflineFileFlag))
+
+	cmd.Flags().String(WatchFileFlag, "", WatchFileDescription)
+	_ = viper.BindPFlag(WatchFileFlag, cmd.Flags().Lookup(WatchFileFlag))
+
 	return cmd
 }
 
+// validateStartServer drops the access token requirement in offline mode, where LaunchDarkly is never called.
+func validateStartServer() cobra.PositionalArgs {
+	validate := validators.Validate()
+	return func(cmd *cobra.Command, args []string) error {
+		if viper.GetString(OfflineFileFlag) != "" {
+			_ = cmd.Flags().SetAnnotation(cliflags.AccessTokenFlag, cobra.BashCompOneRequiredFlag, []string{"false"})
+		}
+		return validate(cmd, args)
+	}
+}
+
 func startServer(client dev_server.Client) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 
 		var initialSetting model.InitialProjectSettings
 
-		if viper.IsSet(cliflags.ProjectFlag) && viper.IsSet(SourceEnvironmentFlag) {
+		offlineFile := viper.GetString(OfflineFileFlag)
+		watchFile := viper.GetString(WatchFileFlag)
+		if watchFile != "" && offlineFile != "" && offlineFile != watchFile {
+			return errors.New("--offline-file and --watch-file must be the same file")
+		}
+		if (offlineFile != "" || watchFile != "") && !viper.IsSet(cliflags.ProjectFlag) {
+			return errors.New("--project is required with --offline-file or --watch-file")
+		}
+		if watchFile != "" && offlineFile == "" && !viper.IsSet(SourceEnvironmentFlag) {
+			return errors.New("--watch-file needs --source to sync the project from, or --offline-file to seed it from the file")
+		}
+		if offlineFile != "" && viper.GetBool(LiveSyncFlag) {
+			return errors.New("--live-sync can't be used in offline mode")
+		}
+		if offlineFile != "" && viper.GetBool(ForwardEventsFlag) {
+			return errors.New("--forward-events can't be used in offline mode")
+		}
+		if viper.GetBool(EphemeralFlag) && viper.GetString(DataDirFlag) != "" {
+			return errors.New("--ephemeral and --data-dir can't be used together")
+		}
+		tlsCertFile, tlsKeyFile := viper.GetString(TLSCertFlag), viper.GetString(TLSKeyFlag)
+		if (tlsCertFile == "") != (tlsKeyFile == "") {
+			return errors.New("--tls-cert and --tls-key must be used together")
+		}
+		serveTLS := viper.GetBool(TLSFlag) || tlsCertFile != ""
+		if viper.GetBool(TLSAllowPlainFlag) && !serveTLS {
+			return errors.New("--tls-allow-plain needs --tls or --tls-cert")
+		}
+
+		if viper.IsSet(cliflags.ProjectFlag) && (viper.IsSet(SourceEnvironmentFlag) || offlineFile != "") {
 
 			initialSetting = model.InitialProjectSettings{
 				Enabled:    true,
 				ProjectKey: viper.GetString(cliflags.ProjectFlag),
 				EnvKey:     viper.GetString(SourceEnvironmentFlag),
 				SyncOnce:   viper.GetBool(cliflags.SyncOnceFlag),
+				File:       offlineFile,
+				WatchFile:  watchFile,
 			}
 			if viper.IsSet(ContextFlag) {
 				var c ldcontext.Context
@@ -87,6 +174,24 @@ func startServer(client dev_server.Client) func(*cobra.Command, []string) error
 			}
 		}
 
+		// Ephemeral servers keep their state file and log in a directory of their own, which they remove when they
+		// stop, so that parallel ones don't find each other.
+		stateDir := viper.GetString(DataDirFlag)
+		if viper.GetBool(EphemeralFlag) {
+			stateDir = viper.GetString(StateDirFlag)
+			if stateDir == "" {
+				var err error
+				stateDir, err = os.MkdirTemp("", "ldcli-dev-server-")
+				if err != nil {
+					return fmt.Errorf("unable to create a state directory: %w", err)
+				}
+			}
+		}
+
+		if viper.GetBool(DetachFlag) {
+			return startDetached(cmd, stateDir)
+		}
+
 		params := dev_server.ServerParams{
 			AccessToken:            viper.GetString(cliflags.AccessTokenFlag),
 			BaseURI:                viper.GetString(cliflags.BaseURIFlag),
@@ -95,6 +200,17 @@ func startServer(client dev_server.Client) func(*cobra.Command, []string) error
 			CorsEnabled:            viper.GetBool(cliflags.CorsEnabledFlag),
 			CorsOrigin:             viper.GetString(cliflags.CorsOriginFlag),
 			StreamFlagStartup:      viper.GetBool(StreamFlagStartupFlag),
+			LiveSync:               viper.GetBool(LiveSyncFlag),
+			ForwardEvents:          viper.GetBool(ForwardEventsFlag),
+			EventsURI:              viper.GetString(EventsURIFlag),
+			TLS:                    serveTLS,
+			TLSCertFile:            tlsCertFile,
+			TLSKeyFile:             tlsKeyFile,
+			TLSAllowPlain:          viper.GetBool(TLSAllowPlainFlag),
+			Ephemeral:              viper.GetBool(EphemeralFlag),
+			DataDir:                viper.GetString(DataDirFlag),
+			StateDir:               stateDir,
+			Metrics:                viper.GetBool(MetricsFlag),
 			InitialProjectSettings: initialSetting,
 		}
 
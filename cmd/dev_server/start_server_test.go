@@ -1,6 +1,7 @@
 package dev_server_test
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -60,4 +61,242 @@ func TestStartServerCmd(t *testing.T) {
 		require.Error(t, err)
 		assert.False(t, mockClient.RunServerCalled)
 	})
+
+	t.Run("offline file does not need an access token or source environment", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "start", "--project", "test-proj", "--offline-file", "flags.yaml"},
+		)
+
+		require.NoError(t, err)
+		assert.True(t, mockClient.RunServerCalled)
+		settings := mockClient.RunServerParams.InitialProjectSettings
+		assert.True(t, settings.Enabled)
+		assert.Equal(t, "test-proj", settings.ProjectKey)
+		assert.Equal(t, "flags.yaml", settings.File)
+	})
+
+	t.Run("offline file requires a project", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "start", "--offline-file", "flags.yaml"},
+		)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--project is required")
+		assert.False(t, mockClient.RunServerCalled)
+	})
+
+	t.Run("watch file seeds from and watches the offline file", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "start", "--project", "test-proj", "--offline-file", "flags.yaml", "--watch-file", "flags.yaml"},
+		)
+
+		require.NoError(t, err)
+		settings := mockClient.RunServerParams.InitialProjectSettings
+		assert.Equal(t, "flags.yaml", settings.File)
+		assert.Equal(t, "flags.yaml", settings.WatchFile)
+	})
+
+	t.Run("watch file applies to a project synced from LaunchDarkly", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--watch-file", "flags.yaml"),
+		)
+
+		require.NoError(t, err)
+		settings := mockClient.RunServerParams.InitialProjectSettings
+		assert.Empty(t, settings.File)
+		assert.Equal(t, "flags.yaml", settings.WatchFile)
+		assert.Equal(t, "staging", settings.EnvKey)
+	})
+
+	t.Run("returns error for a watch file without a source environment or offline file", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "start", "--access-token", "test-token", "--project", "test-proj", "--watch-file", "flags.yaml"},
+		)
+
+		require.Error(t, err)
+		assert.False(t, mockClient.RunServerCalled)
+	})
+
+	t.Run("returns error for different offline and watch files", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "start", "--project", "test-proj", "--offline-file", "a.yaml", "--watch-file", "b.yaml"},
+		)
+
+		require.Error(t, err)
+		assert.False(t, mockClient.RunServerCalled)
+	})
+
+	t.Run("passes live sync to RunServer", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--live-sync"),
+		)
+
+		require.NoError(t, err)
+		assert.True(t, mockClient.RunServerParams.LiveSync)
+	})
+
+	t.Run("passes event forwarding to RunServer", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--forward-events", "--events-uri", "http://localhost:9999"),
+		)
+
+		require.NoError(t, err)
+		assert.True(t, mockClient.RunServerParams.ForwardEvents)
+		assert.Equal(t, "http://localhost:9999", mockClient.RunServerParams.EventsURI)
+	})
+
+	t.Run("returns error for event forwarding in offline mode", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "start", "--project", "test-proj", "--offline-file", "flags.yaml", "--forward-events"},
+		)
+
+		require.Error(t, err)
+		assert.False(t, mockClient.RunServerCalled)
+	})
+
+	t.Run("passes TLS to RunServer", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--tls"),
+		)
+
+		require.NoError(t, err)
+		assert.True(t, mockClient.RunServerParams.TLS)
+		assert.Empty(t, mockClient.RunServerParams.TLSCertFile)
+		assert.False(t, mockClient.RunServerParams.TLSAllowPlain)
+	})
+
+	t.Run("passes the plain HTTP fallback to RunServer", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--tls", "--tls-allow-plain"),
+		)
+
+		require.NoError(t, err)
+		assert.True(t, mockClient.RunServerParams.TLS)
+		assert.True(t, mockClient.RunServerParams.TLSAllowPlain)
+	})
+
+	t.Run("returns error for the plain HTTP fallback without TLS", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--tls-allow-plain"),
+		)
+
+		require.Error(t, err)
+		assert.False(t, mockClient.RunServerCalled)
+	})
+
+	t.Run("serves TLS with a given certificate and key", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--tls-cert", "cert.pem", "--tls-key", "key.pem"),
+		)
+
+		require.NoError(t, err)
+		assert.True(t, mockClient.RunServerParams.TLS)
+		assert.Equal(t, "cert.pem", mockClient.RunServerParams.TLSCertFile)
+		assert.Equal(t, "key.pem", mockClient.RunServerParams.TLSKeyFile)
+	})
+
+	t.Run("returns error for a certificate without a key", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--tls-cert", "cert.pem"),
+		)
+
+		require.Error(t, err)
+		assert.False(t, mockClient.RunServerCalled)
+	})
+
+	t.Run("passes ephemeral and data dir to RunServer", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--ephemeral"),
+		)
+
+		require.NoError(t, err)
+		assert.True(t, mockClient.RunServerParams.Ephemeral)
+		assert.DirExists(t, mockClient.RunServerParams.StateDir, "ephemeral servers get a state directory of their own")
+		_ = os.RemoveAll(mockClient.RunServerParams.StateDir)
+
+		_, err = cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--data-dir", "ci-workspace"),
+		)
+
+		require.NoError(t, err)
+		assert.False(t, mockClient.RunServerParams.Ephemeral)
+		assert.Equal(t, "ci-workspace", mockClient.RunServerParams.DataDir)
+		assert.Equal(t, "ci-workspace", mockClient.RunServerParams.StateDir)
+	})
+
+	t.Run("returns error for ephemeral with a data dir", func(t *testing.T) {
+		mockClient := &dev_server.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{DevClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			append(baseArgs, "--ephemeral", "--data-dir", "ci-workspace"),
+		)
+
+		require.Error(t, err)
+		assert.False(t, mockClient.RunServerCalled)
+	})
 }
@@ -0,0 +1,82 @@
+package dev_server
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/internal/dev_server/certs"
+)
+
+func NewTrustInfoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "server",
+		Args:    validateWithoutAccessToken(),
+		Long: `show the local CA that issues the dev server's certificate with --tls, and how to trust it on devices and in
+browsers. The CA is created if it doesn't exist yet, so it can be installed before the server first starts.
+
+Examples:
+  # Copy the CA somewhere a device can download it from
+  ldcli dev-server trust-info --export ~/Downloads/ldcli-dev-server.crt
+
+  # Print the CA certificate
+  ldcli dev-server trust-info --export -`,
+		RunE:  trustInfo(),
+		Short: "show how to trust the dev server's HTTPS certificate",
+		Use:   "trust-info",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().String(TrustInfoExportFlag, "", "Write the PEM encoded CA certificate to this file, or to stdout for -")
+	_ = viper.BindPFlag(TrustInfoExportFlag, cmd.Flags().Lookup(TrustInfoExportFlag))
+
+	cmd.Flags().String(DataDirFlag, "", ServerDataDirDescription)
+	_ = viper.BindPFlag(DataDirFlag, cmd.Flags().Lookup(DataDirFlag))
+
+	return cmd
+}
+
+func trustInfo() func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		dir, err := certs.Dir(viper.GetString(DataDirFlag))
+		if err != nil {
+			return err
+		}
+		ca, err := certs.LoadOrCreateCA(dir)
+		if err != nil {
+			return err
+		}
+
+		switch export := viper.GetString(TrustInfoExportFlag); export {
+		case "":
+		case "-":
+			_, err = cmd.OutOrStdout().Write(ca.PEM())
+			return err
+		default:
+			if err := os.WriteFile(export, ca.PEM(), 0o644); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "CA certificate written to %s\n\n", export)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), `CA certificate: %[1]s
+SHA-256 fingerprint: %[2]s
+
+To trust it:
+  macOS    sudo security add-trusted-cert -d -r trustRoot -k /Library/Keychains/System.keychain %[1]s
+  Linux    sudo cp %[1]s /usr/local/share/ca-certificates/ldcli-dev-server.crt && sudo update-ca-certificates
+  Windows  certutil -addstore -user Root %[1]s
+  iOS      open the certificate on the device to install its profile, then turn on full trust for it under
+           Settings > General > About > Certificate Trust Settings
+  Android  install it under Settings > Security > Encryption & credentials, and allow user CAs in the app's debug
+           network security config
+  Firefox  import it under Settings > Privacy & Security > Certificates, as Firefox doesn't use the system store
+`, ca.Path(), ca.Fingerprint())
+
+		return nil
+	}
+}
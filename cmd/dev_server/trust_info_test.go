@@ -0,0 +1,50 @@
+package dev_server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+)
+
+func TestTrustInfoCmd(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	t.Run("prints the CA and how to trust it", func(t *testing.T) {
+		out, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "trust-info"},
+		)
+
+		require.NoError(t, err)
+		assert.Contains(t, string(out), filepath.Join(xdg.StateHome, "ldcli", "tls", "ca.pem"))
+		assert.Contains(t, string(out), "SHA-256 fingerprint: ")
+	})
+
+	t.Run("exports the CA certificate", func(t *testing.T) {
+		export := filepath.Join(t.TempDir(), "ca.crt")
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "trust-info", "--export", export},
+		)
+
+		require.NoError(t, err)
+		exported, err := os.ReadFile(export)
+		require.NoError(t, err)
+		installed, err := os.ReadFile(filepath.Join(xdg.StateHome, "ldcli", "tls", "ca.pem"))
+		require.NoError(t, err)
+		assert.Equal(t, installed, exported)
+	})
+}
@@ -0,0 +1,156 @@
+package dev_server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/launchdarkly/ldcli/cmd/cliflags"
+	resourcescmd "github.com/launchdarkly/ldcli/cmd/resources"
+	"github.com/launchdarkly/ldcli/cmd/validators"
+	"github.com/launchdarkly/ldcli/internal/output"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func NewWebhooksCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		GroupID: "projects",
+		Long:    "post a project's overrides, syncs, imports and deletion to URLs as they happen. Deliveries are signed with an HMAC-SHA256 of the body, keyed with the webhook's secret, in the X-LDCLI-Signature header.",
+		Short:   "manage webhooks",
+		Use:     "webhooks",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	cmd.AddCommand(newListWebhooksCmd(client))
+	cmd.AddCommand(newAddWebhookCmd(client))
+	cmd.AddCommand(newRemoveWebhookCmd(client))
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	return cmd
+}
+
+func newListWebhooksCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "list the webhooks of every project, or of one project with --project, oldest first. Secrets are only shown when a webhook is added.",
+		RunE:  listWebhooks(client),
+		Short: "list webhooks",
+		Use:   "list",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().String(cliflags.ProjectFlag, "", "Only list the webhooks of this project")
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+
+	return cmd
+}
+
+func listWebhooks(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		query := url.Values{}
+		if projectKey := viper.GetString(cliflags.ProjectFlag); projectKey != "" {
+			query.Set("projectKey", projectKey)
+		}
+		res, err := client.MakeRequest("", "GET", webhooksPath(), "application/json", query, nil, false)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func newAddWebhookCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "register a URL to post the project's state changes to. The response has the secret that deliveries are signed with.",
+		RunE:  addWebhook(client),
+		Short: "add a webhook",
+		Use:   "add",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().String(cliflags.ProjectFlag, "", "The project key")
+	_ = cmd.MarkFlagRequired(cliflags.ProjectFlag)
+	_ = cmd.Flags().SetAnnotation(cliflags.ProjectFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(cliflags.ProjectFlag, cmd.Flags().Lookup(cliflags.ProjectFlag))
+
+	cmd.Flags().String(WebhookURLFlag, "", "The URL to post to")
+	_ = cmd.MarkFlagRequired(WebhookURLFlag)
+	_ = cmd.Flags().SetAnnotation(WebhookURLFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(WebhookURLFlag, cmd.Flags().Lookup(WebhookURLFlag))
+
+	cmd.Flags().String(WebhookSecretFlag, "", "The key to sign deliveries with. One is generated without it.")
+	_ = viper.BindPFlag(WebhookSecretFlag, cmd.Flags().Lookup(WebhookSecretFlag))
+
+	return cmd
+}
+
+func addWebhook(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		webhook := map[string]string{
+			"projectKey": viper.GetString(cliflags.ProjectFlag),
+			"url":        viper.GetString(WebhookURLFlag),
+		}
+		if secret := viper.GetString(WebhookSecretFlag); secret != "" {
+			webhook["secret"] = secret
+		}
+		body, err := json.Marshal(webhook)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		res, err := client.MakeUnauthenticatedRequest("POST", webhooksPath(), body)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func newRemoveWebhookCmd(client resources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  validators.Validate(),
+		Long:  "stop posting to a webhook",
+		RunE:  removeWebhook(client),
+		Short: "remove a webhook",
+		Use:   "remove",
+	}
+
+	cmd.SetUsageTemplate(resourcescmd.SubcommandUsageTemplate())
+
+	cmd.Flags().String(WebhookIDFlag, "", "The webhook ID")
+	_ = cmd.MarkFlagRequired(WebhookIDFlag)
+	_ = cmd.Flags().SetAnnotation(WebhookIDFlag, "required", []string{"true"})
+	_ = viper.BindPFlag(WebhookIDFlag, cmd.Flags().Lookup(WebhookIDFlag))
+
+	return cmd
+}
+
+func removeWebhook(client resources.Client) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		path := fmt.Sprintf("%s/%s", webhooksPath(), url.PathEscape(viper.GetString(WebhookIDFlag)))
+		res, err := client.MakeUnauthenticatedRequest("DELETE", path, nil)
+		if err != nil {
+			return output.NewCmdOutputError(err, cliflags.GetOutputKind(cmd))
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(res))
+
+		return nil
+	}
+}
+
+func webhooksPath() string {
+	return fmt.Sprintf("%s/dev/webhooks", getDevServerUrl())
+}
@@ -0,0 +1,55 @@
+package dev_server_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/cmd"
+	"github.com/launchdarkly/ldcli/internal/analytics"
+	"github.com/launchdarkly/ldcli/internal/resources"
+)
+
+func TestWebhooksAddCmd(t *testing.T) {
+	t.Run("sends the project, url and secret", func(t *testing.T) {
+		mockClient := &resources.MockClient{Response: []byte(`{"id":"1"}`)}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{
+				"dev-server", "webhooks", "add", "--access-token", "test-token", "--project", "test-proj",
+				"--url", "http://localhost:9000/hook", "--secret", "shh",
+			},
+		)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"projectKey":"test-proj","url":"http://localhost:9000/hook","secret":"shh"}`, string(mockClient.Input))
+	})
+
+	t.Run("returns error without a url", func(t *testing.T) {
+		mockClient := &resources.MockClient{}
+		_, err := cmd.CallCmd(
+			t,
+			cmd.APIClients{ResourcesClient: mockClient},
+			analytics.NoopClientFn{}.Tracker(),
+			[]string{"dev-server", "webhooks", "add", "--access-token", "test-token", "--project", "test-proj"},
+		)
+
+		require.Error(t, err)
+	})
+}
+
+func TestWebhooksListCmd(t *testing.T) {
+	mockClient := &resources.MockClient{Response: []byte(`[]`)}
+	_, err := cmd.CallCmd(
+		t,
+		cmd.APIClients{ResourcesClient: mockClient},
+		analytics.NoopClientFn{}.Tracker(),
+		[]string{"dev-server", "webhooks", "list", "--access-token", "test-token", "--project", "test-proj"},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "test-proj", mockClient.Query.Get("projectKey"))
+}
@@ -24,10 +24,18 @@ func GetApi(ctx context.Context) Api {
 //go:generate go run go.uber.org/mock/mockgen -destination mocks/api.go -package mocks . Api
 type Api interface {
 	GetSdkKey(ctx context.Context, projectKey, environmentKey string) (string, error)
+	GetEnvironmentCredentials(ctx context.Context, projectKey, environmentKey string) (EnvironmentCredentials, error)
 	GetAllFlags(ctx context.Context, projectKey string) ([]ldapi.FeatureFlag, error)
 	GetProjectEnvironments(ctx context.Context, projectKey string, query string, limit *int) ([]ldapi.Environment, error)
 }
 
+// EnvironmentCredentials are the credentials SDKs use to connect to an environment.
+type EnvironmentCredentials struct {
+	SdkKey       string
+	MobileKey    string
+	ClientSideID string
+}
+
 type apiClientApi struct {
 	apiClient ldapi.APIClient
 }
@@ -45,6 +53,19 @@ func (a apiClientApi) GetSdkKey(ctx context.Context, projectKey, environmentKey
 	return environment.ApiKey, nil
 }
 
+func (a apiClientApi) GetEnvironmentCredentials(ctx context.Context, projectKey, environmentKey string) (EnvironmentCredentials, error) {
+	log.Printf("GetEnvironmentCredentials - projectKey: %s, environmentKey: %s", projectKey, environmentKey)
+	environment, _, err := a.apiClient.EnvironmentsApi.GetEnvironment(ctx, projectKey, environmentKey).Execute()
+	if err != nil {
+		return EnvironmentCredentials{}, errors.Wrap(err, "unable to get environment credentials from LD API")
+	}
+	return EnvironmentCredentials{
+		SdkKey:       environment.ApiKey,
+		MobileKey:    environment.MobileKey,
+		ClientSideID: environment.Id,
+	}, nil
+}
+
 func (a apiClientApi) GetAllFlags(ctx context.Context, projectKey string) ([]ldapi.FeatureFlag, error) {
 	log.Printf("Fetching all flags for project '%s'", projectKey)
 	flags, err := a.getFlags(ctx, projectKey)
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	ldapi "github.com/launchdarkly/api-client-go/v14"
+	adapters "github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -56,6 +57,21 @@ func (mr *MockApiMockRecorder) GetAllFlags(ctx, projectKey any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllFlags", reflect.TypeOf((*MockApi)(nil).GetAllFlags), ctx, projectKey)
 }
 
+// GetEnvironmentCredentials mocks base method.
+func (m *MockApi) GetEnvironmentCredentials(ctx context.Context, projectKey, environmentKey string) (adapters.EnvironmentCredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvironmentCredentials", ctx, projectKey, environmentKey)
+	ret0, _ := ret[0].(adapters.EnvironmentCredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnvironmentCredentials indicates an expected call of GetEnvironmentCredentials.
+func (mr *MockApiMockRecorder) GetEnvironmentCredentials(ctx, projectKey, environmentKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironmentCredentials", reflect.TypeOf((*MockApi)(nil).GetEnvironmentCredentials), ctx, projectKey, environmentKey)
+}
+
 // GetProjectEnvironments mocks base method.
 func (m *MockApi) GetProjectEnvironments(ctx context.Context, projectKey, query string, limit *int) ([]ldapi.Environment, error) {
 	m.ctrl.T.Helper()
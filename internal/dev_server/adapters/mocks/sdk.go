@@ -13,8 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
-	ldcontext "github.com/launchdarkly/go-sdk-common/v3/ldcontext"
-	flagstate "github.com/launchdarkly/go-server-sdk/v7/interfaces/flagstate"
+	adapters "github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,17 +41,31 @@ func (m *MockSdk) EXPECT() *MockSdkMockRecorder {
 	return m.recorder
 }
 
-// GetAllFlagsState mocks base method.
-func (m *MockSdk) GetAllFlagsState(ctx context.Context, ldContext ldcontext.Context, sdkKey string) (flagstate.AllFlags, error) {
+// GetFlagsData mocks base method.
+func (m *MockSdk) GetFlagsData(ctx context.Context, sdkKey string) (adapters.FlagsData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllFlagsState", ctx, ldContext, sdkKey)
-	ret0, _ := ret[0].(flagstate.AllFlags)
+	ret := m.ctrl.Call(m, "GetFlagsData", ctx, sdkKey)
+	ret0, _ := ret[0].(adapters.FlagsData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllFlagsState indicates an expected call of GetAllFlagsState.
-func (mr *MockSdkMockRecorder) GetAllFlagsState(ctx, ldContext, sdkKey any) *gomock.Call {
+// GetFlagsData indicates an expected call of GetFlagsData.
+func (mr *MockSdkMockRecorder) GetFlagsData(ctx, sdkKey any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllFlagsState", reflect.TypeOf((*MockSdk)(nil).GetAllFlagsState), ctx, ldContext, sdkKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlagsData", reflect.TypeOf((*MockSdk)(nil).GetFlagsData), ctx, sdkKey)
+}
+
+// StreamFlagsData mocks base method.
+func (m *MockSdk) StreamFlagsData(ctx context.Context, sdkKey string, onChange func(adapters.FlagsData)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamFlagsData", ctx, sdkKey, onChange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamFlagsData indicates an expected call of StreamFlagsData.
+func (mr *MockSdkMockRecorder) StreamFlagsData(ctx, sdkKey, onChange any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamFlagsData", reflect.TypeOf((*MockSdk)(nil).StreamFlagsData), ctx, sdkKey, onChange)
 }
@@ -3,6 +3,9 @@ package mocks
 import (
 	"context"
 
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldbuilders"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
 	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	"go.uber.org/mock/gomock"
 )
@@ -15,3 +18,17 @@ func WithMockApiAndSdk(ctx context.Context, controller *gomock.Controller) (cont
 
 	return ctx, api, sdk
 }
+
+// FlagsDataFromValues builds flag configurations that serve the given value to every context.
+func FlagsDataFromValues(values map[string]ldvalue.Value, version int) adapters.FlagsData {
+	flags := make(map[string]ldmodel.FeatureFlag, len(values))
+	for key, value := range values {
+		flags[key] = ldbuilders.NewFlagBuilder(key).
+			Version(version).
+			On(true).
+			Variations(value).
+			FallthroughVariation(0).
+			Build()
+	}
+	return adapters.FlagsData{Flags: flags, Segments: map[string]ldmodel.Segment{}}
+}
@@ -0,0 +1,63 @@
+package adapters
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	ldapi "github.com/launchdarkly/api-client-go/v14"
+)
+
+// ErrOffline is returned in place of every LaunchDarkly call while the dev server is in offline mode.
+var ErrOffline = errors.New("the dev server is in offline mode: projects are seeded from a local flag file and no " +
+	"access token is used, so LaunchDarkly can't be reached. Restart the dev server without an offline file to sync")
+
+// WithOffline puts adapters on the context that never call LaunchDarkly.
+func WithOffline(ctx context.Context) context.Context {
+	ctx = WithSdk(ctx, offlineSdk{})
+	ctx = WithApi(ctx, offlineApi{})
+	return ctx
+}
+
+// OfflineMiddleware is the offline mode counterpart of Middleware.
+func OfflineMiddleware() func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			request = request.WithContext(WithOffline(request.Context()))
+			handler.ServeHTTP(writer, request)
+		})
+	}
+}
+
+type offlineApi struct{}
+
+var _ Api = offlineApi{}
+
+func (offlineApi) GetSdkKey(ctx context.Context, projectKey, environmentKey string) (string, error) {
+	return "", ErrOffline
+}
+
+func (offlineApi) GetEnvironmentCredentials(ctx context.Context, projectKey, environmentKey string) (EnvironmentCredentials, error) {
+	return EnvironmentCredentials{}, ErrOffline
+}
+
+func (offlineApi) GetAllFlags(ctx context.Context, projectKey string) ([]ldapi.FeatureFlag, error) {
+	return nil, ErrOffline
+}
+
+func (offlineApi) GetProjectEnvironments(ctx context.Context, projectKey string, query string, limit *int) ([]ldapi.Environment, error) {
+	return nil, ErrOffline
+}
+
+type offlineSdk struct{}
+
+var _ Sdk = offlineSdk{}
+
+func (offlineSdk) GetFlagsData(ctx context.Context, sdkKey string) (FlagsData, error) {
+	return FlagsData{}, ErrOffline
+}
+
+func (offlineSdk) StreamFlagsData(ctx context.Context, sdkKey string, onChange func(FlagsData)) error {
+	return ErrOffline
+}
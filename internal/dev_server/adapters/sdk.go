@@ -5,11 +5,13 @@ import (
 	"log"
 	"time"
 
-	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
 	"github.com/launchdarkly/go-sdk-common/v3/ldlog"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
 	ldsdk "github.com/launchdarkly/go-server-sdk/v7"
-	"github.com/launchdarkly/go-server-sdk/v7/interfaces/flagstate"
+	"github.com/launchdarkly/go-server-sdk/v7/interfaces"
 	"github.com/launchdarkly/go-server-sdk/v7/ldcomponents"
+	"github.com/launchdarkly/go-server-sdk/v7/subsystems"
+	"github.com/launchdarkly/go-server-sdk/v7/subsystems/ldstoreimpl"
 	"github.com/pkg/errors"
 )
 
@@ -23,9 +25,18 @@ func GetSdk(ctx context.Context) Sdk {
 	return ctx.Value(ctxKeySdk).(Sdk)
 }
 
+// FlagsData is the full flag and segment configuration of an environment, as delivered to server-side SDKs.
+type FlagsData struct {
+	Flags    map[string]ldmodel.FeatureFlag `json:"flags"`
+	Segments map[string]ldmodel.Segment     `json:"segments"`
+}
+
 //go:generate go run go.uber.org/mock/mockgen -destination mocks/sdk.go -package mocks . Sdk
 type Sdk interface {
-	GetAllFlagsState(ctx context.Context, ldContext ldcontext.Context, sdkKey string) (flagstate.AllFlags, error)
+	GetFlagsData(ctx context.Context, sdkKey string) (FlagsData, error)
+	// StreamFlagsData keeps an SDK connection open until ctx is done. onChange is called with the initial
+	// configuration and again with the full configuration whenever flags change upstream.
+	StreamFlagsData(ctx context.Context, sdkKey string, onChange func(FlagsData)) error
 }
 
 type streamingSdk struct {
@@ -38,8 +49,9 @@ func newSdk(streamingUrl string) Sdk {
 	}
 }
 
-func (s streamingSdk) GetAllFlagsState(ctx context.Context, ldContext ldcontext.Context, sdkKey string) (flagstate.AllFlags, error) {
+func (s streamingSdk) config(store *capturingDataStore) ldsdk.Config {
 	config := ldsdk.Config{
+		DataStore:        store,
 		DiagnosticOptOut: true,
 		Events:           ldcomponents.NoEvents(),
 		Logging:          ldcomponents.Logging().MinLevel(ldlog.Debug),
@@ -47,16 +59,126 @@ func (s streamingSdk) GetAllFlagsState(ctx context.Context, ldContext ldcontext.
 	if s.streamingUrl != "" {
 		config.ServiceEndpoints.Streaming = s.streamingUrl
 	}
+	return config
+}
+
+func (s streamingSdk) GetFlagsData(ctx context.Context, sdkKey string) (FlagsData, error) {
+	store := &capturingDataStore{}
+	ldClient, err := ldsdk.MakeCustomClient(sdkKey, s.config(store), 5*time.Second)
+	if err != nil {
+		closeFailedClient(ldClient)
+		return FlagsData{}, errors.Wrap(err, "unable to get source flags from LD SDK")
+	}
+	defer closeClient(ldClient)
+	return store.flagsData()
+}
+
+func (s streamingSdk) StreamFlagsData(ctx context.Context, sdkKey string, onChange func(FlagsData)) error {
+	store := &capturingDataStore{}
+	config := s.config(store)
+	config.Logging = ldcomponents.Logging().MinLevel(ldlog.Info)
 	ldClient, err := ldsdk.MakeCustomClient(sdkKey, config, 5*time.Second)
 	if err != nil {
-		return flagstate.AllFlags{}, errors.Wrap(err, "unable to get source flags from LD SDK")
+		closeFailedClient(ldClient)
+		return errors.Wrap(err, "unable to stream source flags from LD SDK")
+	}
+	tracker := ldClient.GetFlagTracker()
+	changes := tracker.AddFlagChangeListener()
+	data, err := store.flagsData()
+	if err != nil {
+		tracker.RemoveFlagChangeListener(changes)
+		closeClient(ldClient)
+		return err
 	}
-	defer func() {
-		err := ldClient.Close()
-		if err != nil {
-			log.Printf("error while closing SDK client: %+v", err)
+	onChange(data)
+
+	go func() {
+		defer closeClient(ldClient)
+		defer tracker.RemoveFlagChangeListener(changes)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-changes:
+				if !ok {
+					return
+				}
+				// A single upstream update emits one event per affected flag; read the configuration once for all of them.
+				drainFlagChanges(changes)
+				data, err := store.flagsData()
+				if err != nil {
+					log.Printf("unable to read streamed flags: %+v", err)
+					continue
+				}
+				onChange(data)
+			}
 		}
 	}()
-	flags := ldClient.AllFlagsState(ldContext)
-	return flags, nil
+	return nil
+}
+
+func drainFlagChanges(changes <-chan interfaces.FlagChangeEvent) {
+	for {
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func closeClient(ldClient *ldsdk.LDClient) {
+	err := ldClient.Close()
+	if err != nil {
+		log.Printf("error while closing SDK client: %+v", err)
+	}
+}
+
+// closeFailedClient closes a client that failed to initialize. The SDK still returns one when initialization times out
+// or fails, and it keeps connecting in the background until it is closed.
+func closeFailedClient(ldClient *ldsdk.LDClient) {
+	if ldClient != nil {
+		closeClient(ldClient)
+	}
+}
+
+// capturingDataStore builds the SDK's default in-memory data store and keeps a reference to it, so that the raw flag
+// and segment configuration can be read back out once the client has initialized.
+type capturingDataStore struct {
+	store subsystems.DataStore
+}
+
+func (c *capturingDataStore) Build(clientContext subsystems.ClientContext) (subsystems.DataStore, error) {
+	store, err := ldcomponents.InMemoryDataStore().Build(clientContext)
+	c.store = store
+	return store, err
+}
+
+func (c *capturingDataStore) flagsData() (FlagsData, error) {
+	data := FlagsData{
+		Flags:    make(map[string]ldmodel.FeatureFlag),
+		Segments: make(map[string]ldmodel.Segment),
+	}
+	flags, err := c.store.GetAll(ldstoreimpl.Features())
+	if err != nil {
+		return FlagsData{}, errors.Wrap(err, "unable to read flags from LD SDK")
+	}
+	for _, flag := range flags {
+		if flag, ok := flag.Item.Item.(*ldmodel.FeatureFlag); ok && flag != nil {
+			data.Flags[flag.Key] = *flag
+		}
+	}
+	segments, err := c.store.GetAll(ldstoreimpl.Segments())
+	if err != nil {
+		return FlagsData{}, errors.Wrap(err, "unable to read segments from LD SDK")
+	}
+	for _, segment := range segments {
+		if segment, ok := segment.Item.Item.(*ldmodel.Segment); ok && segment != nil {
+			data.Segments[segment.Key] = *segment
+		}
+	}
+	return data, nil
 }
@@ -1,6 +1,10 @@
 package api
 
-import "github.com/launchdarkly/ldcli/internal/dev_server/model"
+import (
+	"time"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
 
 func availableVariationsToResponseFormat(availableVariations map[string][]model.Variation) map[string][]Variation {
 	respAvailableVariations := make(map[string][]Variation, len(availableVariations))
@@ -18,3 +22,90 @@ func availableVariationsToResponseFormat(availableVariations map[string][]model.
 	}
 	return respAvailableVariations
 }
+
+func scenarioToResponseFormat(scenario model.Scenario) Scenario {
+	return Scenario{
+		Name:      scenario.Name,
+		Overrides: scenario.Overrides,
+	}
+}
+
+func snapshotToResponseFormat(snapshot model.Snapshot) Snapshot {
+	return Snapshot{
+		Name:       snapshot.Name,
+		CreatedAt:  snapshot.CreatedAt,
+		Context:    snapshot.Context,
+		FlagsState: snapshot.AllFlagsState,
+		Overrides:  overridesToResponseFormat(snapshot.Overrides),
+	}
+}
+
+func overridesToResponseFormat(overrides model.Overrides) model.OverridesState {
+	now := time.Now()
+	respOverrides := make(model.OverridesState)
+	for _, override := range overrides {
+		if !override.Active {
+			continue
+		}
+		state := model.OverrideState{
+			FlagState: model.FlagState{
+				Value:   override.Value,
+				Version: override.Version,
+			},
+		}
+		if !override.ExpiresAt.IsZero() {
+			state.ExpiresAt = &override.ExpiresAt
+			state.ExpiresIn = override.ExpiresAt.Sub(now).Round(time.Second).String()
+		}
+		if override.ContextMatcher != nil {
+			state.ContextMatcher = override.ContextMatcher.String()
+		}
+		respOverrides[override.FlagKey] = state
+	}
+	return respOverrides
+}
+
+func projectEnvironmentsToResponseFormat(environments []model.Project) []ProjectEnvironment {
+	respEnvironments := make([]ProjectEnvironment, 0, len(environments))
+	for _, environment := range environments {
+		respEnvironments = append(respEnvironments, ProjectEnvironment{
+			ProjectKey:           environment.Key,
+			SourceEnvironmentKey: environment.SourceEnvironmentKey,
+			LastSyncedFromSource: environment.LastSyncTime.Unix(),
+		})
+	}
+	return respEnvironments
+}
+
+func historyToResponseFormat(history []model.HistoryEntry) []HistoryEntry {
+	respHistory := make([]HistoryEntry, 0, len(history))
+	for _, entry := range history {
+		respEntry := HistoryEntry{
+			Id:        entry.ID,
+			ChangeId:  entry.ChangeID,
+			Action:    string(entry.Action),
+			OldValue:  entry.OldValue,
+			NewValue:  entry.NewValue,
+			Source:    string(entry.Source),
+			Timestamp: entry.Timestamp,
+		}
+		if entry.FlagKey != "" {
+			respEntry.FlagKey = &entry.FlagKey
+		}
+		if entry.Reverts != 0 {
+			respEntry.Reverts = &entry.Reverts
+		}
+		respHistory = append(respHistory, respEntry)
+	}
+	return respHistory
+}
+
+// webhookToResponseFormat leaves out the webhook's secret, which is only returned when the webhook is created.
+func webhookToResponseFormat(webhook model.Webhook) Webhook {
+	return Webhook{
+		Id:         webhook.ID,
+		ProjectKey: webhook.ProjectKey,
+		Url:        webhook.URL,
+		CreatedAt:  webhook.CreatedAt,
+	}
+}
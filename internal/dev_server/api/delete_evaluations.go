@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) DeleteEvaluations(ctx context.Context, request DeleteEvaluationsRequestObject) (DeleteEvaluationsResponseObject, error) {
+	store := model.StoreFromContext(ctx)
+	_, err := store.GetDevProject(ctx, request.ProjectKey)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return DeleteEvaluations404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	model.EvaluationsFromContext(ctx).Clear(request.ProjectKey)
+	return DeleteEvaluations204Response{}, nil
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) DeleteFaults(ctx context.Context, request DeleteFaultsRequestObject) (DeleteFaultsResponseObject, error) {
+	store := model.StoreFromContext(ctx)
+	_, err := store.GetDevProject(ctx, request.ProjectKey)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return DeleteFaults404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	model.FaultsFromContext(ctx).Clear(request.ProjectKey)
+	return DeleteFaults204Response{}, nil
+}
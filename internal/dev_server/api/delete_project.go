@@ -7,8 +7,7 @@ import (
 )
 
 func (s server) DeleteProject(ctx context.Context, request DeleteProjectRequestObject) (DeleteProjectResponseObject, error) {
-	store := model.StoreFromContext(ctx)
-	deleted, err := store.DeleteDevProject(ctx, request.ProjectKey)
+	deleted, err := model.DeleteProject(ctx, request.ProjectKey)
 	if err != nil {
 		return nil, err
 	}
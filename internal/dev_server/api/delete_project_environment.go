@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) DeleteProjectEnvironment(ctx context.Context, request DeleteProjectEnvironmentRequestObject) (DeleteProjectEnvironmentResponseObject, error) {
+	deleted, err := model.DeleteProject(ctx, model.EnvironmentProjectKey(request.ProjectKey, request.EnvironmentKey))
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return DeleteProjectEnvironment404JSONResponse{ErrorResponseJSONResponse{
+			Code:    "not_found",
+			Message: "environment not found",
+		}}, nil
+	}
+	return DeleteProjectEnvironment204Response{}, nil
+}
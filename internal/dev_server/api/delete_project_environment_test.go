@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func TestDeleteProjectEnvironment(t *testing.T) {
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx := model.ContextWithStore(context.Background(), store)
+	observers := model.NewObservers()
+	ctx = model.SetObserversOnContext(ctx, observers)
+	faults := model.NewFaults()
+	observers.RegisterObserver(faults)
+	webhooks := model.NewWebhookDispatcher()
+	observers.RegisterObserver(webhooks)
+	runCtx, cancel := context.WithCancel(ctx)
+	t.Cleanup(cancel)
+	go webhooks.Run(runCtx)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(receiver.Close)
+	webhook, err := model.NewWebhook("proj:staging", receiver.URL, "secret")
+	require.NoError(t, err)
+
+	t.Run("removes the environment's faults and webhooks", func(t *testing.T) {
+		require.NoError(t, faults.Add("proj:staging", model.Fault{Kind: model.FaultError, Probability: 1, Status: 500}))
+		store.EXPECT().DeleteDevProjects(gomock.Any(), []string{"proj:staging"}).Return([]string{"proj:staging"}, nil)
+		store.EXPECT().GetWebhooks(gomock.Any(), "proj:staging").Return([]model.Webhook{webhook}, nil)
+		webhookDeleted := make(chan struct{})
+		store.EXPECT().DeleteWebhook(gomock.Any(), webhook.ID).DoAndReturn(func(ctx context.Context, id string) (bool, error) {
+			close(webhookDeleted)
+			return true, nil
+		})
+
+		response, err := server{}.DeleteProjectEnvironment(ctx, DeleteProjectEnvironmentRequestObject{
+			ProjectKey:     "proj",
+			EnvironmentKey: "staging",
+		})
+		require.NoError(t, err)
+		assert.IsType(t, DeleteProjectEnvironment204Response{}, response)
+		assert.Empty(t, faults.ForProject("proj:staging"))
+		select {
+		case <-webhookDeleted:
+		case <-time.After(time.Second):
+			t.Fatal("the environment's webhook wasn't removed")
+		}
+	})
+
+	t.Run("reports environments that don't exist", func(t *testing.T) {
+		store.EXPECT().DeleteDevProjects(gomock.Any(), []string{"proj:test"}).Return(nil, nil)
+
+		response, err := server{}.DeleteProjectEnvironment(ctx, DeleteProjectEnvironmentRequestObject{
+			ProjectKey:     "proj",
+			EnvironmentKey: "test",
+		})
+		require.NoError(t, err)
+		assert.IsType(t, DeleteProjectEnvironment404JSONResponse{}, response)
+	})
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"context"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) DeleteScenario(ctx context.Context, request DeleteScenarioRequestObject) (DeleteScenarioResponseObject, error) {
+	store := model.StoreFromContext(ctx)
+	deleted, err := store.DeleteScenario(ctx, request.ProjectKey, request.ScenarioName)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return DeleteScenario404JSONResponse{ErrorResponseJSONResponse{
+			Code:    "not_found",
+			Message: "scenario not found",
+		}}, nil
+	}
+	return DeleteScenario204Response{}, nil
+}
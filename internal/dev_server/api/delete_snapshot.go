@@ -0,0 +1,22 @@
+package api
+
+import (
+	"context"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) DeleteSnapshot(ctx context.Context, request DeleteSnapshotRequestObject) (DeleteSnapshotResponseObject, error) {
+	store := model.StoreFromContext(ctx)
+	deleted, err := store.DeleteSnapshot(ctx, request.ProjectKey, request.SnapshotName)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return DeleteSnapshot404JSONResponse{ErrorResponseJSONResponse{
+			Code:    "not_found",
+			Message: "snapshot not found",
+		}}, nil
+	}
+	return DeleteSnapshot204Response{}, nil
+}
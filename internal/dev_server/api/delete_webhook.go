@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) DeleteWebhook(ctx context.Context, request DeleteWebhookRequestObject) (DeleteWebhookResponseObject, error) {
+	deleted, err := model.StoreFromContext(ctx).DeleteWebhook(ctx, request.WebhookId)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return DeleteWebhook404JSONResponse{ErrorResponseJSONResponse{
+			Code:    "not_found",
+			Message: "webhook not found",
+		}}, nil
+	}
+	return DeleteWebhook204Response{}, nil
+}
@@ -16,10 +16,10 @@ import (
 type sdkEventObserver struct {
 	ctx             context.Context
 	debugSessionKey string
-	updateChan      chan<- []byte
+	updateChan      chan<- sdk.StreamUpdate
 }
 
-func newSdkEventObserver(updateChan chan<- []byte, ctx context.Context) sdkEventObserver {
+func newSdkEventObserver(updateChan chan<- sdk.StreamUpdate, ctx context.Context) sdkEventObserver {
 	debugSessionKey := uuid.New().String()
 	db := model.EventStoreFromContext(ctx)
 	err := db.CreateDebugSession(ctx, debugSessionKey)
@@ -54,13 +54,13 @@ func (o sdkEventObserver) Handle(message interface{}) {
 		return
 	}
 
-	o.updateChan <- sdk.Message{Event: sdk.TYPE_PUT, Data: str}.ToPayload()
+	o.updateChan <- sdk.StreamUpdate{Payload: sdk.Message{Event: sdk.TYPE_PUT, Data: str}.ToPayload()}
 }
 
 func SdkEventsTeeHandler(writer http.ResponseWriter, request *http.Request) {
 	updateChan, errChan := sdk.OpenStream(
+		request.Context(),
 		writer,
-		request.Context().Done(),
 		sdk.Message{Event: sdk.TYPE_PUT, Data: []byte{}}.ToPayload(),
 	)
 	defer close(updateChan)
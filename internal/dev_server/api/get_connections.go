@@ -0,0 +1,15 @@
+package api
+
+import (
+	"context"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) GetConnections(ctx context.Context, request GetConnectionsRequestObject) (GetConnectionsResponseObject, error) {
+	var projectKey string
+	if request.Params.ProjectKey != nil {
+		projectKey = *request.Params.ProjectKey
+	}
+	return GetConnections200JSONResponse(model.ConnectionsFromContext(ctx).List(projectKey)), nil
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) GetEvaluations(ctx context.Context, request GetEvaluationsRequestObject) (GetEvaluationsResponseObject, error) {
+	store := model.StoreFromContext(ctx)
+	_, err := store.GetDevProject(ctx, request.ProjectKey)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return GetEvaluations404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	evaluations := model.EvaluationsFromContext(ctx).ForProject(request.ProjectKey)
+	return GetEvaluations200JSONResponse(evaluations), nil
+}
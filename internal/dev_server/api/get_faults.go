@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) GetFaults(ctx context.Context, request GetFaultsRequestObject) (GetFaultsResponseObject, error) {
+	store := model.StoreFromContext(ctx)
+	_, err := store.GetDevProject(ctx, request.ProjectKey)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return GetFaults404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	faults := model.FaultsFromContext(ctx).ForProject(request.ProjectKey)
+	return GetFaults200JSONResponse(faults), nil
+}
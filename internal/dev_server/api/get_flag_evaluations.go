@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+// GetFlagEvaluations reports a flag of the project that no SDK has evaluated with a count of zero. Flags that aren't
+// in the project are still reported if an SDK evaluated them, since that usually means the app has the key wrong.
+func (s server) GetFlagEvaluations(ctx context.Context, request GetFlagEvaluationsRequestObject) (GetFlagEvaluationsResponseObject, error) {
+	store := model.StoreFromContext(ctx)
+	project, err := store.GetDevProject(ctx, request.ProjectKey)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return GetFlagEvaluations404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	evaluations, ok := model.EvaluationsFromContext(ctx).ForFlag(request.ProjectKey, request.FlagKey)
+	if !ok {
+		if _, exists := project.AllFlagsState[request.FlagKey]; !exists {
+			return GetFlagEvaluations404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: "flag not found",
+			}}, nil
+		}
+		evaluations = model.FlagEvaluations{
+			FlagKey:      request.FlagKey,
+			ContextKinds: []string{},
+			Contexts:     []model.SeenContext{},
+			Values:       []model.ServedValue{},
+		}
+	}
+	return GetFlagEvaluations200JSONResponse(evaluations), nil
+}
@@ -8,7 +8,8 @@ import (
 
 func (s server) GetProject(ctx context.Context, request GetProjectRequestObject) (GetProjectResponseObject, error) {
 	store := model.StoreFromContext(ctx)
-	project, err := store.GetDevProject(ctx, request.ProjectKey)
+	projectKey := request.ProjectKey
+	project, err := store.GetDevProject(ctx, projectKey)
 	if err != nil {
 		return nil, err
 	}
@@ -22,28 +23,27 @@ func (s server) GetProject(ctx context.Context, request GetProjectRequestObject)
 		SourceEnvironmentKey: project.SourceEnvironmentKey,
 		FlagsState:           &project.AllFlagsState,
 	}
+	if model.SourceProjectKey(projectKey) == projectKey {
+		environments, err := model.GetProjectEnvironments(ctx, projectKey)
+		if err != nil {
+			return nil, err
+		}
+		respEnvironments := projectEnvironmentsToResponseFormat(environments)
+		response.Environments = &respEnvironments
+	}
 
 	if request.Params.Expand != nil {
 		for _, item := range *request.Params.Expand {
 			if item == "overrides" {
-				overrides, err := store.GetOverridesForProject(ctx, request.ProjectKey)
+				overrides, err := store.GetOverridesForProject(ctx, projectKey)
 				if err != nil {
 					return nil, err
 				}
-				respOverrides := make(model.FlagsState)
-				for _, override := range overrides {
-					if !override.Active {
-						continue
-					}
-					respOverrides[override.FlagKey] = model.FlagState{
-						Value:   override.Value,
-						Version: override.Version,
-					}
-				}
+				respOverrides := overridesToResponseFormat(overrides)
 				response.Overrides = &respOverrides
 			}
 			if item == "availableVariations" {
-				availableVariations, err := store.GetAvailableVariationsForProject(ctx, request.ProjectKey)
+				availableVariations, err := store.GetAvailableVariationsForProject(ctx, projectKey)
 				if err != nil {
 					return nil, err
 				}
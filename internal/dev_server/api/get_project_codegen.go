@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) GetProjectCodegen(ctx context.Context, request GetProjectCodegenRequestObject) (GetProjectCodegenResponseObject, error) {
+	lang, err := model.ParseCodegenLanguage(string(request.Params.Lang))
+	if err != nil {
+		return GetProjectCodegen400JSONResponse{ErrorResponseJSONResponse{
+			Code:    "invalid_request",
+			Message: err.Error(),
+		}}, nil
+	}
+	var packageName string
+	if request.Params.Package != nil {
+		packageName = *request.Params.Package
+		if !token.IsIdentifier(packageName) {
+			return GetProjectCodegen400JSONResponse{ErrorResponseJSONResponse{
+				Code:    "invalid_request",
+				Message: fmt.Sprintf("package %q is not a valid Go package name", packageName),
+			}}, nil
+		}
+	}
+	src, err := model.GenerateFlagAccessors(ctx, request.ProjectKey, lang, packageName)
+	if err != nil {
+		return codegenNotFound(err)
+	}
+	return GetProjectCodegen200TextResponse(src), nil
+}
+
+func codegenNotFound(err error) (GetProjectCodegenResponseObject, error) {
+	if errors.As(err, &model.ErrNotFound{}) {
+		return GetProjectCodegen404JSONResponse{
+			Code:    "not_found",
+			Message: err.Error(),
+		}, nil
+	}
+	return nil, err
+}
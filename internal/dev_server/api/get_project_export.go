@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) GetProjectExport(ctx context.Context, request GetProjectExportRequestObject) (GetProjectExportResponseObject, error) {
+	format, err := model.ParseExportFormat(string(request.Params.Format))
+	if err != nil {
+		return GetProjectExport400JSONResponse{ErrorResponseJSONResponse{
+			Code:    "invalid_request",
+			Message: err.Error(),
+		}}, nil
+	}
+	fixture, err := model.ExportProject(ctx, request.ProjectKey, format)
+	if err != nil {
+		return exportNotFound(err)
+	}
+	return GetProjectExport200TextResponse(fixture), nil
+}
+
+func exportNotFound(err error) (GetProjectExportResponseObject, error) {
+	if errors.As(err, &model.ErrNotFound{}) {
+		return GetProjectExport404JSONResponse{
+			Code:    "not_found",
+			Message: err.Error(),
+		}, nil
+	}
+	return nil, err
+}
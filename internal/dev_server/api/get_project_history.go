@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) GetProjectHistory(ctx context.Context, request GetProjectHistoryRequestObject) (GetProjectHistoryResponseObject, error) {
+	history, err := model.GetHistory(ctx, request.ProjectKey)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return GetProjectHistory404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	slices.Reverse(history)
+	if limit := request.Params.Limit; limit != nil && *limit >= 0 && *limit < len(history) {
+		history = history[:*limit]
+	}
+	return GetProjectHistory200JSONResponse(historyToResponseFormat(history)), nil
+}
@@ -8,12 +8,16 @@ import (
 
 func (s server) GetProjects(ctx context.Context, _ GetProjectsRequestObject) (GetProjectsResponseObject, error) {
 	store := model.StoreFromContext(ctx)
-	projectKeys, err := store.GetDevProjectKeys(ctx)
+	keys, err := store.GetDevProjectKeys(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if projectKeys == nil {
-		projectKeys = make([]string, 0) // HACK to make the json behavior compatible with go.
+	projectKeys := make([]string, 0) // HACK to make the json behavior compatible with go.
+	for _, key := range keys {
+		// A project's additional environments are shown with the project rather than as projects of their own.
+		if model.SourceProjectKey(key) == key {
+			projectKeys = append(projectKeys, key)
+		}
 	}
 	return GetProjects200JSONResponse(projectKeys), nil
 }
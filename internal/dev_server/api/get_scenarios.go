@@ -0,0 +1,32 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) GetScenarios(ctx context.Context, request GetScenariosRequestObject) (GetScenariosResponseObject, error) {
+	store := model.StoreFromContext(ctx)
+	_, err := store.GetDevProject(ctx, request.ProjectKey)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return GetScenarios404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	scenarios, err := store.GetScenariosForProject(ctx, request.ProjectKey)
+	if err != nil {
+		return nil, err
+	}
+	response := make(GetScenarios200JSONResponse, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		response = append(response, scenarioToResponseFormat(scenario))
+	}
+	return response, nil
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) GetSnapshotDiff(ctx context.Context, request GetSnapshotDiffRequestObject) (GetSnapshotDiffResponseObject, error) {
+	var against string
+	if request.Params.Against != nil {
+		against = *request.Params.Against
+	}
+	diff, err := model.DiffSnapshot(ctx, request.ProjectKey, request.SnapshotName, against)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return GetSnapshotDiff404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	return GetSnapshotDiff200JSONResponse(diff), nil
+}
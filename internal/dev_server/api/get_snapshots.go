@@ -0,0 +1,32 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) GetSnapshots(ctx context.Context, request GetSnapshotsRequestObject) (GetSnapshotsResponseObject, error) {
+	store := model.StoreFromContext(ctx)
+	_, err := store.GetDevProject(ctx, request.ProjectKey)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return GetSnapshots404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	snapshots, err := store.GetSnapshotsForProject(ctx, request.ProjectKey)
+	if err != nil {
+		return nil, err
+	}
+	response := make(GetSnapshots200JSONResponse, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		response = append(response, snapshotToResponseFormat(snapshot))
+	}
+	return response, nil
+}
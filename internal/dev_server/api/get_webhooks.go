@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) GetWebhooks(ctx context.Context, request GetWebhooksRequestObject) (GetWebhooksResponseObject, error) {
+	var projectKey string
+	if request.Params.ProjectKey != nil {
+		projectKey = *request.Params.ProjectKey
+	}
+	webhooks, err := model.StoreFromContext(ctx).GetWebhooks(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	response := make(GetWebhooks200JSONResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		response = append(response, webhookToResponseFormat(webhook))
+	}
+	return response, nil
+}
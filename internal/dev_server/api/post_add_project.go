@@ -48,16 +48,7 @@ func (s server) PostAddProject(ctx context.Context, request PostAddProjectReques
 				if err != nil {
 					return nil, err
 				}
-				respOverrides := make(model.FlagsState)
-				for _, override := range overrides {
-					if !override.Active {
-						continue
-					}
-					respOverrides[override.FlagKey] = model.FlagState{
-						Value:   override.Value,
-						Version: override.Version,
-					}
-				}
+				respOverrides := overridesToResponseFormat(overrides)
 				response.Overrides = &respOverrides
 			}
 			if item == "availableVariations" {
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) PostApplyScenario(ctx context.Context, request PostApplyScenarioRequestObject) (PostApplyScenarioResponseObject, error) {
+	scenario, err := model.ApplyScenario(ctx, request.ProjectKey, request.ScenarioName)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return PostApplyScenario404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	return PostApplyScenario200JSONResponse{ScenarioJSONResponse(scenarioToResponseFormat(scenario))}, nil
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) PostFault(ctx context.Context, request PostFaultRequestObject) (PostFaultResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("empty fault body")
+	}
+	store := model.StoreFromContext(ctx)
+	_, err := store.GetDevProject(ctx, request.ProjectKey)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return PostFault404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		return nil, err
+	}
+
+	spec := *request.Body
+	fault := model.Fault{
+		Kind:        model.FaultKind(spec.Kind),
+		Probability: 1,
+	}
+	if spec.Probability != nil {
+		fault.Probability = *spec.Probability
+	}
+	if spec.LatencyMs != nil {
+		fault.LatencyMs = *spec.LatencyMs
+	}
+	if spec.Status != nil {
+		fault.Status = *spec.Status
+	}
+	if spec.DropAfterMs != nil {
+		fault.DropAfterMs = *spec.DropAfterMs
+	}
+	if spec.DurationMs != nil {
+		if *spec.DurationMs <= 0 {
+			return PostFault400JSONResponse{ErrorResponseJSONResponse{
+				Code:    "invalid_request",
+				Message: "durationMs must be positive",
+			}}, nil
+		}
+		expiresAt := time.Now().Add(time.Duration(*spec.DurationMs) * time.Millisecond)
+		fault.ExpiresAt = &expiresAt
+	}
+
+	faults := model.FaultsFromContext(ctx)
+	if err := faults.Add(request.ProjectKey, fault); err != nil {
+		return PostFault400JSONResponse{ErrorResponseJSONResponse{
+			Code:    "invalid_request",
+			Message: err.Error(),
+		}}, nil
+	}
+	return PostFault200JSONResponse(faults.ForProject(request.ProjectKey)), nil
+}
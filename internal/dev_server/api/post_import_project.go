@@ -70,6 +70,9 @@ func (s server) PostImportProject(ctx context.Context, request PostImportProject
 	if err != nil {
 		return nil, err
 	}
+	if err := model.NotifyProjectImported(ctx, *project); err != nil {
+		return nil, err
+	}
 
 	response := ProjectJSONResponse{
 		LastSyncedFromSource: project.LastSyncTime.Unix(),
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) PostProjectEnvironment(ctx context.Context, request PostProjectEnvironmentRequestObject) (PostProjectEnvironmentResponseObject, error) {
+	if model.SourceProjectKey(request.ProjectKey) != request.ProjectKey {
+		return PostProjectEnvironment400JSONResponse{ErrorResponseJSONResponse{
+			Code:    "invalid_request",
+			Message: "environments can only be added to a project, not to another environment",
+		}}, nil
+	}
+
+	project, err := model.AddProjectEnvironment(ctx, request.ProjectKey, request.EnvironmentKey)
+	switch {
+	case errors.As(err, &model.ErrNotFound{}):
+		return PostProjectEnvironment404JSONResponse{
+			Code:    "not_found",
+			Message: err.Error(),
+		}, nil
+	case errors.As(err, &model.ErrAlreadyExists{}):
+		return PostProjectEnvironment409JSONResponse{
+			Code:    "conflict",
+			Message: err.Error(),
+		}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if model.StreamStartupFromContext(ctx) {
+		model.FillVariationsAsync(ctx, project.Key)
+	}
+
+	return PostProjectEnvironment201JSONResponse{ProjectJSONResponse{
+		LastSyncedFromSource: project.LastSyncTime.Unix(),
+		Context:              project.Context,
+		SourceEnvironmentKey: project.SourceEnvironmentKey,
+		FlagsState:           &project.AllFlagsState,
+	}}, nil
+}
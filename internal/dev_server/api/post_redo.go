@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) PostRedo(ctx context.Context, request PostRedoRequestObject) (PostRedoResponseObject, error) {
+	entries, err := model.RedoChange(ctx, request.ProjectKey)
+	switch {
+	case errors.As(err, &model.ErrNotFound{}):
+		return PostRedo404JSONResponse{ErrorResponseJSONResponse{
+			Code:    "not_found",
+			Message: err.Error(),
+		}}, nil
+	case errors.As(err, &model.ErrNoChange{}):
+		return PostRedo409JSONResponse{
+			Code:    "conflict",
+			Message: err.Error(),
+		}, nil
+	case err != nil:
+		return nil, err
+	}
+	return PostRedo200JSONResponse(historyToResponseFormat(entries)), nil
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) PostRestoreSnapshot(ctx context.Context, request PostRestoreSnapshotRequestObject) (PostRestoreSnapshotResponseObject, error) {
+	snapshot, err := model.RestoreSnapshot(ctx, request.ProjectKey, request.SnapshotName)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return PostRestoreSnapshot404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	return PostRestoreSnapshot200JSONResponse{SnapshotJSONResponse(snapshotToResponseFormat(snapshot))}, nil
+}
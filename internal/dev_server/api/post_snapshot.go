@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) PostSnapshot(ctx context.Context, request PostSnapshotRequestObject) (PostSnapshotResponseObject, error) {
+	snapshot, err := model.CreateSnapshot(ctx, request.ProjectKey, request.SnapshotName)
+	switch {
+	case errors.As(err, &model.ErrNotFound{}):
+		return PostSnapshot404JSONResponse{ErrorResponseJSONResponse{
+			Code:    "not_found",
+			Message: err.Error(),
+		}}, nil
+	case errors.As(err, &model.ErrAlreadyExists{}):
+		return PostSnapshot409JSONResponse{
+			Code:    "conflict",
+			Message: err.Error(),
+		}, nil
+	case err != nil:
+		return nil, err
+	}
+	return PostSnapshot201JSONResponse{SnapshotJSONResponse(snapshotToResponseFormat(snapshot))}, nil
+}
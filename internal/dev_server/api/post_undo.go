@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) PostUndo(ctx context.Context, request PostUndoRequestObject) (PostUndoResponseObject, error) {
+	entries, err := model.UndoChange(ctx, request.ProjectKey)
+	switch {
+	case errors.As(err, &model.ErrNotFound{}):
+		return PostUndo404JSONResponse{ErrorResponseJSONResponse{
+			Code:    "not_found",
+			Message: err.Error(),
+		}}, nil
+	case errors.As(err, &model.ErrNoChange{}):
+		return PostUndo409JSONResponse{
+			Code:    "conflict",
+			Message: err.Error(),
+		}, nil
+	case err != nil:
+		return nil, err
+	}
+	return PostUndo200JSONResponse(historyToResponseFormat(entries)), nil
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) PostWebhook(ctx context.Context, request PostWebhookRequestObject) (PostWebhookResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("empty webhook body")
+	}
+	store := model.StoreFromContext(ctx)
+	_, err := store.GetDevProject(ctx, request.Body.ProjectKey)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return PostWebhook404JSONResponse{ErrorResponseJSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	var secret string
+	if request.Body.Secret != nil {
+		secret = *request.Body.Secret
+	}
+	webhook, err := model.NewWebhook(request.Body.ProjectKey, request.Body.Url, secret)
+	if err != nil {
+		return PostWebhook400JSONResponse{ErrorResponseJSONResponse{
+			Code:    "invalid_request",
+			Message: err.Error(),
+		}}, nil
+	}
+	err = store.InsertWebhook(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+	response := webhookToResponseFormat(webhook)
+	response.Secret = &webhook.Secret
+	return PostWebhook201JSONResponse(response), nil
+}
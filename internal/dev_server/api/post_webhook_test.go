@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func TestPostWebhook(t *testing.T) {
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx := model.ContextWithStore(context.Background(), store)
+
+	t.Run("adds a webhook to the project and shows its secret", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), "proj").Return(&model.Project{Key: "proj"}, nil)
+		store.EXPECT().InsertWebhook(gomock.Any(), gomock.Any()).Return(nil)
+
+		response, err := server{}.PostWebhook(ctx, PostWebhookRequestObject{Body: &PostWebhookJSONRequestBody{
+			ProjectKey: "proj",
+			Url:        "http://localhost:9000/hook",
+		}})
+		require.NoError(t, err)
+		require.IsType(t, PostWebhook201JSONResponse{}, response)
+		webhook := response.(PostWebhook201JSONResponse)
+		assert.Equal(t, "proj", webhook.ProjectKey)
+		require.NotNil(t, webhook.Secret)
+		assert.NotEmpty(t, *webhook.Secret)
+	})
+
+	t.Run("returns 404 for projects that don't exist", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), "missing").Return(nil, model.NewErrNotFound("project", "missing"))
+
+		response, err := server{}.PostWebhook(ctx, PostWebhookRequestObject{Body: &PostWebhookJSONRequestBody{
+			ProjectKey: "missing",
+			Url:        "http://localhost:9000/hook",
+		}})
+		require.NoError(t, err)
+		assert.IsType(t, PostWebhook404JSONResponse{}, response)
+	})
+}
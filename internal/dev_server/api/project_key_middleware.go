@@ -0,0 +1,35 @@
+package api
+
+import (
+	"maps"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+// EnvironmentProjectKeyMiddleware resolves the project key on the path of every /dev/projects/{projectKey} endpoint,
+// so that they all address one of a project's environments as project:env, and the environment the project was added
+// with as the project itself.
+func EnvironmentProjectKeyMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		vars := mux.Vars(request)
+		projectKey, ok := vars["projectKey"]
+		if !ok {
+			handler.ServeHTTP(writer, request)
+			return
+		}
+		resolved, err := model.ResolveEnvironmentProjectKey(request.Context(), projectKey)
+		if err != nil {
+			ResponseErrorHandler(writer, request, err)
+			return
+		}
+		if resolved != projectKey {
+			vars = maps.Clone(vars)
+			vars["projectKey"] = resolved
+			request = mux.SetURLVars(request, vars)
+		}
+		handler.ServeHTTP(writer, request)
+	})
+}
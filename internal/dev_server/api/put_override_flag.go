@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -12,7 +13,31 @@ func (s server) PutOverrideFlag(ctx context.Context, request PutOverrideFlagRequ
 	if request.Body == nil {
 		return nil, errors.New("empty override body")
 	}
-	override, err := model.UpsertOverride(ctx, request.ProjectKey, request.FlagKey, *request.Body)
+	var options model.OverrideOptions
+	if request.Params.ExpiresAt != nil {
+		options.ExpiresAt = *request.Params.ExpiresAt
+		if !options.ExpiresAt.After(time.Now()) {
+			return PutOverrideFlag400JSONResponse{
+				ErrorResponseJSONResponse{
+					Code:    "invalid_request",
+					Message: "expiresAt must be in the future",
+				},
+			}, nil
+		}
+	}
+	if request.Params.ContextMatcher != nil {
+		matcher, err := model.ParseContextMatcher(*request.Params.ContextMatcher)
+		if err != nil {
+			return PutOverrideFlag400JSONResponse{
+				ErrorResponseJSONResponse{
+					Code:    "invalid_request",
+					Message: err.Error(),
+				},
+			}, nil
+		}
+		options.ContextMatcher = &matcher
+	}
+	override, err := model.UpsertOverrideWithOptions(ctx, request.ProjectKey, request.FlagKey, *request.Body, options)
 	if err != nil {
 		if errors.As(err, &model.ErrNotFound{}) {
 			return PutOverrideFlag400JSONResponse{
@@ -24,8 +49,16 @@ func (s server) PutOverrideFlag(ctx context.Context, request PutOverrideFlagRequ
 		}
 		return nil, err
 	}
-	return PutOverrideFlag200JSONResponse{FlagOverrideJSONResponse{
+	response := FlagOverrideJSONResponse{
 		Override: override.Active,
 		Value:    override.Value,
-	}}, nil
+	}
+	if !override.ExpiresAt.IsZero() {
+		response.ExpiresAt = &override.ExpiresAt
+	}
+	if override.ContextMatcher != nil {
+		contextMatcher := override.ContextMatcher.String()
+		response.ContextMatcher = &contextMatcher
+	}
+	return PutOverrideFlag200JSONResponse{response}, nil
 }
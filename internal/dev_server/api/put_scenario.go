@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func (s server) PutScenario(ctx context.Context, request PutScenarioRequestObject) (PutScenarioResponseObject, error) {
+	store := model.StoreFromContext(ctx)
+	_, err := store.GetDevProject(ctx, request.ProjectKey)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return PutScenario404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		return nil, err
+	}
+
+	var overrides map[string]ldvalue.Value
+	if request.Body != nil && request.Body.Overrides != nil {
+		overrides = *request.Body.Overrides
+	}
+	scenario, err := model.SaveScenario(ctx, request.ProjectKey, request.ScenarioName, overrides)
+	if err != nil {
+		if errors.As(err, &model.ErrNotFound{}) {
+			return PutScenario400JSONResponse{ErrorResponseJSONResponse{
+				Code:    "invalid_request",
+				Message: err.Error(),
+			}}, nil
+		}
+		return nil, err
+	}
+	return PutScenario200JSONResponse{ScenarioJSONResponse(scenarioToResponseFormat(scenario))}, nil
+}
@@ -37,6 +37,23 @@ const (
 	Overrides           PostAddProjectParamsExpand = "overrides"
 )
 
+// Defines values for GetProjectCodegenParamsLang.
+const (
+	GetProjectCodegenParamsLangGo         GetProjectCodegenParamsLang = "go"
+	GetProjectCodegenParamsLangTypescript GetProjectCodegenParamsLang = "typescript"
+)
+
+// Defines values for GetProjectExportParamsFormat.
+const (
+	GetProjectExportParamsFormatGo     GetProjectExportParamsFormat = "go"
+	GetProjectExportParamsFormatImport GetProjectExportParamsFormat = "import"
+	GetProjectExportParamsFormatJson   GetProjectExportParamsFormat = "json"
+	GetProjectExportParamsFormatYaml   GetProjectExportParamsFormat = "yaml"
+)
+
+// Connection an SDK connected to the dev server
+type Connection = model.Connection
+
 // Context context object to use when evaluating flags in source environment
 type Context = ldcontext.Context
 
@@ -97,9 +114,63 @@ type EventsPage struct {
 	TotalCount int64 `json:"total_count"`
 }
 
+// Fault a fault injected into a project's SDK endpoints
+type Fault = model.Fault
+
+// FaultSpec a fault to inject into a project's SDK endpoints
+type FaultSpec struct {
+	// DropAfterMs how long streams stay open before drop-stream faults end them
+	DropAfterMs *int `json:"dropAfterMs,omitempty"`
+
+	// DurationMs how long the fault stays in place. The fault stays until removed without it.
+	DurationMs *int `json:"durationMs,omitempty"`
+
+	// Kind latency, error, drop-stream, stall-heartbeats, malformed or truncated
+	Kind string `json:"kind"`
+
+	// LatencyMs the delay added to responses by latency faults
+	LatencyMs *int `json:"latencyMs,omitempty"`
+
+	// Probability the chance that the fault hits a request, from greater than 0 up to 1. Defaults to 1.
+	Probability *float64 `json:"probability,omitempty"`
+
+	// Status the status, 429 or 5xx, of responses from error faults
+	Status *int `json:"status,omitempty"`
+}
+
+// FlagEvaluations how often a flag was evaluated, for which contexts, and the values served
+type FlagEvaluations = model.FlagEvaluations
+
 // FlagValue value of a feature flag variation
 type FlagValue = ldvalue.Value
 
+// FlagValues flag values by flag key
+type FlagValues map[string]FlagValue
+
+// HistoryEntry a change to one flag of a project
+type HistoryEntry struct {
+	// Action one of override, deactivate, import, sync, undo or redo
+	Action string `json:"action"`
+
+	// ChangeId entries written together share a change id and are undone together
+	ChangeId int     `json:"changeId"`
+	FlagKey  *string `json:"flagKey,omitempty"`
+	Id       int     `json:"id"`
+
+	// NewValue value of a feature flag variation
+	NewValue *FlagValue `json:"newValue,omitempty"`
+
+	// OldValue value of a feature flag variation
+	OldValue *FlagValue `json:"oldValue,omitempty"`
+
+	// Reverts the change that an undo reverted or a redo replayed
+	Reverts *int `json:"reverts,omitempty"`
+
+	// Source where the change came from, one of cli, ui, api, scenario or server
+	Source    string    `json:"source"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 // Project Project
 type Project struct {
 	// LastSyncedFromSource unix timestamp for the lat time the flag values were synced from the source environment
@@ -111,16 +182,60 @@ type Project struct {
 	// Context context object to use when evaluating flags in source environment
 	Context Context `json:"context"`
 
+	// Environments the source environments added to the project besides sourceEnvironmentKey
+	Environments *[]ProjectEnvironment `json:"environments,omitempty"`
+
 	// FlagsState flags and their values and version for a given project in the source environment
 	FlagsState *model.FlagsState `json:"flagsState,omitempty"`
 
-	// Overrides overridden flags for the project
-	Overrides *model.FlagsState `json:"overrides,omitempty"`
+	// Overrides overridden flags for the project, with the expiry and context matcher of those that have them
+	Overrides *model.OverridesState `json:"overrides,omitempty"`
 
 	// SourceEnvironmentKey environment to copy flag values from
 	SourceEnvironmentKey string `json:"sourceEnvironmentKey"`
 }
 
+// ProjectEnvironment a source environment added to a project
+type ProjectEnvironment struct {
+	// LastSyncedFromSource unix timestamp for the last time the flag values were synced from the source environment
+	LastSyncedFromSource int64 `json:"_lastSyncedFromSource"`
+
+	// ProjectKey key of the dev project holding the environment's flag state, which SDKs can use as their credential
+	ProjectKey string `json:"projectKey"`
+
+	// SourceEnvironmentKey environment the flag values are copied from
+	SourceEnvironmentKey string `json:"sourceEnvironmentKey"`
+}
+
+// ProjectEvaluations the flags evaluated by the project's SDKs and the contexts they were evaluated for
+type ProjectEvaluations = model.ProjectEvaluations
+
+// Scenario a named set of overrides for a project
+type Scenario struct {
+	Name string `json:"name"`
+
+	// Overrides flag values by flag key
+	Overrides FlagValues `json:"overrides"`
+}
+
+// Snapshot a named copy of a project's state
+type Snapshot struct {
+	// Context context object to use when evaluating flags in source environment
+	Context   Context   `json:"context"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// FlagsState flags and their values and version when the snapshot was taken
+	FlagsState model.FlagsState `json:"flagsState"`
+	Name       string           `json:"name"`
+
+	// Overrides overridden flags when the snapshot was taken
+	Overrides model.OverridesState `json:"overrides"`
+}
+
+// SnapshotDiff the differences between two snapshots of a project, each one a flag's value, override or variations, or the
+// project's context
+type SnapshotDiff = model.SnapshotDiff
+
 // Variation variation of a flag
 type Variation struct {
 	Id          string  `json:"_id"`
@@ -131,6 +246,20 @@ type Variation struct {
 	Value FlagValue `json:"value"`
 }
 
+// Webhook a URL that a project's state changes are posted to
+type Webhook struct {
+	CreatedAt  time.Time `json:"createdAt"`
+	Id         string    `json:"id"`
+	ProjectKey string    `json:"projectKey"`
+
+	// Secret the key deliveries are signed with, only returned when the webhook is created
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// EnvironmentKey defines model for environmentKey.
+type EnvironmentKey = string
+
 // FlagKey defines model for flagKey.
 type FlagKey = string
 
@@ -140,6 +269,12 @@ type ProjectExpand = []string
 // ProjectKey defines model for projectKey.
 type ProjectKey = string
 
+// ScenarioName defines model for scenarioName.
+type ScenarioName = string
+
+// SnapshotName defines model for snapshotName.
+type SnapshotName = string
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	// Code specific error code encountered
@@ -151,6 +286,12 @@ type ErrorResponse struct {
 
 // FlagOverride defines model for FlagOverride.
 type FlagOverride struct {
+	// ContextMatcher the contexts the override applies to
+	ContextMatcher *string `json:"contextMatcher,omitempty"`
+
+	// ExpiresAt when the override will be removed
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
 	// Override whether or not this is an overridden value or one from the source environment
 	Override bool `json:"override"`
 
@@ -158,6 +299,12 @@ type FlagOverride struct {
 	Value FlagValue `json:"value"`
 }
 
+// GetConnectionsParams defines parameters for GetConnections.
+type GetConnectionsParams struct {
+	// ProjectKey only list the project's connections
+	ProjectKey *string `form:"projectKey,omitempty" json:"projectKey,omitempty"`
+}
+
 // GetDebugSessionsParams defines parameters for GetDebugSessions.
 type GetDebugSessionsParams struct {
 	// Limit limit the number of debug sessions returned
@@ -224,6 +371,18 @@ type PostAddProjectParams struct {
 // PostAddProjectParamsExpand defines parameters for PostAddProject.
 type PostAddProjectParamsExpand string
 
+// GetProjectCodegenParams defines parameters for GetProjectCodegen.
+type GetProjectCodegenParams struct {
+	// Lang the language to generate
+	Lang GetProjectCodegenParamsLang `form:"lang" json:"lang"`
+
+	// Package the package of the generated Go file
+	Package *string `form:"package,omitempty" json:"package,omitempty"`
+}
+
+// GetProjectCodegenParamsLang defines parameters for GetProjectCodegen.
+type GetProjectCodegenParamsLang string
+
 // GetEnvironmentsParams defines parameters for GetEnvironments.
 type GetEnvironmentsParams struct {
 	// Name filter by environment name
@@ -233,18 +392,78 @@ type GetEnvironmentsParams struct {
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// GetProjectExportParams defines parameters for GetProjectExport.
+type GetProjectExportParams struct {
+	// Format json or yaml for the SDKs' file data source, import for import-project, or go for an ldtestdata setup
+	Format GetProjectExportParamsFormat `form:"format" json:"format"`
+}
+
+// GetProjectExportParamsFormat defines parameters for GetProjectExport.
+type GetProjectExportParamsFormat string
+
+// GetProjectHistoryParams defines parameters for GetProjectHistory.
+type GetProjectHistoryParams struct {
+	// Limit limit the number of entries returned
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PutOverrideFlagParams defines parameters for PutOverrideFlag.
+type PutOverrideFlagParams struct {
+	// ExpiresAt when the override should be removed, reverting the flag to its source value. The override never expires without it.
+	ExpiresAt *time.Time `form:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+
+	// ContextMatcher limits the override to matching contexts, e.g. `user.key == "alice"` or `org.tier in [enterprise, startup]`. The override applies to every context without it.
+	ContextMatcher *string `form:"contextMatcher,omitempty" json:"contextMatcher,omitempty"`
+}
+
+// PutScenarioJSONBody defines parameters for PutScenario.
+type PutScenarioJSONBody struct {
+	// Overrides flag values by flag key
+	Overrides *FlagValues `json:"overrides,omitempty"`
+}
+
+// GetSnapshotDiffParams defines parameters for GetSnapshotDiff.
+type GetSnapshotDiffParams struct {
+	// Against the snapshot to compare with. Defaults to the project's live state.
+	Against *string `form:"against,omitempty" json:"against,omitempty"`
+}
+
+// GetWebhooksParams defines parameters for GetWebhooks.
+type GetWebhooksParams struct {
+	// ProjectKey only list the project's webhooks
+	ProjectKey *string `form:"projectKey,omitempty" json:"projectKey,omitempty"`
+}
+
+// PostWebhookJSONBody defines parameters for PostWebhook.
+type PostWebhookJSONBody struct {
+	ProjectKey string `json:"projectKey"`
+
+	// Secret the key deliveries are signed with. One is generated if it is missing.
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
 // PatchProjectJSONRequestBody defines body for PatchProject for application/json ContentType.
 type PatchProjectJSONRequestBody PatchProjectJSONBody
 
 // PostAddProjectJSONRequestBody defines body for PostAddProject for application/json ContentType.
 type PostAddProjectJSONRequestBody PostAddProjectJSONBody
 
+// PostFaultJSONRequestBody defines body for PostFault for application/json ContentType.
+type PostFaultJSONRequestBody = FaultSpec
+
 // PostImportProjectJSONRequestBody defines body for PostImportProject for application/json ContentType.
 type PostImportProjectJSONRequestBody = Project
 
 // PutOverrideFlagJSONRequestBody defines body for PutOverrideFlag for application/json ContentType.
 type PutOverrideFlagJSONRequestBody = FlagValue
 
+// PutScenarioJSONRequestBody defines body for PutScenario for application/json ContentType.
+type PutScenarioJSONRequestBody PutScenarioJSONBody
+
+// PostWebhookJSONRequestBody defines body for PostWebhook for application/json ContentType.
+type PostWebhookJSONRequestBody PostWebhookJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// get the backup
@@ -253,6 +472,9 @@ type ServerInterface interface {
 	// post backup
 	// (POST /backup)
 	RestoreBackup(w http.ResponseWriter, r *http.Request)
+	// list the SDKs connected to the dev server, oldest first: open streams, and SDKs that polled in the last 10 minutes
+	// (GET /connections)
+	GetConnections(w http.ResponseWriter, r *http.Request, params GetConnectionsParams)
 	// list all debug sessions with event counts
 	// (GET /debug-sessions)
 	GetDebugSessions(w http.ResponseWriter, r *http.Request, params GetDebugSessionsParams)
@@ -277,9 +499,42 @@ type ServerInterface interface {
 	// Add the project to the dev server
 	// (POST /projects/{projectKey})
 	PostAddProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params PostAddProjectParams)
+	// generate a typed accessor for each of the project's flags
+	// (GET /projects/{projectKey}/codegen)
+	GetProjectCodegen(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params GetProjectCodegenParams)
 	// list all environments for the given project
 	// (GET /projects/{projectKey}/environments)
 	GetEnvironments(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params GetEnvironmentsParams)
+	// forget the evaluations reported for the project
+	// (DELETE /projects/{projectKey}/evaluations)
+	DeleteEvaluations(w http.ResponseWriter, r *http.Request, projectKey ProjectKey)
+	// summarize the flag evaluations and contexts reported in the events of the project's SDKs
+	// (GET /projects/{projectKey}/evaluations)
+	GetEvaluations(w http.ResponseWriter, r *http.Request, projectKey ProjectKey)
+	// summarize the evaluations of a flag reported in the events of the project's SDKs
+	// (GET /projects/{projectKey}/evaluations/{flagKey})
+	GetFlagEvaluations(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, flagKey FlagKey)
+	// export the project's flag values, with its active overrides applied, as a test fixture
+	// (GET /projects/{projectKey}/export)
+	GetProjectExport(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params GetProjectExportParams)
+	// remove the faults injected into the project's SDK endpoints
+	// (DELETE /projects/{projectKey}/faults)
+	DeleteFaults(w http.ResponseWriter, r *http.Request, projectKey ProjectKey)
+	// list the faults injected into the project's SDK endpoints
+	// (GET /projects/{projectKey}/faults)
+	GetFaults(w http.ResponseWriter, r *http.Request, projectKey ProjectKey)
+	// inject a fault into the project's SDK endpoints
+	// (POST /projects/{projectKey}/faults)
+	PostFault(w http.ResponseWriter, r *http.Request, projectKey ProjectKey)
+	// list the changes made to the project's overrides and flag values, most recent first
+	// (GET /projects/{projectKey}/history)
+	GetProjectHistory(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params GetProjectHistoryParams)
+	// replay the most recently undone override change, notifying connected SDKs
+	// (POST /projects/{projectKey}/history/redo)
+	PostRedo(w http.ResponseWriter, r *http.Request, projectKey ProjectKey)
+	// revert the most recent override change that hasn't been undone, notifying connected SDKs
+	// (POST /projects/{projectKey}/history/undo)
+	PostUndo(w http.ResponseWriter, r *http.Request, projectKey ProjectKey)
 	// Import a project from exported JSON data
 	// (POST /projects/{projectKey}/import)
 	PostImportProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey)
@@ -291,7 +546,51 @@ type ServerInterface interface {
 	DeleteFlagOverride(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, flagKey FlagKey)
 	// override flag value with value provided in the body
 	// (PUT /projects/{projectKey}/overrides/{flagKey})
-	PutOverrideFlag(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, flagKey FlagKey)
+	PutOverrideFlag(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, flagKey FlagKey, params PutOverrideFlagParams)
+	// list the saved override scenarios for the given project
+	// (GET /projects/{projectKey}/scenarios)
+	GetScenarios(w http.ResponseWriter, r *http.Request, projectKey ProjectKey)
+	// remove the scenario. The project's overrides are left as they are.
+	// (DELETE /projects/{projectKey}/scenarios/{scenarioName})
+	DeleteScenario(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, scenarioName ScenarioName)
+	// save a named set of overrides. Without overrides, the project's current overrides are saved.
+	// (PUT /projects/{projectKey}/scenarios/{scenarioName})
+	PutScenario(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, scenarioName ScenarioName)
+	// replace the project's overrides with the scenario's, sending connected SDKs a single update
+	// (POST /projects/{projectKey}/scenarios/{scenarioName}/apply)
+	PostApplyScenario(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, scenarioName ScenarioName)
+	// list the project's snapshots, oldest first
+	// (GET /projects/{projectKey}/snapshots)
+	GetSnapshots(w http.ResponseWriter, r *http.Request, projectKey ProjectKey)
+	// remove the snapshot. The project is left as it is.
+	// (DELETE /projects/{projectKey}/snapshots/{snapshotName})
+	DeleteSnapshot(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, snapshotName SnapshotName)
+	// save the project's flag state, overrides, context and available variations as a named snapshot
+	// (POST /projects/{projectKey}/snapshots/{snapshotName})
+	PostSnapshot(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, snapshotName SnapshotName)
+	// compare the snapshot with another snapshot, or with the project's live state
+	// (GET /projects/{projectKey}/snapshots/{snapshotName}/diff)
+	GetSnapshotDiff(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, snapshotName SnapshotName, params GetSnapshotDiffParams)
+	// replace the project's flag state, overrides, context and available variations with the snapshot's, sending
+	// connected SDKs a single update. Other projects are left as they are.
+	// (POST /projects/{projectKey}/snapshots/{snapshotName}/restore)
+	PostRestoreSnapshot(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, snapshotName SnapshotName)
+	// remove a source environment that was added to the project, along with its overrides
+	// (DELETE /projects/{projectKey}/source-environments/{environmentKey})
+	DeleteProjectEnvironment(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, environmentKey EnvironmentKey)
+	// sync another source environment into the project. Its flag state is kept as a dev project of its own, keyed
+	// projectKey:environmentKey, which SDKs can use as their credential.
+	// (POST /projects/{projectKey}/source-environments/{environmentKey})
+	PostProjectEnvironment(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, environmentKey EnvironmentKey)
+	// list the webhooks that state changes are posted to, oldest first
+	// (GET /webhooks)
+	GetWebhooks(w http.ResponseWriter, r *http.Request, params GetWebhooksParams)
+	// register a URL to post the project's overrides, syncs, imports and deletion to. Deliveries are signed with an HMAC-SHA256 of the body, keyed with the webhook's secret, in the X-LDCLI-Signature header.
+	// (POST /webhooks)
+	PostWebhook(w http.ResponseWriter, r *http.Request)
+	// remove the webhook
+	// (DELETE /webhooks/{webhookId})
+	DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookId string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -331,6 +630,33 @@ func (siw *ServerInterfaceWrapper) RestoreBackup(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
+// GetConnections operation middleware
+func (siw *ServerInterfaceWrapper) GetConnections(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetConnectionsParams
+
+	// ------------- Optional query parameter "projectKey" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "projectKey", r.URL.Query(), &params.ProjectKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConnections(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetDebugSessions operation middleware
 func (siw *ServerInterfaceWrapper) GetDebugSessions(w http.ResponseWriter, r *http.Request) {
 
@@ -590,6 +916,57 @@ func (siw *ServerInterfaceWrapper) PostAddProject(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
+// GetProjectCodegen operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectCodegen(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectCodegenParams
+
+	// ------------- Required query parameter "lang" -------------
+
+	if paramValue := r.URL.Query().Get("lang"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "lang"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "lang", r.URL.Query(), &params.Lang)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lang", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "package" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "package", r.URL.Query(), &params.Package)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "package", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProjectCodegen(w, r, projectKey, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetEnvironments operation middleware
 func (siw *ServerInterfaceWrapper) GetEnvironments(w http.ResponseWriter, r *http.Request) {
 
@@ -634,8 +1011,8 @@ func (siw *ServerInterfaceWrapper) GetEnvironments(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// PostImportProject operation middleware
-func (siw *ServerInterfaceWrapper) PostImportProject(w http.ResponseWriter, r *http.Request) {
+// DeleteEvaluations operation middleware
+func (siw *ServerInterfaceWrapper) DeleteEvaluations(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -649,7 +1026,7 @@ func (siw *ServerInterfaceWrapper) PostImportProject(w http.ResponseWriter, r *h
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostImportProject(w, r, projectKey)
+		siw.Handler.DeleteEvaluations(w, r, projectKey)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -659,8 +1036,8 @@ func (siw *ServerInterfaceWrapper) PostImportProject(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteOverrides operation middleware
-func (siw *ServerInterfaceWrapper) DeleteOverrides(w http.ResponseWriter, r *http.Request) {
+// GetEvaluations operation middleware
+func (siw *ServerInterfaceWrapper) GetEvaluations(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -674,7 +1051,7 @@ func (siw *ServerInterfaceWrapper) DeleteOverrides(w http.ResponseWriter, r *htt
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteOverrides(w, r, projectKey)
+		siw.Handler.GetEvaluations(w, r, projectKey)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -684,8 +1061,8 @@ func (siw *ServerInterfaceWrapper) DeleteOverrides(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteFlagOverride operation middleware
-func (siw *ServerInterfaceWrapper) DeleteFlagOverride(w http.ResponseWriter, r *http.Request) {
+// GetFlagEvaluations operation middleware
+func (siw *ServerInterfaceWrapper) GetFlagEvaluations(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -708,7 +1085,7 @@ func (siw *ServerInterfaceWrapper) DeleteFlagOverride(w http.ResponseWriter, r *
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteFlagOverride(w, r, projectKey, flagKey)
+		siw.Handler.GetFlagEvaluations(w, r, projectKey, flagKey)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -718,8 +1095,8 @@ func (siw *ServerInterfaceWrapper) DeleteFlagOverride(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// PutOverrideFlag operation middleware
-func (siw *ServerInterfaceWrapper) PutOverrideFlag(w http.ResponseWriter, r *http.Request) {
+// GetProjectExport operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectExport(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -732,17 +1109,26 @@ func (siw *ServerInterfaceWrapper) PutOverrideFlag(w http.ResponseWriter, r *htt
 		return
 	}
 
-	// ------------- Path parameter "flagKey" -------------
-	var flagKey FlagKey
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectExportParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "flagKey", mux.Vars(r)["flagKey"], &flagKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	// ------------- Required query parameter "format" -------------
+
+	if paramValue := r.URL.Query().Get("format"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "format"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "format", r.URL.Query(), &params.Format)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flagKey", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PutOverrideFlag(w, r, projectKey, flagKey)
+		siw.Handler.GetProjectExport(w, r, projectKey, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -752,704 +1138,3053 @@ func (siw *ServerInterfaceWrapper) PutOverrideFlag(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// DeleteFaults operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFaults(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteFaults(w, r, projectKey)
+	}))
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type RequiredParamError struct {
-	ParamName string
+	handler.ServeHTTP(w, r)
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+// GetFaults operation middleware
+func (siw *ServerInterfaceWrapper) GetFaults(w http.ResponseWriter, r *http.Request) {
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	var err error
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFaults(w, r, projectKey)
+	}))
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+	handler.ServeHTTP(w, r)
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+// PostFault operation middleware
+func (siw *ServerInterfaceWrapper) PostFault(w http.ResponseWriter, r *http.Request) {
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	var err error
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, GorillaServerOptions{})
-}
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-type GorillaServerOptions struct {
-	BaseURL          string
-	BaseRouter       *mux.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r *mux.Router) http.Handler {
-	return HandlerWithOptions(si, GorillaServerOptions{
-		BaseRouter: r,
-	})
-}
-
-func HandlerFromMuxWithBaseURL(si ServerInterface, r *mux.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, GorillaServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
-}
-
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.Handler {
-	r := options.BaseRouter
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostFault(w, r, projectKey)
+	}))
 
-	if r == nil {
-		r = mux.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	r.HandleFunc(options.BaseURL+"/backup", wrapper.GetBackup).Methods("GET")
+	handler.ServeHTTP(w, r)
+}
 
-	r.HandleFunc(options.BaseURL+"/backup", wrapper.RestoreBackup).Methods("POST")
+// GetProjectHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetProjectHistory(w http.ResponseWriter, r *http.Request) {
 
-	r.HandleFunc(options.BaseURL+"/debug-sessions", wrapper.GetDebugSessions).Methods("GET")
+	var err error
 
-	r.HandleFunc(options.BaseURL+"/debug-sessions/{debugSessionKey}", wrapper.DeleteDebugSession).Methods("DELETE")
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-	r.HandleFunc(options.BaseURL+"/debug-sessions/{debugSessionKey}/events", wrapper.GetDebugSessionEvents).Methods("GET")
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-	r.HandleFunc(options.BaseURL+"/projects", wrapper.GetProjects).Methods("GET")
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProjectHistoryParams
 
-	r.HandleFunc(options.BaseURL+"/projects/{projectKey}", wrapper.DeleteProject).Methods("DELETE")
+	// ------------- Optional query parameter "limit" -------------
 
-	r.HandleFunc(options.BaseURL+"/projects/{projectKey}", wrapper.GetProject).Methods("GET")
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-	r.HandleFunc(options.BaseURL+"/projects/{projectKey}", wrapper.PatchProject).Methods("PATCH")
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProjectHistory(w, r, projectKey, params)
+	}))
 
-	r.HandleFunc(options.BaseURL+"/projects/{projectKey}", wrapper.PostAddProject).Methods("POST")
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/environments", wrapper.GetEnvironments).Methods("GET")
+	handler.ServeHTTP(w, r)
+}
 
-	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/import", wrapper.PostImportProject).Methods("POST")
+// PostRedo operation middleware
+func (siw *ServerInterfaceWrapper) PostRedo(w http.ResponseWriter, r *http.Request) {
 
-	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/overrides", wrapper.DeleteOverrides).Methods("DELETE")
+	var err error
 
-	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/overrides/{flagKey}", wrapper.DeleteFlagOverride).Methods("DELETE")
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/overrides/{flagKey}", wrapper.PutOverrideFlag).Methods("PUT")
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-	return r
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRedo(w, r, projectKey)
+	}))
 
-type DbBackupApplicationvndSqlite3Response struct {
-	Body io.Reader
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	ContentLength int64
+	handler.ServeHTTP(w, r)
 }
 
-type ErrorResponseJSONResponse struct {
-	// Code specific error code encountered
-	Code string `json:"code"`
-
-	// Message description of the error
-	Message string `json:"message"`
-}
+// PostUndo operation middleware
+func (siw *ServerInterfaceWrapper) PostUndo(w http.ResponseWriter, r *http.Request) {
 
-type FlagOverrideJSONResponse struct {
-	// Override whether or not this is an overridden value or one from the source environment
-	Override bool `json:"override"`
+	var err error
 
-	// Value value of a feature flag variation
-	Value FlagValue `json:"value"`
-}
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-type ProjectJSONResponse Project
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-type GetBackupRequestObject struct {
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUndo(w, r, projectKey)
+	}))
 
-type GetBackupResponseObject interface {
-	VisitGetBackupResponse(w http.ResponseWriter) error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetBackup200ApplicationvndSqlite3Response struct {
-	DbBackupApplicationvndSqlite3Response
+	handler.ServeHTTP(w, r)
 }
 
-func (response GetBackup200ApplicationvndSqlite3Response) VisitGetBackupResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/vnd.sqlite3")
-	if response.ContentLength != 0 {
-		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
-	}
-	w.WriteHeader(200)
+// PostImportProject operation middleware
+func (siw *ServerInterfaceWrapper) PostImportProject(w http.ResponseWriter, r *http.Request) {
 
-	if closer, ok := response.Body.(io.ReadCloser); ok {
-		defer closer.Close()
-	}
-	_, err := io.Copy(w, response.Body)
-	return err
-}
+	var err error
 
-type RestoreBackupRequestObject struct {
-	Body io.Reader
-}
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-type RestoreBackupResponseObject interface {
-	VisitRestoreBackupResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-type RestoreBackup200Response struct {
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostImportProject(w, r, projectKey)
+	}))
 
-func (response RestoreBackup200Response) VisitRestoreBackupResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetDebugSessionsRequestObject struct {
-	Params GetDebugSessionsParams
+	handler.ServeHTTP(w, r)
 }
 
-type GetDebugSessionsResponseObject interface {
-	VisitGetDebugSessionsResponse(w http.ResponseWriter) error
-}
+// DeleteOverrides operation middleware
+func (siw *ServerInterfaceWrapper) DeleteOverrides(w http.ResponseWriter, r *http.Request) {
 
-type GetDebugSessions200JSONResponse DebugSessionsPage
+	var err error
 
-func (response GetDebugSessions200JSONResponse) VisitGetDebugSessionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-type GetDebugSessions400JSONResponse struct{ ErrorResponseJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteOverrides(w, r, projectKey)
+	}))
 
-func (response GetDebugSessions400JSONResponse) VisitGetDebugSessionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type DeleteDebugSessionRequestObject struct {
-	DebugSessionKey string `json:"debugSessionKey"`
-}
+// DeleteFlagOverride operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFlagOverride(w http.ResponseWriter, r *http.Request) {
 
-type DeleteDebugSessionResponseObject interface {
-	VisitDeleteDebugSessionResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type DeleteDebugSession204Response struct {
-}
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-func (response DeleteDebugSession204Response) VisitDeleteDebugSessionResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-type DeleteDebugSession404JSONResponse struct{ ErrorResponseJSONResponse }
+	// ------------- Path parameter "flagKey" -------------
+	var flagKey FlagKey
 
-func (response DeleteDebugSession404JSONResponse) VisitDeleteDebugSessionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindStyledParameterWithOptions("simple", "flagKey", mux.Vars(r)["flagKey"], &flagKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flagKey", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteFlagOverride(w, r, projectKey, flagKey)
+	}))
 
-type GetDebugSessionEventsRequestObject struct {
-	DebugSessionKey string `json:"debugSessionKey"`
-	Params          GetDebugSessionEventsParams
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetDebugSessionEventsResponseObject interface {
-	VisitGetDebugSessionEventsResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type GetDebugSessionEvents200JSONResponse EventsPage
+// PutOverrideFlag operation middleware
+func (siw *ServerInterfaceWrapper) PutOverrideFlag(w http.ResponseWriter, r *http.Request) {
 
-func (response GetDebugSessionEvents200JSONResponse) VisitGetDebugSessionEventsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
-
-type GetDebugSessionEvents400JSONResponse struct{ ErrorResponseJSONResponse }
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-func (response GetDebugSessionEvents400JSONResponse) VisitGetDebugSessionEventsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "flagKey" -------------
+	var flagKey FlagKey
 
-type GetDebugSessionEvents404JSONResponse struct {
-	// Code specific error code encountered
-	Code string `json:"code"`
+	err = runtime.BindStyledParameterWithOptions("simple", "flagKey", mux.Vars(r)["flagKey"], &flagKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flagKey", Err: err})
+		return
+	}
 
-	// Message description of the error
-	Message string `json:"message"`
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutOverrideFlagParams
 
-func (response GetDebugSessionEvents404JSONResponse) VisitGetDebugSessionEventsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// ------------- Optional query parameter "expiresAt" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "expiresAt", r.URL.Query(), &params.ExpiresAt)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expiresAt", Err: err})
+		return
+	}
 
-type GetProjectsRequestObject struct {
-}
+	// ------------- Optional query parameter "contextMatcher" -------------
 
-type GetProjectsResponseObject interface {
-	VisitGetProjectsResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("form", true, false, "contextMatcher", r.URL.Query(), &params.ContextMatcher)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "contextMatcher", Err: err})
+		return
+	}
 
-type GetProjects200JSONResponse []string
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutOverrideFlag(w, r, projectKey, flagKey, params)
+	}))
 
-func (response GetProjects200JSONResponse) VisitGetProjectsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type DeleteProjectRequestObject struct {
-	ProjectKey ProjectKey `json:"projectKey"`
-}
+// GetScenarios operation middleware
+func (siw *ServerInterfaceWrapper) GetScenarios(w http.ResponseWriter, r *http.Request) {
 
-type DeleteProjectResponseObject interface {
-	VisitDeleteProjectResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type DeleteProject204Response struct {
-}
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-func (response DeleteProject204Response) VisitDeleteProjectResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-type DeleteProject404JSONResponse struct{ ErrorResponseJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetScenarios(w, r, projectKey)
+	}))
 
-func (response DeleteProject404JSONResponse) VisitDeleteProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type GetProjectRequestObject struct {
-	ProjectKey ProjectKey `json:"projectKey"`
-	Params     GetProjectParams
-}
+// DeleteScenario operation middleware
+func (siw *ServerInterfaceWrapper) DeleteScenario(w http.ResponseWriter, r *http.Request) {
 
-type GetProjectResponseObject interface {
-	VisitGetProjectResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type GetProject200JSONResponse struct{ ProjectJSONResponse }
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-func (response GetProject200JSONResponse) VisitGetProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "scenarioName" -------------
+	var scenarioName ScenarioName
 
-type GetProject404Response struct {
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "scenarioName", mux.Vars(r)["scenarioName"], &scenarioName, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scenarioName", Err: err})
+		return
+	}
 
-func (response GetProject404Response) VisitGetProjectResponse(w http.ResponseWriter) error {
-	w.WriteHeader(404)
-	return nil
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteScenario(w, r, projectKey, scenarioName)
+	}))
 
-type PatchProjectRequestObject struct {
-	ProjectKey ProjectKey `json:"projectKey"`
-	Params     PatchProjectParams
-	Body       *PatchProjectJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type PatchProjectResponseObject interface {
-	VisitPatchProjectResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type PatchProject200JSONResponse struct{ ProjectJSONResponse }
-
-func (response PatchProject200JSONResponse) VisitPatchProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// PutScenario operation middleware
+func (siw *ServerInterfaceWrapper) PutScenario(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type PatchProject404Response struct {
-}
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-func (response PatchProject404Response) VisitPatchProjectResponse(w http.ResponseWriter) error {
-	w.WriteHeader(404)
-	return nil
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-type PostAddProjectRequestObject struct {
-	ProjectKey ProjectKey `json:"projectKey"`
-	Params     PostAddProjectParams
-	Body       *PostAddProjectJSONRequestBody
-}
+	// ------------- Path parameter "scenarioName" -------------
+	var scenarioName ScenarioName
 
-type PostAddProjectResponseObject interface {
-	VisitPostAddProjectResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "scenarioName", mux.Vars(r)["scenarioName"], &scenarioName, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scenarioName", Err: err})
+		return
+	}
 
-type PostAddProject201JSONResponse struct{ ProjectJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutScenario(w, r, projectKey, scenarioName)
+	}))
 
-func (response PostAddProject201JSONResponse) VisitPostAddProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type PostAddProject400JSONResponse struct{ ErrorResponseJSONResponse }
+// PostApplyScenario operation middleware
+func (siw *ServerInterfaceWrapper) PostApplyScenario(w http.ResponseWriter, r *http.Request) {
 
-func (response PostAddProject400JSONResponse) VisitPostAddProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-type PostAddProject409JSONResponse struct {
-	// Code specific error code encountered
-	Code string `json:"code"`
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-	// Message description of the error
-	Message string `json:"message"`
-}
+	// ------------- Path parameter "scenarioName" -------------
+	var scenarioName ScenarioName
 
-func (response PostAddProject409JSONResponse) VisitPostAddProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	err = runtime.BindStyledParameterWithOptions("simple", "scenarioName", mux.Vars(r)["scenarioName"], &scenarioName, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scenarioName", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApplyScenario(w, r, projectKey, scenarioName)
+	}))
 
-type GetEnvironmentsRequestObject struct {
-	ProjectKey ProjectKey `json:"projectKey"`
-	Params     GetEnvironmentsParams
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetEnvironmentsResponseObject interface {
-	VisitGetEnvironmentsResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type GetEnvironments200JSONResponse []Environment
-
-func (response GetEnvironments200JSONResponse) VisitGetEnvironmentsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// GetSnapshots operation middleware
+func (siw *ServerInterfaceWrapper) GetSnapshots(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type GetEnvironments400JSONResponse struct{ ErrorResponseJSONResponse }
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
 
-func (response GetEnvironments400JSONResponse) VisitGetEnvironmentsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSnapshots(w, r, projectKey)
+	}))
 
-type GetEnvironments404JSONResponse struct {
-	// Code specific error code encountered
-	Code string `json:"code"`
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	// Message description of the error
-	Message string `json:"message"`
+	handler.ServeHTTP(w, r)
 }
 
-func (response GetEnvironments404JSONResponse) VisitGetEnvironmentsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// DeleteSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSnapshot(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type PostImportProjectRequestObject struct {
-	ProjectKey ProjectKey `json:"projectKey"`
-	Body       *PostImportProjectJSONRequestBody
-}
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "snapshotName" -------------
+	var snapshotName SnapshotName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "snapshotName", mux.Vars(r)["snapshotName"], &snapshotName, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "snapshotName", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSnapshot(w, r, projectKey, snapshotName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) PostSnapshot(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "snapshotName" -------------
+	var snapshotName SnapshotName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "snapshotName", mux.Vars(r)["snapshotName"], &snapshotName, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "snapshotName", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostSnapshot(w, r, projectKey, snapshotName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSnapshotDiff operation middleware
+func (siw *ServerInterfaceWrapper) GetSnapshotDiff(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "snapshotName" -------------
+	var snapshotName SnapshotName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "snapshotName", mux.Vars(r)["snapshotName"], &snapshotName, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "snapshotName", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSnapshotDiffParams
+
+	// ------------- Optional query parameter "against" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "against", r.URL.Query(), &params.Against)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "against", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSnapshotDiff(w, r, projectKey, snapshotName, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRestoreSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) PostRestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "snapshotName" -------------
+	var snapshotName SnapshotName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "snapshotName", mux.Vars(r)["snapshotName"], &snapshotName, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "snapshotName", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRestoreSnapshot(w, r, projectKey, snapshotName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteProjectEnvironment operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProjectEnvironment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "environmentKey" -------------
+	var environmentKey EnvironmentKey
+
+	err = runtime.BindStyledParameterWithOptions("simple", "environmentKey", mux.Vars(r)["environmentKey"], &environmentKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "environmentKey", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteProjectEnvironment(w, r, projectKey, environmentKey)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostProjectEnvironment operation middleware
+func (siw *ServerInterfaceWrapper) PostProjectEnvironment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "projectKey" -------------
+	var projectKey ProjectKey
+
+	err = runtime.BindStyledParameterWithOptions("simple", "projectKey", mux.Vars(r)["projectKey"], &projectKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "environmentKey" -------------
+	var environmentKey EnvironmentKey
+
+	err = runtime.BindStyledParameterWithOptions("simple", "environmentKey", mux.Vars(r)["environmentKey"], &environmentKey, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "environmentKey", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostProjectEnvironment(w, r, projectKey, environmentKey)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhooksParams
+
+	// ------------- Optional query parameter "projectKey" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "projectKey", r.URL.Query(), &params.ProjectKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "projectKey", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhooks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostWebhook operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhook(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostWebhook(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", mux.Vars(r)["webhookId"], &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhook(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, GorillaServerOptions{})
+}
+
+type GorillaServerOptions struct {
+	BaseURL          string
+	BaseRouter       *mux.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r *mux.Router) http.Handler {
+	return HandlerWithOptions(si, GorillaServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r *mux.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, GorillaServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = mux.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.HandleFunc(options.BaseURL+"/backup", wrapper.GetBackup).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/backup", wrapper.RestoreBackup).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/connections", wrapper.GetConnections).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/debug-sessions", wrapper.GetDebugSessions).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/debug-sessions/{debugSessionKey}", wrapper.DeleteDebugSession).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/debug-sessions/{debugSessionKey}/events", wrapper.GetDebugSessionEvents).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects", wrapper.GetProjects).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}", wrapper.DeleteProject).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}", wrapper.GetProject).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}", wrapper.PatchProject).Methods("PATCH")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}", wrapper.PostAddProject).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/codegen", wrapper.GetProjectCodegen).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/environments", wrapper.GetEnvironments).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/evaluations", wrapper.DeleteEvaluations).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/evaluations", wrapper.GetEvaluations).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/evaluations/{flagKey}", wrapper.GetFlagEvaluations).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/export", wrapper.GetProjectExport).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/faults", wrapper.DeleteFaults).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/faults", wrapper.GetFaults).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/faults", wrapper.PostFault).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/history", wrapper.GetProjectHistory).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/history/redo", wrapper.PostRedo).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/history/undo", wrapper.PostUndo).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/import", wrapper.PostImportProject).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/overrides", wrapper.DeleteOverrides).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/overrides/{flagKey}", wrapper.DeleteFlagOverride).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/overrides/{flagKey}", wrapper.PutOverrideFlag).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/scenarios", wrapper.GetScenarios).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/scenarios/{scenarioName}", wrapper.DeleteScenario).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/scenarios/{scenarioName}", wrapper.PutScenario).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/scenarios/{scenarioName}/apply", wrapper.PostApplyScenario).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/snapshots", wrapper.GetSnapshots).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/snapshots/{snapshotName}", wrapper.DeleteSnapshot).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/snapshots/{snapshotName}", wrapper.PostSnapshot).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/snapshots/{snapshotName}/diff", wrapper.GetSnapshotDiff).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/snapshots/{snapshotName}/restore", wrapper.PostRestoreSnapshot).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/source-environments/{environmentKey}", wrapper.DeleteProjectEnvironment).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/projects/{projectKey}/source-environments/{environmentKey}", wrapper.PostProjectEnvironment).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/webhooks", wrapper.GetWebhooks).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/webhooks", wrapper.PostWebhook).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/webhooks/{webhookId}", wrapper.DeleteWebhook).Methods("DELETE")
+
+	return r
+}
+
+type DbBackupApplicationvndSqlite3Response struct {
+	Body io.Reader
+
+	ContentLength int64
+}
+
+type ErrorResponseJSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+type FlagOverrideJSONResponse struct {
+	// ContextMatcher the contexts the override applies to
+	ContextMatcher *string `json:"contextMatcher,omitempty"`
+
+	// ExpiresAt when the override will be removed
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Override whether or not this is an overridden value or one from the source environment
+	Override bool `json:"override"`
+
+	// Value value of a feature flag variation
+	Value FlagValue `json:"value"`
+}
+
+type ProjectJSONResponse Project
+
+type ScenarioJSONResponse Scenario
+
+type SnapshotJSONResponse Snapshot
+
+type GetBackupRequestObject struct {
+}
+
+type GetBackupResponseObject interface {
+	VisitGetBackupResponse(w http.ResponseWriter) error
+}
+
+type GetBackup200ApplicationvndSqlite3Response struct {
+	DbBackupApplicationvndSqlite3Response
+}
+
+func (response GetBackup200ApplicationvndSqlite3Response) VisitGetBackupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/vnd.sqlite3")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type RestoreBackupRequestObject struct {
+	Body io.Reader
+}
+
+type RestoreBackupResponseObject interface {
+	VisitRestoreBackupResponse(w http.ResponseWriter) error
+}
+
+type RestoreBackup200Response struct {
+}
+
+func (response RestoreBackup200Response) VisitRestoreBackupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type GetConnectionsRequestObject struct {
+	Params GetConnectionsParams
+}
+
+type GetConnectionsResponseObject interface {
+	VisitGetConnectionsResponse(w http.ResponseWriter) error
+}
+
+type GetConnections200JSONResponse []Connection
+
+func (response GetConnections200JSONResponse) VisitGetConnectionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDebugSessionsRequestObject struct {
+	Params GetDebugSessionsParams
+}
+
+type GetDebugSessionsResponseObject interface {
+	VisitGetDebugSessionsResponse(w http.ResponseWriter) error
+}
+
+type GetDebugSessions200JSONResponse DebugSessionsPage
+
+func (response GetDebugSessions200JSONResponse) VisitGetDebugSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDebugSessions400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetDebugSessions400JSONResponse) VisitGetDebugSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDebugSessionRequestObject struct {
+	DebugSessionKey string `json:"debugSessionKey"`
+}
+
+type DeleteDebugSessionResponseObject interface {
+	VisitDeleteDebugSessionResponse(w http.ResponseWriter) error
+}
+
+type DeleteDebugSession204Response struct {
+}
+
+func (response DeleteDebugSession204Response) VisitDeleteDebugSessionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteDebugSession404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response DeleteDebugSession404JSONResponse) VisitDeleteDebugSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDebugSessionEventsRequestObject struct {
+	DebugSessionKey string `json:"debugSessionKey"`
+	Params          GetDebugSessionEventsParams
+}
+
+type GetDebugSessionEventsResponseObject interface {
+	VisitGetDebugSessionEventsResponse(w http.ResponseWriter) error
+}
+
+type GetDebugSessionEvents200JSONResponse EventsPage
+
+func (response GetDebugSessionEvents200JSONResponse) VisitGetDebugSessionEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDebugSessionEvents400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetDebugSessionEvents400JSONResponse) VisitGetDebugSessionEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDebugSessionEvents404JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response GetDebugSessionEvents404JSONResponse) VisitGetDebugSessionEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectsRequestObject struct {
+}
+
+type GetProjectsResponseObject interface {
+	VisitGetProjectsResponse(w http.ResponseWriter) error
+}
+
+type GetProjects200JSONResponse []string
+
+func (response GetProjects200JSONResponse) VisitGetProjectsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProjectRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+}
+
+type DeleteProjectResponseObject interface {
+	VisitDeleteProjectResponse(w http.ResponseWriter) error
+}
+
+type DeleteProject204Response struct {
+}
+
+func (response DeleteProject204Response) VisitDeleteProjectResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteProject404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response DeleteProject404JSONResponse) VisitDeleteProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	Params     GetProjectParams
+}
+
+type GetProjectResponseObject interface {
+	VisitGetProjectResponse(w http.ResponseWriter) error
+}
+
+type GetProject200JSONResponse struct{ ProjectJSONResponse }
+
+func (response GetProject200JSONResponse) VisitGetProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProject404Response struct {
+}
+
+func (response GetProject404Response) VisitGetProjectResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PatchProjectRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	Params     PatchProjectParams
+	Body       *PatchProjectJSONRequestBody
+}
+
+type PatchProjectResponseObject interface {
+	VisitPatchProjectResponse(w http.ResponseWriter) error
+}
+
+type PatchProject200JSONResponse struct{ ProjectJSONResponse }
+
+func (response PatchProject200JSONResponse) VisitPatchProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProject404Response struct {
+}
+
+func (response PatchProject404Response) VisitPatchProjectResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAddProjectRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	Params     PostAddProjectParams
+	Body       *PostAddProjectJSONRequestBody
+}
+
+type PostAddProjectResponseObject interface {
+	VisitPostAddProjectResponse(w http.ResponseWriter) error
+}
+
+type PostAddProject201JSONResponse struct{ ProjectJSONResponse }
+
+func (response PostAddProject201JSONResponse) VisitPostAddProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAddProject400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PostAddProject400JSONResponse) VisitPostAddProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAddProject409JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response PostAddProject409JSONResponse) VisitPostAddProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectCodegenRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	Params     GetProjectCodegenParams
+}
+
+type GetProjectCodegenResponseObject interface {
+	VisitGetProjectCodegenResponse(w http.ResponseWriter) error
+}
+
+type GetProjectCodegen200TextResponse string
+
+func (response GetProjectCodegen200TextResponse) VisitGetProjectCodegenResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(200)
+
+	_, err := w.Write([]byte(response))
+	return err
+}
+
+type GetProjectCodegen400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetProjectCodegen400JSONResponse) VisitGetProjectCodegenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectCodegen404JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response GetProjectCodegen404JSONResponse) VisitGetProjectCodegenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEnvironmentsRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	Params     GetEnvironmentsParams
+}
+
+type GetEnvironmentsResponseObject interface {
+	VisitGetEnvironmentsResponse(w http.ResponseWriter) error
+}
+
+type GetEnvironments200JSONResponse []Environment
+
+func (response GetEnvironments200JSONResponse) VisitGetEnvironmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEnvironments400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetEnvironments400JSONResponse) VisitGetEnvironmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEnvironments404JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response GetEnvironments404JSONResponse) VisitGetEnvironmentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEvaluationsRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+}
+
+type DeleteEvaluationsResponseObject interface {
+	VisitDeleteEvaluationsResponse(w http.ResponseWriter) error
+}
+
+type DeleteEvaluations204Response struct {
+}
+
+func (response DeleteEvaluations204Response) VisitDeleteEvaluationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteEvaluations404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response DeleteEvaluations404JSONResponse) VisitDeleteEvaluationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEvaluationsRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+}
+
+type GetEvaluationsResponseObject interface {
+	VisitGetEvaluationsResponse(w http.ResponseWriter) error
+}
+
+type GetEvaluations200JSONResponse ProjectEvaluations
+
+func (response GetEvaluations200JSONResponse) VisitGetEvaluationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEvaluations404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetEvaluations404JSONResponse) VisitGetEvaluationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlagEvaluationsRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	FlagKey    FlagKey    `json:"flagKey"`
+}
+
+type GetFlagEvaluationsResponseObject interface {
+	VisitGetFlagEvaluationsResponse(w http.ResponseWriter) error
+}
+
+type GetFlagEvaluations200JSONResponse FlagEvaluations
+
+func (response GetFlagEvaluations200JSONResponse) VisitGetFlagEvaluationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlagEvaluations404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetFlagEvaluations404JSONResponse) VisitGetFlagEvaluationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectExportRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	Params     GetProjectExportParams
+}
+
+type GetProjectExportResponseObject interface {
+	VisitGetProjectExportResponse(w http.ResponseWriter) error
+}
+
+type GetProjectExport200TextResponse string
+
+func (response GetProjectExport200TextResponse) VisitGetProjectExportResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(200)
+
+	_, err := w.Write([]byte(response))
+	return err
+}
+
+type GetProjectExport400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetProjectExport400JSONResponse) VisitGetProjectExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectExport404JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response GetProjectExport404JSONResponse) VisitGetProjectExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFaultsRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+}
+
+type DeleteFaultsResponseObject interface {
+	VisitDeleteFaultsResponse(w http.ResponseWriter) error
+}
+
+type DeleteFaults204Response struct {
+}
+
+func (response DeleteFaults204Response) VisitDeleteFaultsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteFaults404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response DeleteFaults404JSONResponse) VisitDeleteFaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFaultsRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+}
+
+type GetFaultsResponseObject interface {
+	VisitGetFaultsResponse(w http.ResponseWriter) error
+}
+
+type GetFaults200JSONResponse []Fault
+
+func (response GetFaults200JSONResponse) VisitGetFaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFaults404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetFaults404JSONResponse) VisitGetFaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostFaultRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	Body       *PostFaultJSONRequestBody
+}
+
+type PostFaultResponseObject interface {
+	VisitPostFaultResponse(w http.ResponseWriter) error
+}
+
+type PostFault200JSONResponse []Fault
+
+func (response PostFault200JSONResponse) VisitPostFaultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostFault400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PostFault400JSONResponse) VisitPostFaultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostFault404JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response PostFault404JSONResponse) VisitPostFaultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectHistoryRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	Params     GetProjectHistoryParams
+}
+
+type GetProjectHistoryResponseObject interface {
+	VisitGetProjectHistoryResponse(w http.ResponseWriter) error
+}
+
+type GetProjectHistory200JSONResponse []HistoryEntry
+
+func (response GetProjectHistory200JSONResponse) VisitGetProjectHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProjectHistory404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetProjectHistory404JSONResponse) VisitGetProjectHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRedoRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+}
+
+type PostRedoResponseObject interface {
+	VisitPostRedoResponse(w http.ResponseWriter) error
+}
+
+type PostRedo200JSONResponse []HistoryEntry
+
+func (response PostRedo200JSONResponse) VisitPostRedoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRedo404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PostRedo404JSONResponse) VisitPostRedoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRedo409JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response PostRedo409JSONResponse) VisitPostRedoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUndoRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+}
+
+type PostUndoResponseObject interface {
+	VisitPostUndoResponse(w http.ResponseWriter) error
+}
+
+type PostUndo200JSONResponse []HistoryEntry
+
+func (response PostUndo200JSONResponse) VisitPostUndoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUndo404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PostUndo404JSONResponse) VisitPostUndoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUndo409JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response PostUndo409JSONResponse) VisitPostUndoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostImportProjectRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	Body       *PostImportProjectJSONRequestBody
+}
 
 type PostImportProjectResponseObject interface {
 	VisitPostImportProjectResponse(w http.ResponseWriter) error
 }
 
-type PostImportProject201JSONResponse struct{ ProjectJSONResponse }
+type PostImportProject201JSONResponse struct{ ProjectJSONResponse }
+
+func (response PostImportProject201JSONResponse) VisitPostImportProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostImportProject400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PostImportProject400JSONResponse) VisitPostImportProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostImportProject409JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response PostImportProject409JSONResponse) VisitPostImportProjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteOverridesRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+}
+
+type DeleteOverridesResponseObject interface {
+	VisitDeleteOverridesResponse(w http.ResponseWriter) error
+}
+
+type DeleteOverrides204Response struct {
+}
+
+func (response DeleteOverrides204Response) VisitDeleteOverridesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteOverrides404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response DeleteOverrides404JSONResponse) VisitDeleteOverridesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFlagOverrideRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	FlagKey    FlagKey    `json:"flagKey"`
+}
+
+type DeleteFlagOverrideResponseObject interface {
+	VisitDeleteFlagOverrideResponse(w http.ResponseWriter) error
+}
+
+type DeleteFlagOverride204Response struct {
+}
+
+func (response DeleteFlagOverride204Response) VisitDeleteFlagOverrideResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteFlagOverride404Response struct {
+}
+
+func (response DeleteFlagOverride404Response) VisitDeleteFlagOverrideResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PutOverrideFlagRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+	FlagKey    FlagKey    `json:"flagKey"`
+	Params     PutOverrideFlagParams
+	Body       *PutOverrideFlagJSONRequestBody
+}
+
+type PutOverrideFlagResponseObject interface {
+	VisitPutOverrideFlagResponse(w http.ResponseWriter) error
+}
+
+type PutOverrideFlag200JSONResponse struct{ FlagOverrideJSONResponse }
+
+func (response PutOverrideFlag200JSONResponse) VisitPutOverrideFlagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutOverrideFlag400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PutOverrideFlag400JSONResponse) VisitPutOverrideFlagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScenariosRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+}
+
+type GetScenariosResponseObject interface {
+	VisitGetScenariosResponse(w http.ResponseWriter) error
+}
+
+type GetScenarios200JSONResponse []Scenario
+
+func (response GetScenarios200JSONResponse) VisitGetScenariosResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScenarios404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetScenarios404JSONResponse) VisitGetScenariosResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteScenarioRequestObject struct {
+	ProjectKey   ProjectKey   `json:"projectKey"`
+	ScenarioName ScenarioName `json:"scenarioName"`
+}
+
+type DeleteScenarioResponseObject interface {
+	VisitDeleteScenarioResponse(w http.ResponseWriter) error
+}
+
+type DeleteScenario204Response struct {
+}
+
+func (response DeleteScenario204Response) VisitDeleteScenarioResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteScenario404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response DeleteScenario404JSONResponse) VisitDeleteScenarioResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutScenarioRequestObject struct {
+	ProjectKey   ProjectKey   `json:"projectKey"`
+	ScenarioName ScenarioName `json:"scenarioName"`
+	Body         *PutScenarioJSONRequestBody
+}
+
+type PutScenarioResponseObject interface {
+	VisitPutScenarioResponse(w http.ResponseWriter) error
+}
+
+type PutScenario200JSONResponse struct{ ScenarioJSONResponse }
+
+func (response PutScenario200JSONResponse) VisitPutScenarioResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutScenario400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PutScenario400JSONResponse) VisitPutScenarioResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutScenario404JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response PutScenario404JSONResponse) VisitPutScenarioResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApplyScenarioRequestObject struct {
+	ProjectKey   ProjectKey   `json:"projectKey"`
+	ScenarioName ScenarioName `json:"scenarioName"`
+}
+
+type PostApplyScenarioResponseObject interface {
+	VisitPostApplyScenarioResponse(w http.ResponseWriter) error
+}
+
+type PostApplyScenario200JSONResponse struct{ ScenarioJSONResponse }
+
+func (response PostApplyScenario200JSONResponse) VisitPostApplyScenarioResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApplyScenario404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PostApplyScenario404JSONResponse) VisitPostApplyScenarioResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSnapshotsRequestObject struct {
+	ProjectKey ProjectKey `json:"projectKey"`
+}
+
+type GetSnapshotsResponseObject interface {
+	VisitGetSnapshotsResponse(w http.ResponseWriter) error
+}
+
+type GetSnapshots200JSONResponse []Snapshot
+
+func (response GetSnapshots200JSONResponse) VisitGetSnapshotsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSnapshots404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetSnapshots404JSONResponse) VisitGetSnapshotsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSnapshotRequestObject struct {
+	ProjectKey   ProjectKey   `json:"projectKey"`
+	SnapshotName SnapshotName `json:"snapshotName"`
+}
+
+type DeleteSnapshotResponseObject interface {
+	VisitDeleteSnapshotResponse(w http.ResponseWriter) error
+}
+
+type DeleteSnapshot204Response struct {
+}
+
+func (response DeleteSnapshot204Response) VisitDeleteSnapshotResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteSnapshot404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response DeleteSnapshot404JSONResponse) VisitDeleteSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSnapshotRequestObject struct {
+	ProjectKey   ProjectKey   `json:"projectKey"`
+	SnapshotName SnapshotName `json:"snapshotName"`
+}
+
+type PostSnapshotResponseObject interface {
+	VisitPostSnapshotResponse(w http.ResponseWriter) error
+}
+
+type PostSnapshot201JSONResponse struct{ SnapshotJSONResponse }
+
+func (response PostSnapshot201JSONResponse) VisitPostSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSnapshot404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PostSnapshot404JSONResponse) VisitPostSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSnapshot409JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response PostSnapshot409JSONResponse) VisitPostSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSnapshotDiffRequestObject struct {
+	ProjectKey   ProjectKey   `json:"projectKey"`
+	SnapshotName SnapshotName `json:"snapshotName"`
+	Params       GetSnapshotDiffParams
+}
+
+type GetSnapshotDiffResponseObject interface {
+	VisitGetSnapshotDiffResponse(w http.ResponseWriter) error
+}
+
+type GetSnapshotDiff200JSONResponse SnapshotDiff
+
+func (response GetSnapshotDiff200JSONResponse) VisitGetSnapshotDiffResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSnapshotDiff404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response GetSnapshotDiff404JSONResponse) VisitGetSnapshotDiffResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRestoreSnapshotRequestObject struct {
+	ProjectKey   ProjectKey   `json:"projectKey"`
+	SnapshotName SnapshotName `json:"snapshotName"`
+}
+
+type PostRestoreSnapshotResponseObject interface {
+	VisitPostRestoreSnapshotResponse(w http.ResponseWriter) error
+}
+
+type PostRestoreSnapshot200JSONResponse struct{ SnapshotJSONResponse }
+
+func (response PostRestoreSnapshot200JSONResponse) VisitPostRestoreSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRestoreSnapshot404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PostRestoreSnapshot404JSONResponse) VisitPostRestoreSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProjectEnvironmentRequestObject struct {
+	ProjectKey     ProjectKey     `json:"projectKey"`
+	EnvironmentKey EnvironmentKey `json:"environmentKey"`
+}
+
+type DeleteProjectEnvironmentResponseObject interface {
+	VisitDeleteProjectEnvironmentResponse(w http.ResponseWriter) error
+}
+
+type DeleteProjectEnvironment204Response struct {
+}
+
+func (response DeleteProjectEnvironment204Response) VisitDeleteProjectEnvironmentResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteProjectEnvironment404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response DeleteProjectEnvironment404JSONResponse) VisitDeleteProjectEnvironmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProjectEnvironmentRequestObject struct {
+	ProjectKey     ProjectKey     `json:"projectKey"`
+	EnvironmentKey EnvironmentKey `json:"environmentKey"`
+}
+
+type PostProjectEnvironmentResponseObject interface {
+	VisitPostProjectEnvironmentResponse(w http.ResponseWriter) error
+}
+
+type PostProjectEnvironment201JSONResponse struct{ ProjectJSONResponse }
+
+func (response PostProjectEnvironment201JSONResponse) VisitPostProjectEnvironmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProjectEnvironment400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PostProjectEnvironment400JSONResponse) VisitPostProjectEnvironmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProjectEnvironment404JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response PostProjectEnvironment404JSONResponse) VisitPostProjectEnvironmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProjectEnvironment409JSONResponse struct {
+	// Code specific error code encountered
+	Code string `json:"code"`
+
+	// Message description of the error
+	Message string `json:"message"`
+}
+
+func (response PostProjectEnvironment409JSONResponse) VisitPostProjectEnvironmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooksRequestObject struct {
+	Params GetWebhooksParams
+}
+
+type GetWebhooksResponseObject interface {
+	VisitGetWebhooksResponse(w http.ResponseWriter) error
+}
+
+type GetWebhooks200JSONResponse []Webhook
+
+func (response GetWebhooks200JSONResponse) VisitGetWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostWebhookRequestObject struct {
+	Body *PostWebhookJSONRequestBody
+}
+
+type PostWebhookResponseObject interface {
+	VisitPostWebhookResponse(w http.ResponseWriter) error
+}
+
+type PostWebhook201JSONResponse Webhook
+
+func (response PostWebhook201JSONResponse) VisitPostWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostWebhook400JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PostWebhook400JSONResponse) VisitPostWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostWebhook404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response PostWebhook404JSONResponse) VisitPostWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhookRequestObject struct {
+	WebhookId string `json:"webhookId"`
+}
+
+type DeleteWebhookResponseObject interface {
+	VisitDeleteWebhookResponse(w http.ResponseWriter) error
+}
+
+type DeleteWebhook204Response struct {
+}
+
+func (response DeleteWebhook204Response) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteWebhook404JSONResponse struct{ ErrorResponseJSONResponse }
+
+func (response DeleteWebhook404JSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// get the backup
+	// (GET /backup)
+	GetBackup(ctx context.Context, request GetBackupRequestObject) (GetBackupResponseObject, error)
+	// post backup
+	// (POST /backup)
+	RestoreBackup(ctx context.Context, request RestoreBackupRequestObject) (RestoreBackupResponseObject, error)
+	// list the SDKs connected to the dev server, oldest first: open streams, and SDKs that polled in the last 10 minutes
+	// (GET /connections)
+	GetConnections(ctx context.Context, request GetConnectionsRequestObject) (GetConnectionsResponseObject, error)
+	// list all debug sessions with event counts
+	// (GET /debug-sessions)
+	GetDebugSessions(ctx context.Context, request GetDebugSessionsRequestObject) (GetDebugSessionsResponseObject, error)
+	// delete a specific debug session and all its events
+	// (DELETE /debug-sessions/{debugSessionKey})
+	DeleteDebugSession(ctx context.Context, request DeleteDebugSessionRequestObject) (DeleteDebugSessionResponseObject, error)
+	// get events for a specific debug session
+	// (GET /debug-sessions/{debugSessionKey}/events)
+	GetDebugSessionEvents(ctx context.Context, request GetDebugSessionEventsRequestObject) (GetDebugSessionEventsResponseObject, error)
+	// lists all projects that have been configured for the dev server
+	// (GET /projects)
+	GetProjects(ctx context.Context, request GetProjectsRequestObject) (GetProjectsResponseObject, error)
+	// remove the specified project from the dev server
+	// (DELETE /projects/{projectKey})
+	DeleteProject(ctx context.Context, request DeleteProjectRequestObject) (DeleteProjectResponseObject, error)
+	// get the specified project and its configuration for syncing from the LaunchDarkly Service
+	// (GET /projects/{projectKey})
+	GetProject(ctx context.Context, request GetProjectRequestObject) (GetProjectResponseObject, error)
+	// updates the project context or sourceEnvironmentKey then syncs.  Input an empty body to only force a sync.
+	// (PATCH /projects/{projectKey})
+	PatchProject(ctx context.Context, request PatchProjectRequestObject) (PatchProjectResponseObject, error)
+	// Add the project to the dev server
+	// (POST /projects/{projectKey})
+	PostAddProject(ctx context.Context, request PostAddProjectRequestObject) (PostAddProjectResponseObject, error)
+	// generate a typed accessor for each of the project's flags
+	// (GET /projects/{projectKey}/codegen)
+	GetProjectCodegen(ctx context.Context, request GetProjectCodegenRequestObject) (GetProjectCodegenResponseObject, error)
+	// list all environments for the given project
+	// (GET /projects/{projectKey}/environments)
+	GetEnvironments(ctx context.Context, request GetEnvironmentsRequestObject) (GetEnvironmentsResponseObject, error)
+	// forget the evaluations reported for the project
+	// (DELETE /projects/{projectKey}/evaluations)
+	DeleteEvaluations(ctx context.Context, request DeleteEvaluationsRequestObject) (DeleteEvaluationsResponseObject, error)
+	// summarize the flag evaluations and contexts reported in the events of the project's SDKs
+	// (GET /projects/{projectKey}/evaluations)
+	GetEvaluations(ctx context.Context, request GetEvaluationsRequestObject) (GetEvaluationsResponseObject, error)
+	// summarize the evaluations of a flag reported in the events of the project's SDKs
+	// (GET /projects/{projectKey}/evaluations/{flagKey})
+	GetFlagEvaluations(ctx context.Context, request GetFlagEvaluationsRequestObject) (GetFlagEvaluationsResponseObject, error)
+	// export the project's flag values, with its active overrides applied, as a test fixture
+	// (GET /projects/{projectKey}/export)
+	GetProjectExport(ctx context.Context, request GetProjectExportRequestObject) (GetProjectExportResponseObject, error)
+	// remove the faults injected into the project's SDK endpoints
+	// (DELETE /projects/{projectKey}/faults)
+	DeleteFaults(ctx context.Context, request DeleteFaultsRequestObject) (DeleteFaultsResponseObject, error)
+	// list the faults injected into the project's SDK endpoints
+	// (GET /projects/{projectKey}/faults)
+	GetFaults(ctx context.Context, request GetFaultsRequestObject) (GetFaultsResponseObject, error)
+	// inject a fault into the project's SDK endpoints
+	// (POST /projects/{projectKey}/faults)
+	PostFault(ctx context.Context, request PostFaultRequestObject) (PostFaultResponseObject, error)
+	// list the changes made to the project's overrides and flag values, most recent first
+	// (GET /projects/{projectKey}/history)
+	GetProjectHistory(ctx context.Context, request GetProjectHistoryRequestObject) (GetProjectHistoryResponseObject, error)
+	// replay the most recently undone override change, notifying connected SDKs
+	// (POST /projects/{projectKey}/history/redo)
+	PostRedo(ctx context.Context, request PostRedoRequestObject) (PostRedoResponseObject, error)
+	// revert the most recent override change that hasn't been undone, notifying connected SDKs
+	// (POST /projects/{projectKey}/history/undo)
+	PostUndo(ctx context.Context, request PostUndoRequestObject) (PostUndoResponseObject, error)
+	// Import a project from exported JSON data
+	// (POST /projects/{projectKey}/import)
+	PostImportProject(ctx context.Context, request PostImportProjectRequestObject) (PostImportProjectResponseObject, error)
+	// remove all overrides for the given project
+	// (DELETE /projects/{projectKey}/overrides)
+	DeleteOverrides(ctx context.Context, request DeleteOverridesRequestObject) (DeleteOverridesResponseObject, error)
+	// remove override for flag
+	// (DELETE /projects/{projectKey}/overrides/{flagKey})
+	DeleteFlagOverride(ctx context.Context, request DeleteFlagOverrideRequestObject) (DeleteFlagOverrideResponseObject, error)
+	// override flag value with value provided in the body
+	// (PUT /projects/{projectKey}/overrides/{flagKey})
+	PutOverrideFlag(ctx context.Context, request PutOverrideFlagRequestObject) (PutOverrideFlagResponseObject, error)
+	// list the saved override scenarios for the given project
+	// (GET /projects/{projectKey}/scenarios)
+	GetScenarios(ctx context.Context, request GetScenariosRequestObject) (GetScenariosResponseObject, error)
+	// remove the scenario. The project's overrides are left as they are.
+	// (DELETE /projects/{projectKey}/scenarios/{scenarioName})
+	DeleteScenario(ctx context.Context, request DeleteScenarioRequestObject) (DeleteScenarioResponseObject, error)
+	// save a named set of overrides. Without overrides, the project's current overrides are saved.
+	// (PUT /projects/{projectKey}/scenarios/{scenarioName})
+	PutScenario(ctx context.Context, request PutScenarioRequestObject) (PutScenarioResponseObject, error)
+	// replace the project's overrides with the scenario's, sending connected SDKs a single update
+	// (POST /projects/{projectKey}/scenarios/{scenarioName}/apply)
+	PostApplyScenario(ctx context.Context, request PostApplyScenarioRequestObject) (PostApplyScenarioResponseObject, error)
+	// list the project's snapshots, oldest first
+	// (GET /projects/{projectKey}/snapshots)
+	GetSnapshots(ctx context.Context, request GetSnapshotsRequestObject) (GetSnapshotsResponseObject, error)
+	// remove the snapshot. The project is left as it is.
+	// (DELETE /projects/{projectKey}/snapshots/{snapshotName})
+	DeleteSnapshot(ctx context.Context, request DeleteSnapshotRequestObject) (DeleteSnapshotResponseObject, error)
+	// save the project's flag state, overrides, context and available variations as a named snapshot
+	// (POST /projects/{projectKey}/snapshots/{snapshotName})
+	PostSnapshot(ctx context.Context, request PostSnapshotRequestObject) (PostSnapshotResponseObject, error)
+	// compare the snapshot with another snapshot, or with the project's live state
+	// (GET /projects/{projectKey}/snapshots/{snapshotName}/diff)
+	GetSnapshotDiff(ctx context.Context, request GetSnapshotDiffRequestObject) (GetSnapshotDiffResponseObject, error)
+	// replace the project's flag state, overrides, context and available variations with the snapshot's, sending
+	// connected SDKs a single update. Other projects are left as they are.
+	// (POST /projects/{projectKey}/snapshots/{snapshotName}/restore)
+	PostRestoreSnapshot(ctx context.Context, request PostRestoreSnapshotRequestObject) (PostRestoreSnapshotResponseObject, error)
+	// remove a source environment that was added to the project, along with its overrides
+	// (DELETE /projects/{projectKey}/source-environments/{environmentKey})
+	DeleteProjectEnvironment(ctx context.Context, request DeleteProjectEnvironmentRequestObject) (DeleteProjectEnvironmentResponseObject, error)
+	// sync another source environment into the project. Its flag state is kept as a dev project of its own, keyed
+	// projectKey:environmentKey, which SDKs can use as their credential.
+	// (POST /projects/{projectKey}/source-environments/{environmentKey})
+	PostProjectEnvironment(ctx context.Context, request PostProjectEnvironmentRequestObject) (PostProjectEnvironmentResponseObject, error)
+	// list the webhooks that state changes are posted to, oldest first
+	// (GET /webhooks)
+	GetWebhooks(ctx context.Context, request GetWebhooksRequestObject) (GetWebhooksResponseObject, error)
+	// register a URL to post the project's overrides, syncs, imports and deletion to. Deliveries are signed with an HMAC-SHA256 of the body, keyed with the webhook's secret, in the X-LDCLI-Signature header.
+	// (POST /webhooks)
+	PostWebhook(ctx context.Context, request PostWebhookRequestObject) (PostWebhookResponseObject, error)
+	// remove the webhook
+	// (DELETE /webhooks/{webhookId})
+	DeleteWebhook(ctx context.Context, request DeleteWebhookRequestObject) (DeleteWebhookResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetBackup operation middleware
+func (sh *strictHandler) GetBackup(w http.ResponseWriter, r *http.Request) {
+	var request GetBackupRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBackup(ctx, request.(GetBackupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBackup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetBackupResponseObject); ok {
+		if err := validResponse.VisitGetBackupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RestoreBackup operation middleware
+func (sh *strictHandler) RestoreBackup(w http.ResponseWriter, r *http.Request) {
+	var request RestoreBackupRequestObject
+
+	request.Body = r.Body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RestoreBackup(ctx, request.(RestoreBackupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RestoreBackup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RestoreBackupResponseObject); ok {
+		if err := validResponse.VisitRestoreBackupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetConnections operation middleware
+func (sh *strictHandler) GetConnections(w http.ResponseWriter, r *http.Request, params GetConnectionsParams) {
+	var request GetConnectionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetConnections(ctx, request.(GetConnectionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetConnections")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetConnectionsResponseObject); ok {
+		if err := validResponse.VisitGetConnectionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDebugSessions operation middleware
+func (sh *strictHandler) GetDebugSessions(w http.ResponseWriter, r *http.Request, params GetDebugSessionsParams) {
+	var request GetDebugSessionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDebugSessions(ctx, request.(GetDebugSessionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDebugSessions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDebugSessionsResponseObject); ok {
+		if err := validResponse.VisitGetDebugSessionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteDebugSession operation middleware
+func (sh *strictHandler) DeleteDebugSession(w http.ResponseWriter, r *http.Request, debugSessionKey string) {
+	var request DeleteDebugSessionRequestObject
+
+	request.DebugSessionKey = debugSessionKey
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteDebugSession(ctx, request.(DeleteDebugSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteDebugSession")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteDebugSessionResponseObject); ok {
+		if err := validResponse.VisitDeleteDebugSessionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDebugSessionEvents operation middleware
+func (sh *strictHandler) GetDebugSessionEvents(w http.ResponseWriter, r *http.Request, debugSessionKey string, params GetDebugSessionEventsParams) {
+	var request GetDebugSessionEventsRequestObject
+
+	request.DebugSessionKey = debugSessionKey
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDebugSessionEvents(ctx, request.(GetDebugSessionEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDebugSessionEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDebugSessionEventsResponseObject); ok {
+		if err := validResponse.VisitGetDebugSessionEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProjects operation middleware
+func (sh *strictHandler) GetProjects(w http.ResponseWriter, r *http.Request) {
+	var request GetProjectsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProjects(ctx, request.(GetProjectsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProjects")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProjectsResponseObject); ok {
+		if err := validResponse.VisitGetProjectsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteProject operation middleware
+func (sh *strictHandler) DeleteProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request DeleteProjectRequestObject
+
+	request.ProjectKey = projectKey
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteProject(ctx, request.(DeleteProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteProject")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteProjectResponseObject); ok {
+		if err := validResponse.VisitDeleteProjectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProject operation middleware
+func (sh *strictHandler) GetProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params GetProjectParams) {
+	var request GetProjectRequestObject
+
+	request.ProjectKey = projectKey
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProject(ctx, request.(GetProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProject")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProjectResponseObject); ok {
+		if err := validResponse.VisitGetProjectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PatchProject operation middleware
+func (sh *strictHandler) PatchProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params PatchProjectParams) {
+	var request PatchProjectRequestObject
+
+	request.ProjectKey = projectKey
+	request.Params = params
+
+	var body PatchProjectJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchProject(ctx, request.(PatchProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchProject")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchProjectResponseObject); ok {
+		if err := validResponse.VisitPatchProjectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAddProject operation middleware
+func (sh *strictHandler) PostAddProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params PostAddProjectParams) {
+	var request PostAddProjectRequestObject
+
+	request.ProjectKey = projectKey
+	request.Params = params
+
+	var body PostAddProjectJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAddProject(ctx, request.(PostAddProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAddProject")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAddProjectResponseObject); ok {
+		if err := validResponse.VisitPostAddProjectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProjectCodegen operation middleware
+func (sh *strictHandler) GetProjectCodegen(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params GetProjectCodegenParams) {
+	var request GetProjectCodegenRequestObject
+
+	request.ProjectKey = projectKey
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProjectCodegen(ctx, request.(GetProjectCodegenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProjectCodegen")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProjectCodegenResponseObject); ok {
+		if err := validResponse.VisitGetProjectCodegenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEnvironments operation middleware
+func (sh *strictHandler) GetEnvironments(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params GetEnvironmentsParams) {
+	var request GetEnvironmentsRequestObject
+
+	request.ProjectKey = projectKey
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEnvironments(ctx, request.(GetEnvironmentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEnvironments")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEnvironmentsResponseObject); ok {
+		if err := validResponse.VisitGetEnvironmentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteEvaluations operation middleware
+func (sh *strictHandler) DeleteEvaluations(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request DeleteEvaluationsRequestObject
+
+	request.ProjectKey = projectKey
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteEvaluations(ctx, request.(DeleteEvaluationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteEvaluations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteEvaluationsResponseObject); ok {
+		if err := validResponse.VisitDeleteEvaluationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEvaluations operation middleware
+func (sh *strictHandler) GetEvaluations(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request GetEvaluationsRequestObject
+
+	request.ProjectKey = projectKey
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEvaluations(ctx, request.(GetEvaluationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEvaluations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEvaluationsResponseObject); ok {
+		if err := validResponse.VisitGetEvaluationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlagEvaluations operation middleware
+func (sh *strictHandler) GetFlagEvaluations(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, flagKey FlagKey) {
+	var request GetFlagEvaluationsRequestObject
 
-func (response PostImportProject201JSONResponse) VisitPostImportProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	request.ProjectKey = projectKey
+	request.FlagKey = flagKey
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlagEvaluations(ctx, request.(GetFlagEvaluationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlagEvaluations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlagEvaluationsResponseObject); ok {
+		if err := validResponse.VisitGetFlagEvaluationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostImportProject400JSONResponse struct{ ErrorResponseJSONResponse }
+// GetProjectExport operation middleware
+func (sh *strictHandler) GetProjectExport(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params GetProjectExportParams) {
+	var request GetProjectExportRequestObject
 
-func (response PostImportProject400JSONResponse) VisitPostImportProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.ProjectKey = projectKey
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProjectExport(ctx, request.(GetProjectExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProjectExport")
+	}
 
-type PostImportProject409JSONResponse struct {
-	// Code specific error code encountered
-	Code string `json:"code"`
+	response, err := handler(r.Context(), w, r, request)
 
-	// Message description of the error
-	Message string `json:"message"`
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProjectExportResponseObject); ok {
+		if err := validResponse.VisitGetProjectExportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response PostImportProject409JSONResponse) VisitPostImportProjectResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+// DeleteFaults operation middleware
+func (sh *strictHandler) DeleteFaults(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request DeleteFaultsRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.ProjectKey = projectKey
 
-type DeleteOverridesRequestObject struct {
-	ProjectKey ProjectKey `json:"projectKey"`
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteFaults(ctx, request.(DeleteFaultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteFaults")
+	}
 
-type DeleteOverridesResponseObject interface {
-	VisitDeleteOverridesResponse(w http.ResponseWriter) error
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type DeleteOverrides204Response struct {
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteFaultsResponseObject); ok {
+		if err := validResponse.VisitDeleteFaultsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response DeleteOverrides204Response) VisitDeleteOverridesResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+// GetFaults operation middleware
+func (sh *strictHandler) GetFaults(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request GetFaultsRequestObject
 
-type DeleteOverrides404JSONResponse struct{ ErrorResponseJSONResponse }
+	request.ProjectKey = projectKey
 
-func (response DeleteOverrides404JSONResponse) VisitDeleteOverridesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFaults(ctx, request.(GetFaultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFaults")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type DeleteFlagOverrideRequestObject struct {
-	ProjectKey ProjectKey `json:"projectKey"`
-	FlagKey    FlagKey    `json:"flagKey"`
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFaultsResponseObject); ok {
+		if err := validResponse.VisitGetFaultsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteFlagOverrideResponseObject interface {
-	VisitDeleteFlagOverrideResponse(w http.ResponseWriter) error
-}
+// PostFault operation middleware
+func (sh *strictHandler) PostFault(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request PostFaultRequestObject
 
-type DeleteFlagOverride204Response struct {
-}
+	request.ProjectKey = projectKey
 
-func (response DeleteFlagOverride204Response) VisitDeleteFlagOverrideResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	var body PostFaultJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type DeleteFlagOverride404Response struct {
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostFault(ctx, request.(PostFaultRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostFault")
+	}
 
-func (response DeleteFlagOverride404Response) VisitDeleteFlagOverrideResponse(w http.ResponseWriter) error {
-	w.WriteHeader(404)
-	return nil
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type PutOverrideFlagRequestObject struct {
-	ProjectKey ProjectKey `json:"projectKey"`
-	FlagKey    FlagKey    `json:"flagKey"`
-	Body       *PutOverrideFlagJSONRequestBody
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostFaultResponseObject); ok {
+		if err := validResponse.VisitPostFaultResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PutOverrideFlagResponseObject interface {
-	VisitPutOverrideFlagResponse(w http.ResponseWriter) error
-}
+// GetProjectHistory operation middleware
+func (sh *strictHandler) GetProjectHistory(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params GetProjectHistoryParams) {
+	var request GetProjectHistoryRequestObject
 
-type PutOverrideFlag200JSONResponse struct{ FlagOverrideJSONResponse }
+	request.ProjectKey = projectKey
+	request.Params = params
 
-func (response PutOverrideFlag200JSONResponse) VisitPutOverrideFlagResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProjectHistory(ctx, request.(GetProjectHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProjectHistory")
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProjectHistoryResponseObject); ok {
+		if err := validResponse.VisitGetProjectHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PutOverrideFlag400JSONResponse struct{ ErrorResponseJSONResponse }
+// PostRedo operation middleware
+func (sh *strictHandler) PostRedo(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request PostRedoRequestObject
 
-func (response PutOverrideFlag400JSONResponse) VisitPutOverrideFlagResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.ProjectKey = projectKey
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRedo(ctx, request.(PostRedoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRedo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRedoResponseObject); ok {
+		if err := validResponse.VisitPostRedoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// get the backup
-	// (GET /backup)
-	GetBackup(ctx context.Context, request GetBackupRequestObject) (GetBackupResponseObject, error)
-	// post backup
-	// (POST /backup)
-	RestoreBackup(ctx context.Context, request RestoreBackupRequestObject) (RestoreBackupResponseObject, error)
-	// list all debug sessions with event counts
-	// (GET /debug-sessions)
-	GetDebugSessions(ctx context.Context, request GetDebugSessionsRequestObject) (GetDebugSessionsResponseObject, error)
-	// delete a specific debug session and all its events
-	// (DELETE /debug-sessions/{debugSessionKey})
-	DeleteDebugSession(ctx context.Context, request DeleteDebugSessionRequestObject) (DeleteDebugSessionResponseObject, error)
-	// get events for a specific debug session
-	// (GET /debug-sessions/{debugSessionKey}/events)
-	GetDebugSessionEvents(ctx context.Context, request GetDebugSessionEventsRequestObject) (GetDebugSessionEventsResponseObject, error)
-	// lists all projects that have been configured for the dev server
-	// (GET /projects)
-	GetProjects(ctx context.Context, request GetProjectsRequestObject) (GetProjectsResponseObject, error)
-	// remove the specified project from the dev server
-	// (DELETE /projects/{projectKey})
-	DeleteProject(ctx context.Context, request DeleteProjectRequestObject) (DeleteProjectResponseObject, error)
-	// get the specified project and its configuration for syncing from the LaunchDarkly Service
-	// (GET /projects/{projectKey})
-	GetProject(ctx context.Context, request GetProjectRequestObject) (GetProjectResponseObject, error)
-	// updates the project context or sourceEnvironmentKey then syncs.  Input an empty body to only force a sync.
-	// (PATCH /projects/{projectKey})
-	PatchProject(ctx context.Context, request PatchProjectRequestObject) (PatchProjectResponseObject, error)
-	// Add the project to the dev server
-	// (POST /projects/{projectKey})
-	PostAddProject(ctx context.Context, request PostAddProjectRequestObject) (PostAddProjectResponseObject, error)
-	// list all environments for the given project
-	// (GET /projects/{projectKey}/environments)
-	GetEnvironments(ctx context.Context, request GetEnvironmentsRequestObject) (GetEnvironmentsResponseObject, error)
-	// Import a project from exported JSON data
-	// (POST /projects/{projectKey}/import)
-	PostImportProject(ctx context.Context, request PostImportProjectRequestObject) (PostImportProjectResponseObject, error)
-	// remove all overrides for the given project
-	// (DELETE /projects/{projectKey}/overrides)
-	DeleteOverrides(ctx context.Context, request DeleteOverridesRequestObject) (DeleteOverridesResponseObject, error)
-	// remove override for flag
-	// (DELETE /projects/{projectKey}/overrides/{flagKey})
-	DeleteFlagOverride(ctx context.Context, request DeleteFlagOverrideRequestObject) (DeleteFlagOverrideResponseObject, error)
-	// override flag value with value provided in the body
-	// (PUT /projects/{projectKey}/overrides/{flagKey})
-	PutOverrideFlag(ctx context.Context, request PutOverrideFlagRequestObject) (PutOverrideFlagResponseObject, error)
+// PostUndo operation middleware
+func (sh *strictHandler) PostUndo(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request PostUndoRequestObject
+
+	request.ProjectKey = projectKey
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUndo(ctx, request.(PostUndoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUndo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostUndoResponseObject); ok {
+		if err := validResponse.VisitPostUndoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// PostImportProject operation middleware
+func (sh *strictHandler) PostImportProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request PostImportProjectRequestObject
+
+	request.ProjectKey = projectKey
+
+	var body PostImportProjectJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostImportProject(ctx, request.(PostImportProjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostImportProject")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostImportProjectResponseObject); ok {
+		if err := validResponse.VisitPostImportProjectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+// DeleteOverrides operation middleware
+func (sh *strictHandler) DeleteOverrides(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request DeleteOverridesRequestObject
 
-// GetBackup operation middleware
-func (sh *strictHandler) GetBackup(w http.ResponseWriter, r *http.Request) {
-	var request GetBackupRequestObject
+	request.ProjectKey = projectKey
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetBackup(ctx, request.(GetBackupRequestObject))
+		return sh.ssi.DeleteOverrides(ctx, request.(DeleteOverridesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetBackup")
+		handler = middleware(handler, "DeleteOverrides")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetBackupResponseObject); ok {
-		if err := validResponse.VisitGetBackupResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteOverridesResponseObject); ok {
+		if err := validResponse.VisitDeleteOverridesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1457,25 +4192,26 @@ func (sh *strictHandler) GetBackup(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// RestoreBackup operation middleware
-func (sh *strictHandler) RestoreBackup(w http.ResponseWriter, r *http.Request) {
-	var request RestoreBackupRequestObject
+// DeleteFlagOverride operation middleware
+func (sh *strictHandler) DeleteFlagOverride(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, flagKey FlagKey) {
+	var request DeleteFlagOverrideRequestObject
 
-	request.Body = r.Body
+	request.ProjectKey = projectKey
+	request.FlagKey = flagKey
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.RestoreBackup(ctx, request.(RestoreBackupRequestObject))
+		return sh.ssi.DeleteFlagOverride(ctx, request.(DeleteFlagOverrideRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RestoreBackup")
+		handler = middleware(handler, "DeleteFlagOverride")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RestoreBackupResponseObject); ok {
-		if err := validResponse.VisitRestoreBackupResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteFlagOverrideResponseObject); ok {
+		if err := validResponse.VisitDeleteFlagOverrideResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1483,25 +4219,34 @@ func (sh *strictHandler) RestoreBackup(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetDebugSessions operation middleware
-func (sh *strictHandler) GetDebugSessions(w http.ResponseWriter, r *http.Request, params GetDebugSessionsParams) {
-	var request GetDebugSessionsRequestObject
+// PutOverrideFlag operation middleware
+func (sh *strictHandler) PutOverrideFlag(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, flagKey FlagKey, params PutOverrideFlagParams) {
+	var request PutOverrideFlagRequestObject
 
+	request.ProjectKey = projectKey
+	request.FlagKey = flagKey
 	request.Params = params
 
+	var body PutOverrideFlagJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetDebugSessions(ctx, request.(GetDebugSessionsRequestObject))
+		return sh.ssi.PutOverrideFlag(ctx, request.(PutOverrideFlagRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetDebugSessions")
+		handler = middleware(handler, "PutOverrideFlag")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetDebugSessionsResponseObject); ok {
-		if err := validResponse.VisitGetDebugSessionsResponse(w); err != nil {
+	} else if validResponse, ok := response.(PutOverrideFlagResponseObject); ok {
+		if err := validResponse.VisitPutOverrideFlagResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1509,25 +4254,25 @@ func (sh *strictHandler) GetDebugSessions(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// DeleteDebugSession operation middleware
-func (sh *strictHandler) DeleteDebugSession(w http.ResponseWriter, r *http.Request, debugSessionKey string) {
-	var request DeleteDebugSessionRequestObject
+// GetScenarios operation middleware
+func (sh *strictHandler) GetScenarios(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request GetScenariosRequestObject
 
-	request.DebugSessionKey = debugSessionKey
+	request.ProjectKey = projectKey
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteDebugSession(ctx, request.(DeleteDebugSessionRequestObject))
+		return sh.ssi.GetScenarios(ctx, request.(GetScenariosRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteDebugSession")
+		handler = middleware(handler, "GetScenarios")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteDebugSessionResponseObject); ok {
-		if err := validResponse.VisitDeleteDebugSessionResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetScenariosResponseObject); ok {
+		if err := validResponse.VisitGetScenariosResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1535,26 +4280,26 @@ func (sh *strictHandler) DeleteDebugSession(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// GetDebugSessionEvents operation middleware
-func (sh *strictHandler) GetDebugSessionEvents(w http.ResponseWriter, r *http.Request, debugSessionKey string, params GetDebugSessionEventsParams) {
-	var request GetDebugSessionEventsRequestObject
+// DeleteScenario operation middleware
+func (sh *strictHandler) DeleteScenario(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, scenarioName ScenarioName) {
+	var request DeleteScenarioRequestObject
 
-	request.DebugSessionKey = debugSessionKey
-	request.Params = params
+	request.ProjectKey = projectKey
+	request.ScenarioName = scenarioName
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetDebugSessionEvents(ctx, request.(GetDebugSessionEventsRequestObject))
+		return sh.ssi.DeleteScenario(ctx, request.(DeleteScenarioRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetDebugSessionEvents")
+		handler = middleware(handler, "DeleteScenario")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetDebugSessionEventsResponseObject); ok {
-		if err := validResponse.VisitGetDebugSessionEventsResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteScenarioResponseObject); ok {
+		if err := validResponse.VisitDeleteScenarioResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1562,23 +4307,33 @@ func (sh *strictHandler) GetDebugSessionEvents(w http.ResponseWriter, r *http.Re
 	}
 }
 
-// GetProjects operation middleware
-func (sh *strictHandler) GetProjects(w http.ResponseWriter, r *http.Request) {
-	var request GetProjectsRequestObject
+// PutScenario operation middleware
+func (sh *strictHandler) PutScenario(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, scenarioName ScenarioName) {
+	var request PutScenarioRequestObject
+
+	request.ProjectKey = projectKey
+	request.ScenarioName = scenarioName
+
+	var body PutScenarioJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetProjects(ctx, request.(GetProjectsRequestObject))
+		return sh.ssi.PutScenario(ctx, request.(PutScenarioRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetProjects")
+		handler = middleware(handler, "PutScenario")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetProjectsResponseObject); ok {
-		if err := validResponse.VisitGetProjectsResponse(w); err != nil {
+	} else if validResponse, ok := response.(PutScenarioResponseObject); ok {
+		if err := validResponse.VisitPutScenarioResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1586,25 +4341,26 @@ func (sh *strictHandler) GetProjects(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// DeleteProject operation middleware
-func (sh *strictHandler) DeleteProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
-	var request DeleteProjectRequestObject
+// PostApplyScenario operation middleware
+func (sh *strictHandler) PostApplyScenario(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, scenarioName ScenarioName) {
+	var request PostApplyScenarioRequestObject
 
 	request.ProjectKey = projectKey
+	request.ScenarioName = scenarioName
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteProject(ctx, request.(DeleteProjectRequestObject))
+		return sh.ssi.PostApplyScenario(ctx, request.(PostApplyScenarioRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteProject")
+		handler = middleware(handler, "PostApplyScenario")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteProjectResponseObject); ok {
-		if err := validResponse.VisitDeleteProjectResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostApplyScenarioResponseObject); ok {
+		if err := validResponse.VisitPostApplyScenarioResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1612,26 +4368,25 @@ func (sh *strictHandler) DeleteProject(w http.ResponseWriter, r *http.Request, p
 	}
 }
 
-// GetProject operation middleware
-func (sh *strictHandler) GetProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params GetProjectParams) {
-	var request GetProjectRequestObject
+// GetSnapshots operation middleware
+func (sh *strictHandler) GetSnapshots(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
+	var request GetSnapshotsRequestObject
 
 	request.ProjectKey = projectKey
-	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetProject(ctx, request.(GetProjectRequestObject))
+		return sh.ssi.GetSnapshots(ctx, request.(GetSnapshotsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetProject")
+		handler = middleware(handler, "GetSnapshots")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetProjectResponseObject); ok {
-		if err := validResponse.VisitGetProjectResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetSnapshotsResponseObject); ok {
+		if err := validResponse.VisitGetSnapshotsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1639,33 +4394,26 @@ func (sh *strictHandler) GetProject(w http.ResponseWriter, r *http.Request, proj
 	}
 }
 
-// PatchProject operation middleware
-func (sh *strictHandler) PatchProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params PatchProjectParams) {
-	var request PatchProjectRequestObject
+// DeleteSnapshot operation middleware
+func (sh *strictHandler) DeleteSnapshot(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, snapshotName SnapshotName) {
+	var request DeleteSnapshotRequestObject
 
 	request.ProjectKey = projectKey
-	request.Params = params
-
-	var body PatchProjectJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.SnapshotName = snapshotName
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PatchProject(ctx, request.(PatchProjectRequestObject))
+		return sh.ssi.DeleteSnapshot(ctx, request.(DeleteSnapshotRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PatchProject")
+		handler = middleware(handler, "DeleteSnapshot")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PatchProjectResponseObject); ok {
-		if err := validResponse.VisitPatchProjectResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteSnapshotResponseObject); ok {
+		if err := validResponse.VisitDeleteSnapshotResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1673,33 +4421,26 @@ func (sh *strictHandler) PatchProject(w http.ResponseWriter, r *http.Request, pr
 	}
 }
 
-// PostAddProject operation middleware
-func (sh *strictHandler) PostAddProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params PostAddProjectParams) {
-	var request PostAddProjectRequestObject
+// PostSnapshot operation middleware
+func (sh *strictHandler) PostSnapshot(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, snapshotName SnapshotName) {
+	var request PostSnapshotRequestObject
 
 	request.ProjectKey = projectKey
-	request.Params = params
-
-	var body PostAddProjectJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.SnapshotName = snapshotName
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostAddProject(ctx, request.(PostAddProjectRequestObject))
+		return sh.ssi.PostSnapshot(ctx, request.(PostSnapshotRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostAddProject")
+		handler = middleware(handler, "PostSnapshot")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostAddProjectResponseObject); ok {
-		if err := validResponse.VisitPostAddProjectResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostSnapshotResponseObject); ok {
+		if err := validResponse.VisitPostSnapshotResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1707,26 +4448,27 @@ func (sh *strictHandler) PostAddProject(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// GetEnvironments operation middleware
-func (sh *strictHandler) GetEnvironments(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, params GetEnvironmentsParams) {
-	var request GetEnvironmentsRequestObject
+// GetSnapshotDiff operation middleware
+func (sh *strictHandler) GetSnapshotDiff(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, snapshotName SnapshotName, params GetSnapshotDiffParams) {
+	var request GetSnapshotDiffRequestObject
 
 	request.ProjectKey = projectKey
+	request.SnapshotName = snapshotName
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetEnvironments(ctx, request.(GetEnvironmentsRequestObject))
+		return sh.ssi.GetSnapshotDiff(ctx, request.(GetSnapshotDiffRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetEnvironments")
+		handler = middleware(handler, "GetSnapshotDiff")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetEnvironmentsResponseObject); ok {
-		if err := validResponse.VisitGetEnvironmentsResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetSnapshotDiffResponseObject); ok {
+		if err := validResponse.VisitGetSnapshotDiffResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1734,32 +4476,26 @@ func (sh *strictHandler) GetEnvironments(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// PostImportProject operation middleware
-func (sh *strictHandler) PostImportProject(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
-	var request PostImportProjectRequestObject
+// PostRestoreSnapshot operation middleware
+func (sh *strictHandler) PostRestoreSnapshot(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, snapshotName SnapshotName) {
+	var request PostRestoreSnapshotRequestObject
 
 	request.ProjectKey = projectKey
-
-	var body PostImportProjectJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.SnapshotName = snapshotName
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostImportProject(ctx, request.(PostImportProjectRequestObject))
+		return sh.ssi.PostRestoreSnapshot(ctx, request.(PostRestoreSnapshotRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostImportProject")
+		handler = middleware(handler, "PostRestoreSnapshot")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostImportProjectResponseObject); ok {
-		if err := validResponse.VisitPostImportProjectResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostRestoreSnapshotResponseObject); ok {
+		if err := validResponse.VisitPostRestoreSnapshotResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1767,25 +4503,26 @@ func (sh *strictHandler) PostImportProject(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// DeleteOverrides operation middleware
-func (sh *strictHandler) DeleteOverrides(w http.ResponseWriter, r *http.Request, projectKey ProjectKey) {
-	var request DeleteOverridesRequestObject
+// DeleteProjectEnvironment operation middleware
+func (sh *strictHandler) DeleteProjectEnvironment(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, environmentKey EnvironmentKey) {
+	var request DeleteProjectEnvironmentRequestObject
 
 	request.ProjectKey = projectKey
+	request.EnvironmentKey = environmentKey
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteOverrides(ctx, request.(DeleteOverridesRequestObject))
+		return sh.ssi.DeleteProjectEnvironment(ctx, request.(DeleteProjectEnvironmentRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteOverrides")
+		handler = middleware(handler, "DeleteProjectEnvironment")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteOverridesResponseObject); ok {
-		if err := validResponse.VisitDeleteOverridesResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteProjectEnvironmentResponseObject); ok {
+		if err := validResponse.VisitDeleteProjectEnvironmentResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1793,26 +4530,26 @@ func (sh *strictHandler) DeleteOverrides(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// DeleteFlagOverride operation middleware
-func (sh *strictHandler) DeleteFlagOverride(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, flagKey FlagKey) {
-	var request DeleteFlagOverrideRequestObject
+// PostProjectEnvironment operation middleware
+func (sh *strictHandler) PostProjectEnvironment(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, environmentKey EnvironmentKey) {
+	var request PostProjectEnvironmentRequestObject
 
 	request.ProjectKey = projectKey
-	request.FlagKey = flagKey
+	request.EnvironmentKey = environmentKey
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteFlagOverride(ctx, request.(DeleteFlagOverrideRequestObject))
+		return sh.ssi.PostProjectEnvironment(ctx, request.(PostProjectEnvironmentRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteFlagOverride")
+		handler = middleware(handler, "PostProjectEnvironment")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteFlagOverrideResponseObject); ok {
-		if err := validResponse.VisitDeleteFlagOverrideResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostProjectEnvironmentResponseObject); ok {
+		if err := validResponse.VisitPostProjectEnvironmentResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1820,14 +4557,37 @@ func (sh *strictHandler) DeleteFlagOverride(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// PutOverrideFlag operation middleware
-func (sh *strictHandler) PutOverrideFlag(w http.ResponseWriter, r *http.Request, projectKey ProjectKey, flagKey FlagKey) {
-	var request PutOverrideFlagRequestObject
+// GetWebhooks operation middleware
+func (sh *strictHandler) GetWebhooks(w http.ResponseWriter, r *http.Request, params GetWebhooksParams) {
+	var request GetWebhooksRequestObject
 
-	request.ProjectKey = projectKey
-	request.FlagKey = flagKey
+	request.Params = params
 
-	var body PutOverrideFlagJSONRequestBody
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWebhooks(ctx, request.(GetWebhooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWebhooks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetWebhooksResponseObject); ok {
+		if err := validResponse.VisitGetWebhooksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostWebhook operation middleware
+func (sh *strictHandler) PostWebhook(w http.ResponseWriter, r *http.Request) {
+	var request PostWebhookRequestObject
+
+	var body PostWebhookJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -1835,18 +4595,44 @@ func (sh *strictHandler) PutOverrideFlag(w http.ResponseWriter, r *http.Request,
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PutOverrideFlag(ctx, request.(PutOverrideFlagRequestObject))
+		return sh.ssi.PostWebhook(ctx, request.(PostWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PutOverrideFlag")
+		handler = middleware(handler, "PostWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PutOverrideFlagResponseObject); ok {
-		if err := validResponse.VisitPutOverrideFlagResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostWebhookResponseObject); ok {
+		if err := validResponse.VisitPostWebhookResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteWebhook operation middleware
+func (sh *strictHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookId string) {
+	var request DeleteWebhookRequestObject
+
+	request.WebhookId = webhookId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteWebhook(ctx, request.(DeleteWebhookRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteWebhook")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteWebhookResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
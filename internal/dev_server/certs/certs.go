@@ -0,0 +1,271 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/pkg/errors"
+)
+
+const (
+	caCertFile   = "ca.pem"
+	caKeyFile    = "ca-key.pem"
+	leafCertFile = "localhost.pem"
+	leafKeyFile  = "localhost-key.pem"
+
+	caValidity = 10 * 365 * 24 * time.Hour
+	// Apple platforms reject server certificates that are valid for longer than 825 days, even from a trusted CA.
+	leafValidity    = 397 * 24 * time.Hour
+	leafRenewBefore = 30 * 24 * time.Hour
+)
+
+// Dir is where the dev server keeps its CA and certificate, next to its state: in stateDir, or in the ldcli state
+// directory when stateDir is empty.
+func Dir(stateDir string) (string, error) {
+	if stateDir != "" {
+		dir := filepath.Join(stateDir, "tls")
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return "", errors.Wrap(err, "unable to create TLS directory")
+		}
+		return dir, nil
+	}
+	caPath, err := xdg.StateFile(filepath.Join("ldcli", "tls", caCertFile))
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create state directory")
+	}
+	return filepath.Dir(caPath), nil
+}
+
+// CA is a certificate authority local to this machine, which issues the dev server's certificate. Devices and
+// browsers trust the dev server once they trust the CA, and keep trusting it when the certificate is reissued for
+// new addresses.
+type CA struct {
+	Certificate *x509.Certificate
+	key         crypto.Signer
+	dir         string
+}
+
+// LoadOrCreateCA loads the CA kept in dir, creating it the first time.
+func LoadOrCreateCA(dir string) (*CA, error) {
+	certificate, key, err := readPair(filepath.Join(dir, caCertFile), filepath.Join(dir, caKeyFile))
+	if err == nil {
+		return &CA{Certificate: certificate, key: key, dir: dir}, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.Wrap(err, "unable to load CA")
+	}
+	return createCA(dir)
+}
+
+func createCA(dir string) (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serialNumber, err := randomSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	commonName := "ldcli dev server CA"
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		commonName += " (" + hostname + ")"
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"ldcli dev server"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create CA")
+	}
+	certificate, err := writePair(filepath.Join(dir, caCertFile), filepath.Join(dir, caKeyFile), der, key)
+	if err != nil {
+		return nil, err
+	}
+	return &CA{Certificate: certificate, key: key, dir: dir}, nil
+}
+
+// Path is the file holding the CA certificate, to install on devices.
+func (ca *CA) Path() string {
+	return filepath.Join(ca.dir, caCertFile)
+}
+
+// PEM is the CA certificate, PEM encoded.
+func (ca *CA) PEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Certificate.Raw})
+}
+
+// Fingerprint is the SHA-256 fingerprint of the CA certificate, which devices show when installing it.
+func (ca *CA) Fingerprint() string {
+	sum := sha256.Sum256(ca.Certificate.Raw)
+	hexBytes := make([]string, len(sum))
+	for i, b := range sum {
+		hexBytes[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hexBytes, ":")
+}
+
+// LeafCertificate returns a certificate for the hosts, which are host names or IP addresses. The certificate kept
+// next to the CA is reused while it covers the hosts and isn't close to expiring; otherwise a new one is issued.
+func (ca *CA) LeafCertificate(hosts []string) (tls.Certificate, error) {
+	certPath, keyPath := filepath.Join(ca.dir, leafCertFile), filepath.Join(ca.dir, leafKeyFile)
+	leaf, key, err := readPair(certPath, keyPath)
+	if err == nil && ca.stillServes(leaf, hosts) {
+		return ca.chain(leaf, key), nil
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return tls.Certificate{}, errors.Wrap(err, "unable to load certificate")
+	}
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serialNumber, err := randomSerialNumber()
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: hosts[0], Organization: []string{"ldcli dev server"}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(leafValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Certificate, leafKey.Public(), ca.key)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "unable to create certificate")
+	}
+	leaf, err = writePair(certPath, keyPath, der, leafKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return ca.chain(leaf, leafKey), nil
+}
+
+func (ca *CA) stillServes(leaf *x509.Certificate, hosts []string) bool {
+	if leaf.CheckSignatureFrom(ca.Certificate) != nil || time.Until(leaf.NotAfter) < leafRenewBefore {
+		return false
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			if !slices.ContainsFunc(leaf.IPAddresses, ip.Equal) {
+				return false
+			}
+		} else if !slices.Contains(leaf.DNSNames, host) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ca *CA) chain(leaf *x509.Certificate, key crypto.Signer) tls.Certificate {
+	return tls.Certificate{
+		Certificate: [][]byte{leaf.Raw, ca.Certificate.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+}
+
+// LocalHosts are the names and addresses the dev server can be reached at: localhost, the machine's host name, and
+// its LAN addresses, so that devices on the same network can connect.
+func LocalHosts() []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		hosts = append(hosts, hostname)
+		if !strings.Contains(hostname, ".") {
+			hosts = append(hosts, hostname+".local")
+		}
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return hosts
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		// Link-local addresses only work with a zone, which certificates can't name.
+		if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		if host := ipNet.IP.String(); !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+func readPair(certPath, keyPath string) (*x509.Certificate, crypto.Signer, error) {
+	pair, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	certificate, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.Errorf("unsupported private key in %s", keyPath)
+	}
+	return certificate, key, nil
+}
+
+func writePair(certPath, keyPath string, der []byte, key crypto.Signer) (*x509.Certificate, error) {
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(certPath), 0o700); err != nil {
+		return nil, errors.Wrap(err, "unable to create certificate directory")
+	}
+	err = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}), 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to write private key")
+	}
+	err = os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to write certificate")
+	}
+	return certificate, nil
+}
+
+func randomSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
@@ -0,0 +1,112 @@
+package certs_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/certs"
+)
+
+func TestLoadOrCreateCA(t *testing.T) {
+	dir := t.TempDir()
+
+	ca, err := certs.LoadOrCreateCA(dir)
+	require.NoError(t, err)
+	assert.True(t, ca.Certificate.IsCA)
+
+	reloaded, err := certs.LoadOrCreateCA(dir)
+	require.NoError(t, err)
+	assert.Equal(t, ca.Fingerprint(), reloaded.Fingerprint())
+}
+
+func TestLeafCertificate(t *testing.T) {
+	ca, err := certs.LoadOrCreateCA(t.TempDir())
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+
+	leaf, err := ca.LeafCertificate([]string{"localhost", "127.0.0.1", "192.168.1.20"})
+	require.NoError(t, err)
+
+	t.Run("is trusted for each host by devices that trust the CA", func(t *testing.T) {
+		for _, host := range []string{"localhost", "127.0.0.1", "192.168.1.20"} {
+			_, err := leaf.Leaf.Verify(x509.VerifyOptions{DNSName: host, Roots: roots})
+			assert.NoError(t, err, host)
+		}
+		_, err := leaf.Leaf.Verify(x509.VerifyOptions{DNSName: "192.168.1.21", Roots: roots})
+		assert.Error(t, err)
+	})
+
+	t.Run("is reused while it covers the hosts", func(t *testing.T) {
+		reused, err := ca.LeafCertificate([]string{"localhost", "192.168.1.20"})
+		require.NoError(t, err)
+		assert.Equal(t, leaf.Leaf.Raw, reused.Leaf.Raw)
+	})
+
+	t.Run("is reissued for new hosts", func(t *testing.T) {
+		reissued, err := ca.LeafCertificate([]string{"localhost", "10.0.0.5"})
+		require.NoError(t, err)
+		assert.NotEqual(t, leaf.Leaf.Raw, reissued.Leaf.Raw)
+		_, err = reissued.Leaf.Verify(x509.VerifyOptions{DNSName: "10.0.0.5", Roots: roots})
+		assert.NoError(t, err)
+	})
+}
+
+func TestListenerWithPlainFallback(t *testing.T) {
+	ca, err := certs.LoadOrCreateCA(t.TempDir())
+	require.NoError(t, err)
+	leaf, err := ca.LeafCertificate([]string{"localhost", "127.0.0.1"})
+	require.NoError(t, err)
+
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%s tls=%t", r.Proto, r.TLS != nil)
+	})}
+	config := &tls.Config{Certificates: []tls.Certificate{leaf}, NextProtos: []string{"h2", "http/1.1"}}
+	go func() { _ = server.Serve(certs.NewListenerWithPlainFallback(inner, config)) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+	httpsClient := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{RootCAs: roots},
+		ForceAttemptHTTP2: true,
+	}}
+	addr := inner.Addr().String()
+
+	for name, test := range map[string]struct {
+		get  func() (*http.Response, error)
+		body string
+	}{
+		"https": {func() (*http.Response, error) { return httpsClient.Get("https://" + addr) }, "HTTP/2.0 tls=true"},
+		"http":  {func() (*http.Response, error) { return http.Get("http://" + addr) }, "HTTP/1.1 tls=false"},
+	} {
+		t.Run("serves "+name, func(t *testing.T) {
+			response, err := test.get()
+			require.NoError(t, err)
+			defer response.Body.Close()
+			body, err := io.ReadAll(response.Body)
+			require.NoError(t, err)
+			assert.Equal(t, test.body, string(body))
+		})
+	}
+
+	t.Run("doesn't hold up other connections for one that sends nothing", func(t *testing.T) {
+		idle, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+		defer idle.Close()
+
+		response, err := http.Get("http://" + addr)
+		require.NoError(t, err)
+		_ = response.Body.Close()
+	})
+}
@@ -0,0 +1,101 @@
+package certs
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+)
+
+// tlsHandshakeRecord is the first byte of every TLS connection.
+const tlsHandshakeRecord = 0x16
+
+// sniffTimeout is how long a connection has to send its first byte before it is dropped.
+const sniffTimeout = 10 * time.Second
+
+// NewListenerWithPlainFallback serves TLS and plain HTTP on the same port, for --tls-allow-plain. Connections that
+// open with a TLS handshake are accepted as *tls.Conn, so that the HTTP server negotiates HTTP/2 and sees them as
+// TLS, and the rest as they are.
+func NewListenerWithPlainFallback(inner net.Listener, config *tls.Config) net.Listener {
+	l := &fallbackListener{
+		Listener: inner,
+		config:   config,
+		conns:    make(chan net.Conn),
+		done:     make(chan struct{}),
+	}
+	go l.acceptLoop()
+	return l
+}
+
+type fallbackListener struct {
+	net.Listener
+	config *tls.Config
+	conns  chan net.Conn
+	done   chan struct{}
+	once   sync.Once
+	err    error
+}
+
+// acceptLoop sniffs each connection in a goroutine of its own, so that a slow client doesn't hold up the others.
+func (l *fallbackListener) acceptLoop() {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			l.closeWith(err)
+			return
+		}
+		go l.sniff(conn)
+	}
+}
+
+func (l *fallbackListener) sniff(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	_ = conn.SetReadDeadline(time.Now().Add(sniffTimeout))
+	first, err := reader.Peek(1)
+	_ = conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	var accepted net.Conn = &bufferedConn{Conn: conn, reader: reader}
+	if first[0] == tlsHandshakeRecord {
+		accepted = tls.Server(accepted, l.config)
+	}
+	select {
+	case l.conns <- accepted:
+	case <-l.done:
+		_ = accepted.Close()
+	}
+}
+
+func (l *fallbackListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, l.err
+	}
+}
+
+func (l *fallbackListener) Close() error {
+	l.closeWith(net.ErrClosed)
+	return l.Listener.Close()
+}
+
+func (l *fallbackListener) closeWith(err error) {
+	l.once.Do(func() {
+		l.err = err
+		close(l.done)
+	})
+}
+
+// bufferedConn reads through the reader that peeked at the connection's first byte.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
@@ -0,0 +1,80 @@
+package daemon
+
+import (
+	"os"
+	"os/exec"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const pollInterval = 100 * time.Millisecond
+
+// Start runs the dev server in the background with the arguments, logging to logPath, and waits for it to record
+// in dir that it is serving.
+func Start(args []string, dir, logPath string, timeout time.Duration) (State, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return State{}, errors.Wrap(err, "unable to find the ldcli executable")
+	}
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return State{}, errors.Wrap(err, "unable to create log file")
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(executable, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = detachedProcAttr()
+	if err := cmd.Start(); err != nil {
+		return State{}, errors.Wrap(err, "unable to start the dev server")
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-exited:
+			return State{}, errors.Errorf("the dev server exited during startup (%v), see %s", err, logPath)
+		case <-deadline:
+			return State{}, errors.Errorf("the dev server didn't start within %s, see %s", timeout, logPath)
+		case <-ticker.C:
+			state, err := Running(dir)
+			if err == nil && state.PID == cmd.Process.Pid {
+				return state, nil
+			}
+		}
+	}
+}
+
+// WithoutArg returns args without the boolean flag, however it was given.
+func WithoutArg(args []string, flag string) []string {
+	return slices.DeleteFunc(slices.Clone(args), func(arg string) bool {
+		return arg == "--"+flag || arg == "--"+flag+"=true"
+	})
+}
+
+// Stop asks the dev server whose state is recorded in dir to shut down and waits for it to exit.
+func Stop(dir string, state State, timeout time.Duration) error {
+	process, err := os.FindProcess(state.PID)
+	if err != nil {
+		return errors.Wrap(err, "unable to find the dev server process")
+	}
+	if err := terminate(process); err != nil {
+		return errors.Wrap(err, "unable to stop the dev server")
+	}
+	deadline := time.Now().Add(timeout)
+	for processAlive(state.PID) {
+		if time.Now().After(deadline) {
+			return errors.Errorf("the dev server (pid %d) didn't stop within %s", state.PID, timeout)
+		}
+		time.Sleep(pollInterval)
+	}
+	Forget(dir, state.PID)
+	return nil
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+
+package daemon
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// terminate asks the process to shut down gracefully.
+func terminate(process *os.Process) error {
+	return process.Signal(syscall.SIGTERM)
+}
+
+// detachedProcAttr starts the process in a session of its own, so that it outlives the terminal it was started from.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,35 @@
+//go:build windows
+
+package daemon
+
+import (
+	"os"
+	"syscall"
+)
+
+const (
+	detachedProcess         = 0x00000008
+	processQueryLimitedInfo = 0x1000
+	stillActive             = 259
+)
+
+func processAlive(pid int) bool {
+	handle, err := syscall.OpenProcess(processQueryLimitedInfo, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(handle)
+	var exitCode uint32
+	err = syscall.GetExitCodeProcess(handle, &exitCode)
+	return err == nil && exitCode == stillActive
+}
+
+// terminate ends the process. Windows has no signal another process can send to ask for a graceful shutdown.
+func terminate(process *os.Process) error {
+	return process.Kill()
+}
+
+// detachedProcAttr starts the process without a console, so that it outlives the terminal it was started from.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | detachedProcess}
+}
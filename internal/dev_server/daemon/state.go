@@ -0,0 +1,133 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/pkg/errors"
+)
+
+// ErrNotRunning is returned when no dev server is running on this machine.
+var ErrNotRunning = errors.New("the dev server isn't running")
+
+// State is what a running dev server records about itself, so that other commands can find it.
+type State struct {
+	PID  int    `json:"pid"`
+	Port string `json:"port"`
+	TLS  bool   `json:"tls"`
+	// AllowPlain is set when the server also accepts plain HTTP on its TLS port.
+	AllowPlain bool      `json:"allowPlain,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+}
+
+// URL is where the dev server can be reached from this machine: over HTTPS when it only serves TLS.
+func (s State) URL() string {
+	if s.TLS && !s.AllowPlain {
+		return fmt.Sprintf("https://localhost:%s", s.Port)
+	}
+	return fmt.Sprintf("http://localhost:%s", s.Port)
+}
+
+// File is the path of one of the dev server's files in dir, or in the ldcli state directory when dir is empty. The
+// directory is created if it doesn't exist.
+func File(dir, name string) (string, error) {
+	if dir == "" {
+		path, err := xdg.StateFile(filepath.Join("ldcli", name))
+		return path, errors.Wrap(err, "unable to create state directory")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", errors.Wrap(err, "unable to create data directory")
+	}
+	return filepath.Join(dir, name), nil
+}
+
+const stateFile = "dev_server.json"
+
+// statePath is where the state of a server is recorded in dir. Only recording it creates dir, which a server that
+// removes its directory as it stops could otherwise get back.
+func statePath(dir string) (string, error) {
+	if dir == "" {
+		return File(dir, stateFile)
+	}
+	return filepath.Join(dir, stateFile), nil
+}
+
+const logFile = "dev_server.log"
+
+// LogPath is the file a detached dev server with its state in dir logs to.
+func LogPath(dir string) (string, error) {
+	return File(dir, logFile)
+}
+
+// RemoveDir removes the state directory of an ephemeral dev server once its state has been forgotten: its log, and
+// then the directory if nothing else is left in it. Nothing else is removed, so that being handed the wrong directory
+// can't lose anything else.
+func RemoveDir(dir string) error {
+	if err := os.Remove(filepath.Join(dir, logFile)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.Wrap(err, "unable to remove the log")
+	}
+	return errors.Wrap(os.Remove(dir), "unable to remove the state directory")
+}
+
+// Record records the running dev server's state in dir.
+func Record(dir string, state State) error {
+	path, err := File(dir, stateFile)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Forget removes the state recorded in dir if it belongs to the process, so that a server that is shutting down
+// doesn't remove the state of one that has since started.
+func Forget(dir string, pid int) {
+	path, err := statePath(dir)
+	if err != nil {
+		return
+	}
+	if state, err := read(path); err == nil && state.PID == pid {
+		_ = os.Remove(path)
+	}
+}
+
+// Running returns the state of the dev server recorded in dir, or ErrNotRunning. The state left behind by a server
+// that didn't shut down cleanly is removed.
+func Running(dir string) (State, error) {
+	path, err := statePath(dir)
+	if err != nil {
+		return State{}, err
+	}
+	state, err := read(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return State{}, ErrNotRunning
+	}
+	if err != nil {
+		return State{}, err
+	}
+	if !processAlive(state.PID) {
+		_ = os.Remove(path)
+		return State{}, ErrNotRunning
+	}
+	return state, nil
+}
+
+func read(path string) (State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return State{}, err
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, errors.Wrapf(err, "unable to read %s", path)
+	}
+	return state, nil
+}
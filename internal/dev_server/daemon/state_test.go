@@ -0,0 +1,111 @@
+package daemon_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/daemon"
+)
+
+func withStateHome(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+}
+
+func TestRunning(t *testing.T) {
+	withStateHome(t)
+
+	t.Run("returns ErrNotRunning when nothing was recorded", func(t *testing.T) {
+		_, err := daemon.Running("")
+		assert.ErrorIs(t, err, daemon.ErrNotRunning)
+	})
+
+	t.Run("returns the recorded server while its process runs", func(t *testing.T) {
+		recorded := daemon.State{PID: os.Getpid(), Port: "8765", StartedAt: time.Now().UTC().Truncate(time.Second)}
+		require.NoError(t, daemon.Record("", recorded))
+
+		state, err := daemon.Running("")
+		require.NoError(t, err)
+		assert.Equal(t, recorded, state)
+		assert.Equal(t, "http://localhost:8765", state.URL())
+
+		daemon.Forget("", os.Getpid())
+		_, err = daemon.Running("")
+		assert.ErrorIs(t, err, daemon.ErrNotRunning)
+	})
+
+	t.Run("is reached over HTTPS when it only serves TLS", func(t *testing.T) {
+		assert.Equal(t, "https://localhost:8765", daemon.State{Port: "8765", TLS: true}.URL())
+		assert.Equal(t, "http://localhost:8765", daemon.State{Port: "8765", TLS: true, AllowPlain: true}.URL())
+	})
+
+	t.Run("ignores servers that exited without cleaning up", func(t *testing.T) {
+		exited := exec.Command("go", "version")
+		require.NoError(t, exited.Run())
+		require.NoError(t, daemon.Record("", daemon.State{PID: exited.Process.Pid, Port: "8765"}))
+
+		_, err := daemon.Running("")
+		assert.ErrorIs(t, err, daemon.ErrNotRunning)
+	})
+
+	t.Run("doesn't forget another server's state", func(t *testing.T) {
+		require.NoError(t, daemon.Record("", daemon.State{PID: os.Getpid(), Port: "8765"}))
+		daemon.Forget("", os.Getpid()+1)
+
+		_, err := daemon.Running("")
+		assert.NoError(t, err)
+		daemon.Forget("", os.Getpid())
+	})
+
+	t.Run("keeps the state of servers with a directory of their own apart", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, daemon.Record(dir, daemon.State{PID: os.Getpid(), Port: "9000"}))
+
+		state, err := daemon.Running(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "9000", state.Port)
+		assert.FileExists(t, filepath.Join(dir, "dev_server.json"))
+		_, err = daemon.Running("")
+		assert.ErrorIs(t, err, daemon.ErrNotRunning)
+
+		logPath, err := daemon.LogPath(dir)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "dev_server.log"), logPath)
+	})
+}
+
+func TestRemoveDir(t *testing.T) {
+	t.Run("removes a server's directory once its state is forgotten", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "ephemeral")
+		require.NoError(t, daemon.Record(dir, daemon.State{PID: os.Getpid(), Port: "9000"}))
+		logPath, err := daemon.LogPath(dir)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(logPath, []byte("log"), 0o644))
+
+		daemon.Forget(dir, os.Getpid())
+		require.NoError(t, daemon.RemoveDir(dir))
+		assert.NoDirExists(t, dir)
+	})
+
+	t.Run("keeps directories with anything else in them", func(t *testing.T) {
+		dir := t.TempDir()
+		other := filepath.Join(dir, "keep.txt")
+		require.NoError(t, os.WriteFile(other, []byte("keep"), 0o644))
+
+		assert.Error(t, daemon.RemoveDir(dir))
+		assert.FileExists(t, other)
+	})
+}
+
+func TestWithoutArg(t *testing.T) {
+	args := []string{"dev-server", "start", "--detach", "--project", "p", "--detach=true"}
+	assert.Equal(t, []string{"dev-server", "start", "--project", "p"}, daemon.WithoutArg(args, "detach"))
+}
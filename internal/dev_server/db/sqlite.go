@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/pkg/errors"
 
@@ -19,6 +22,8 @@ import (
 type Sqlite struct {
 	database *sql.DB
 	dbPath   string
+	// keepAlive holds a connection to an in-memory database open, which SQLite frees once its last one closes.
+	keepAlive *sql.Conn
 
 	backupManager *backup.Manager
 }
@@ -26,10 +31,11 @@ type Sqlite struct {
 var _ model.Store = &Sqlite{}
 
 func (s *Sqlite) GetDevProjectKeys(ctx context.Context) ([]string, error) {
-	rows, err := s.database.Query("select key from projects")
+	rows, err := s.database.QueryContext(ctx, "select key from projects")
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	var keys []string
 	for rows.Next() {
 		var key string
@@ -42,20 +48,40 @@ func (s *Sqlite) GetDevProjectKeys(ctx context.Context) ([]string, error) {
 	return keys, nil
 }
 
+func (s *Sqlite) GetDevProjectKeysWithPrefix(ctx context.Context, prefix string) ([]string, error) {
+	rows, err := s.database.QueryContext(ctx, "SELECT key FROM projects WHERE substr(key, 1, ?) = ? ORDER BY key", len(prefix), prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err = rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
 func (s *Sqlite) GetDevProject(ctx context.Context, key string) (*model.Project, error) {
 	var project model.Project
 	var contextData string
 	var flagStateData string
+	var flagsData string
 
 	row := s.database.QueryRowContext(ctx, `
-        SELECT key, source_environment_key, context, last_sync_time, flag_state, payload_version
+        SELECT key, source_environment_key, context, last_sync_time, flag_state, flags_data, payload_version,
+               sdk_key, mobile_key, client_side_id
         FROM projects
         WHERE key = ?
     `, key)
 
 	if err := row.Scan(
 		&project.Key, &project.SourceEnvironmentKey, &contextData,
-		&project.LastSyncTime, &flagStateData, &project.PayloadVersion,
+		&project.LastSyncTime, &flagStateData, &flagsData, &project.PayloadVersion,
+		&project.Credentials.SdkKey, &project.Credentials.MobileKey, &project.Credentials.ClientSideID,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, model.NewErrNotFound("project", key)
@@ -73,15 +99,39 @@ func (s *Sqlite) GetDevProject(ctx context.Context, key string) (*model.Project,
 		return nil, errors.Wrap(err, "unable to unmarshal flag state data")
 	}
 
+	// Projects synced before flag configurations were stored have no flags data
+	if flagsData != "" {
+		if err := json.Unmarshal([]byte(flagsData), &project.FlagsData); err != nil {
+			return nil, errors.Wrap(err, "unable to unmarshal flags data")
+		}
+	}
+
 	return &project, nil
 }
 
-func (s *Sqlite) UpdateProject(ctx context.Context, project model.Project) (bool, error) {
-	flagsStateJson, err := json.Marshal(project.AllFlagsState)
-	if err != nil {
-		return false, errors.Wrap(err, "unable to marshal flags state when updating project")
+func (s *Sqlite) GetDevProjectKeyForCredential(ctx context.Context, credential string) (string, error) {
+	// Offline projects have no credentials, which mustn't match an empty one.
+	if credential == "" {
+		return "", model.NewErrNotFound("project", "for credential")
+	}
+	var key string
+	row := s.database.QueryRowContext(ctx, `
+		SELECT key
+		FROM projects
+		WHERE ? IN (sdk_key, mobile_key, client_side_id)
+		ORDER BY last_sync_time DESC
+		LIMIT 1
+	`, credential)
+	if err := row.Scan(&key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", model.NewErrNotFound("project", "for credential")
+		}
+		return "", err
 	}
+	return key, nil
+}
 
+func (s *Sqlite) UpdateProject(ctx context.Context, project model.Project) (updated bool, err error) {
 	tx, err := s.database.BeginTx(ctx, nil)
 	if err != nil {
 		return false, err
@@ -91,14 +141,80 @@ func (s *Sqlite) UpdateProject(ctx context.Context, project model.Project) (bool
 			_ = tx.Rollback()
 		}
 	}()
+	updated, err = updateProject(ctx, tx, project)
+	if err != nil {
+		return false, err
+	}
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+	return updated, nil
+}
+
+func (s *Sqlite) UpdateProjects(ctx context.Context, projects []model.Project) (payloadVersions []int, err error) {
+	tx, err := s.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	for _, project := range projects {
+		updated, err := updateProject(ctx, tx, project)
+		if err != nil {
+			return nil, err
+		}
+		if !updated {
+			return nil, model.NewErrNotFound("project", project.Key)
+		}
+		var payloadVersion int
+		err = tx.QueryRowContext(ctx, `
+			UPDATE projects
+			SET payload_version = payload_version + 1
+			WHERE key = ?
+			RETURNING payload_version
+		`, project.Key).Scan(&payloadVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to increment payload version")
+		}
+		payloadVersions = append(payloadVersions, payloadVersion)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return payloadVersions, nil
+}
+
+// updateProject updates the project in the transaction, returning false if it doesn't exist.
+func updateProject(ctx context.Context, tx *sql.Tx, project model.Project) (bool, error) {
+	flagsStateJson, err := json.Marshal(project.AllFlagsState)
+	if err != nil {
+		return false, errors.Wrap(err, "unable to marshal flags state when updating project")
+	}
+	flagsDataJson, err := marshalFlagsData(project)
+	if err != nil {
+		return false, errors.Wrap(err, "unable to marshal flags data when updating project")
+	}
+
 	result, err := tx.ExecContext(ctx, `
 		UPDATE projects
-		SET flag_state = ?, last_sync_time = ?, context=?, source_environment_key=?
+		SET flag_state = ?, flags_data = ?, last_sync_time = ?, context=?, source_environment_key=?,
+			sdk_key = ?, mobile_key = ?, client_side_id = ?
 		WHERE key = ?;
-	`, flagsStateJson, project.LastSyncTime, project.Context.JSONString(), project.SourceEnvironmentKey, project.Key)
+	`, flagsStateJson, flagsDataJson, project.LastSyncTime, project.Context.JSONString(), project.SourceEnvironmentKey,
+		project.Credentials.SdkKey, project.Credentials.MobileKey, project.Credentials.ClientSideID, project.Key)
 	if err != nil {
 		return false, errors.Wrap(err, "unable to execute update project")
 	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
 
 	// Delete all and add all new variations. Definitely room for optimization...
 	_, err = tx.ExecContext(ctx, `
@@ -122,37 +238,52 @@ func (s *Sqlite) UpdateProject(ctx context.Context, project model.Project) (bool
 	if err != nil {
 		return false, err
 	}
-
-	err = tx.Commit()
-	if err != nil {
-		return false, err
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return false, err
-	}
-	if rowsAffected == 0 {
-		return false, nil
-	}
-
 	return true, nil
 }
 
 func (s *Sqlite) DeleteDevProject(ctx context.Context, key string) (bool, error) {
-	result, err := s.database.Exec("DELETE FROM projects where key=?", key)
+	deleted, err := s.DeleteDevProjects(ctx, []string{key})
 	if err != nil {
 		return false, err
 	}
+	return len(deleted) > 0, nil
+}
 
-	rowsAffected, err := result.RowsAffected()
+func (s *Sqlite) DeleteDevProjects(ctx context.Context, keys []string) (deleted []string, err error) {
+	tx, err := s.database.BeginTx(ctx, nil)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	if rowsAffected == 0 {
-		return false, nil
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	for _, key := range keys {
+		result, err := tx.ExecContext(ctx, "DELETE FROM projects where key=?", key)
+		if err != nil {
+			return nil, err
+		}
+		// Foreign keys aren't enforced on this database, so the project's rows in other tables are deleted here. A
+		// project created again with the same key starts with a history of its own, which undo can't reach past.
+		for _, table := range []string{"override_history", "snapshots", "scenarios"} {
+			_, err = tx.ExecContext(ctx, "DELETE FROM "+table+" where project_key=?", key)
+			if err != nil {
+				return nil, err
+			}
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rowsAffected > 0 {
+			deleted = append(deleted, key)
+		}
 	}
-	return true, nil
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return deleted, nil
 }
 
 func InsertAvailableVariations(ctx context.Context, tx *sql.Tx, project model.Project) (err error) {
@@ -212,6 +343,10 @@ func (s *Sqlite) InsertProject(ctx context.Context, project model.Project) (err
 	if err != nil {
 		return errors.Wrap(err, "unable to marshal flags state when writing project")
 	}
+	flagsDataJson, err := marshalFlagsData(project)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal flags data when writing project")
+	}
 	tx, err := s.database.BeginTx(ctx, nil)
 	if err != nil {
 		return
@@ -229,6 +364,7 @@ SELECT 1 FROM projects WHERE key = ?
 		return
 	}
 	if projects.Next() {
+		_ = projects.Close()
 		err = model.NewErrAlreadyExists("project", project.Key)
 		return
 	}
@@ -237,15 +373,20 @@ SELECT 1 FROM projects WHERE key = ?
 		return
 	}
 	_, err = tx.Exec(`
-INSERT INTO projects (key, source_environment_key, context, last_sync_time, flag_state, payload_version)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO projects (key, source_environment_key, context, last_sync_time, flag_state, flags_data, payload_version,
+	sdk_key, mobile_key, client_side_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `,
 		project.Key,
 		project.SourceEnvironmentKey,
 		project.Context.JSONString(),
 		project.LastSyncTime,
 		string(flagsStateJson),
+		flagsDataJson,
 		project.PayloadVersion,
+		project.Credentials.SdkKey,
+		project.Credentials.MobileKey,
+		project.Credentials.ClientSideID,
 	)
 	if err != nil {
 		return
@@ -258,6 +399,19 @@ VALUES (?, ?, ?, ?, ?, ?)
 	return tx.Commit()
 }
 
+// marshalFlagsData serializes the project's flag configuration, leaving it empty for projects that have none (e.g.
+// imported ones) so that they keep falling back to their stored flag state.
+func marshalFlagsData(project model.Project) (string, error) {
+	if project.FlagsData.Flags == nil {
+		return "", nil
+	}
+	flagsDataJson, err := json.Marshal(project.FlagsData)
+	if err != nil {
+		return "", err
+	}
+	return string(flagsDataJson), nil
+}
+
 func (s *Sqlite) GetAvailableVariationsForProject(ctx context.Context, projectKey string) (map[string][]model.Variation, error) {
 	rows, err := s.database.QueryContext(ctx, `
 			SELECT flag_key, id, name, description, value
@@ -268,6 +422,7 @@ func (s *Sqlite) GetAvailableVariationsForProject(ctx context.Context, projectKe
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	availableVariations := make(map[string][]model.Variation)
 	for rows.Next() {
@@ -307,7 +462,7 @@ func (s *Sqlite) GetAvailableVariationsForProject(ctx context.Context, projectKe
 
 func (s *Sqlite) GetOverridesForProject(ctx context.Context, projectKey string) (model.Overrides, error) {
 	rows, err := s.database.QueryContext(ctx, `
-        SELECT  flag_key, active, value, version
+        SELECT  project_key, flag_key, active, value, version, expires_at, context_matcher
         FROM overrides 
         WHERE project_key = ?
     `, projectKey)
@@ -315,16 +470,38 @@ func (s *Sqlite) GetOverridesForProject(ctx context.Context, projectKey string)
 	if err != nil {
 		return nil, err
 	}
+	return scanOverrides(rows)
+}
+
+// GetExpiredOverrides returns the active overrides of every project that expired by now. Expiry times are compared
+// with julianday, since they're stored as text with the offset of the time they were written in.
+func (s *Sqlite) GetExpiredOverrides(ctx context.Context, now time.Time) (model.Overrides, error) {
+	rows, err := s.database.QueryContext(ctx, `
+        SELECT  project_key, flag_key, active, value, version, expires_at, context_matcher
+        FROM overrides
+        WHERE active AND expires_at IS NOT NULL AND julianday(expires_at) <= julianday(?)
+    `, now)
+
+	if err != nil {
+		return nil, err
+	}
+	return scanOverrides(rows)
+}
+
+func scanOverrides(rows *sql.Rows) (model.Overrides, error) {
 	defer rows.Close()
 
 	overrides := make(model.Overrides, 0)
 	for rows.Next() {
+		var projectKey string
 		var flagKey string
 		var active bool
 		var value string
 		var version int
+		var expiresAt sql.NullTime
+		var contextMatcher sql.NullString
 
-		err = rows.Scan(&flagKey, &active, &value, &version)
+		err := rows.Scan(&projectKey, &flagKey, &active, &value, &version, &expiresAt, &contextMatcher)
 		if err != nil {
 			return nil, err
 		}
@@ -334,16 +511,22 @@ func (s *Sqlite) GetOverridesForProject(ctx context.Context, projectKey string)
 		if err != nil {
 			return nil, err
 		}
-		overrides = append(overrides, model.Override{
+		override := model.Override{
 			ProjectKey: projectKey,
 			FlagKey:    flagKey,
 			Value:      ldValue,
 			Active:     active,
 			Version:    version,
-		})
+			ExpiresAt:  expiresAt.Time,
+		}
+		override.ContextMatcher, err = parseNullContextMatcher(contextMatcher)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse context matcher of override for flag %s", flagKey)
+		}
+		overrides = append(overrides, override)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
@@ -356,11 +539,13 @@ func (s *Sqlite) UpsertOverride(ctx context.Context, override model.Override) (m
 		return model.Override{}, errors.Wrap(err, "unable to marshal override value when writing override")
 	}
 	row := s.database.QueryRowContext(ctx, `
-		INSERT INTO overrides (project_key, flag_key, value, active)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO overrides (project_key, flag_key, value, active, expires_at, context_matcher)
+		VALUES (?, ?, ?, ?, ?, ?)
 			ON CONFLICT(flag_key, project_key) DO UPDATE SET
 			    value=excluded.value,
 			    active=excluded.active,
+			    expires_at=excluded.expires_at,
+			    context_matcher=excluded.context_matcher,
 			    version=version+1
 		RETURNING project_key, flag_key, active, value, version;
 	`,
@@ -368,6 +553,8 @@ func (s *Sqlite) UpsertOverride(ctx context.Context, override model.Override) (m
 		override.FlagKey,
 		valueJson,
 		override.Active,
+		nullTime(override.ExpiresAt),
+		nullContextMatcher(override.ContextMatcher),
 	)
 	var tempValue []byte
 	if err := row.Scan(&override.ProjectKey, &override.FlagKey, &override.Active, &tempValue, &override.Version); err != nil {
@@ -379,6 +566,29 @@ func (s *Sqlite) UpsertOverride(ctx context.Context, override model.Override) (m
 	return override, nil
 }
 
+// nullTime stores the zero time as NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func nullContextMatcher(matcher *model.ContextMatcher) sql.NullString {
+	if matcher == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: matcher.String(), Valid: true}
+}
+
+func parseNullContextMatcher(matcher sql.NullString) (*model.ContextMatcher, error) {
+	if !matcher.Valid {
+		return nil, nil
+	}
+	parsed, err := model.ParseContextMatcher(matcher.String)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
 func (s *Sqlite) IncrementProjectPayloadVersion(ctx context.Context, projectKey string) (int, error) {
 	row := s.database.QueryRowContext(ctx, `
 		UPDATE projects
@@ -414,124 +624,903 @@ func (s *Sqlite) DeactivateOverride(ctx context.Context, projectKey, flagKey str
 	return version, nil
 }
 
-func (s *Sqlite) RestoreBackup(ctx context.Context, stream io.Reader) (string, error) {
-	filepath, err := s.backupManager.RestoreToFile(ctx, stream)
-	if err != nil {
-		return "", errors.Wrap(err, "unable to restore backup db")
-	}
-	err = s.database.Close()
+func (s *Sqlite) UpsertScenario(ctx context.Context, scenario model.Scenario) error {
+	overridesJson, err := json.Marshal(scenario.Overrides)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to close database before restoring backup")
+		return errors.Wrap(err, "unable to marshal scenario overrides when writing scenario")
 	}
-	err = os.Rename(filepath, s.dbPath)
+	_, err = s.database.ExecContext(ctx, `
+		INSERT INTO scenarios (project_key, name, overrides)
+		VALUES (?, ?, ?)
+			ON CONFLICT(project_key, name) DO UPDATE SET
+			    overrides=excluded.overrides
+	`, scenario.ProjectKey, scenario.Name, string(overridesJson))
 	if err != nil {
-		// panic because this would really leave the app in an invalid state
-		panic(err)
+		return errors.Wrap(err, "unable to upsert scenario")
 	}
-	s.database, err = sql.Open("sqlite3", s.dbPath)
+	return nil
+}
+
+func (s *Sqlite) GetScenariosForProject(ctx context.Context, projectKey string) ([]model.Scenario, error) {
+	rows, err := s.database.QueryContext(ctx, `
+		SELECT name, overrides
+		FROM scenarios
+		WHERE project_key = ?
+		ORDER BY name
+	`, projectKey)
 	if err != nil {
-		// panic because this would really leave the app in an invalid state
-		panic(err)
+		return nil, err
 	}
+	defer rows.Close()
 
-	err = s.runMigrations(ctx)
-	if err != nil {
-		return "", errors.Wrap(err, "unable to run migrations after restoring backup")
+	scenarios := make([]model.Scenario, 0)
+	for rows.Next() {
+		scenario := model.Scenario{ProjectKey: projectKey}
+		var overrides string
+		err = rows.Scan(&scenario.Name, &overrides)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(overrides), &scenario.Overrides)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to unmarshal overrides for scenario %s", scenario.Name)
+		}
+		scenarios = append(scenarios, scenario)
 	}
 
-	return filepath, err
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return scenarios, nil
 }
 
-func (s *Sqlite) CreateBackup(ctx context.Context) (io.ReadCloser, int64, error) {
-	backupPath, err := s.backupManager.MakeBackupFile(ctx)
-	if err != nil {
-		return nil, 0, errors.Wrapf(err, "unable to make backup file, %s", backupPath)
-	}
-	fi, err := os.Open(backupPath)
-	if err != nil {
-		return nil, 0, errors.Wrapf(err, "unable to open backup db at %s", backupPath)
+func (s *Sqlite) GetScenario(ctx context.Context, projectKey, name string) (*model.Scenario, error) {
+	row := s.database.QueryRowContext(ctx, `
+		SELECT overrides
+		FROM scenarios
+		WHERE project_key = ? AND name = ?
+	`, projectKey, name)
+	var overrides string
+	if err := row.Scan(&overrides); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewErrNotFound("scenario", name)
+		}
+		return nil, err
 	}
-	stat, err := fi.Stat()
-	if err != nil {
-		return nil, 0, errors.Wrapf(err, "unable to stat backup db at %s", backupPath)
+
+	scenario := model.Scenario{ProjectKey: projectKey, Name: name}
+	if err := json.Unmarshal([]byte(overrides), &scenario.Overrides); err != nil {
+		return nil, errors.Wrapf(err, "unable to unmarshal overrides for scenario %s", name)
 	}
-	return fi, stat.Size(), nil
+	return &scenario, nil
 }
 
-func NewSqlite(ctx context.Context, dbPath string) (*Sqlite, error) {
-	store := new(Sqlite)
-	store.dbPath = dbPath
-	store.backupManager = backup.NewManager(dbPath, "main", "ld_cli_*.bak", "ld_cli_restore_*.db")
-	store.backupManager.AddValidationQueries(validationQueries...)
-	db, err := sql.Open("sqlite3", dbPath)
+func (s *Sqlite) DeleteScenario(ctx context.Context, projectKey, name string) (bool, error) {
+	result, err := s.database.ExecContext(ctx, "DELETE FROM scenarios WHERE project_key = ? AND name = ?", projectKey, name)
 	if err != nil {
-		return &Sqlite{}, err
+		return false, err
 	}
-	store.database = db
-	err = store.runMigrations(ctx)
+
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return &Sqlite{}, err
+		return false, err
 	}
-	return store, nil
-}
-
-var validationQueries = []string{
-	"SELECT COUNT(1) from projects",
-	"SELECT COUNT(1) from overrides",
-	"SELECT COUNT(1) from available_variations",
+	return rowsAffected > 0, nil
 }
 
-func (s *Sqlite) runMigrations(ctx context.Context) error {
+// ReplaceOverrides deactivates the project's active overrides that aren't in values and upserts the ones that are,
+// leaving overrides that already hold the same value untouched so that their version doesn't change.
+func (s *Sqlite) ReplaceOverrides(ctx context.Context, projectKey string, values map[string]ldvalue.Value) (payloadVersion int, err error) {
 	tx, err := s.database.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer func() {
 		if err != nil {
 			_ = tx.Rollback()
 		}
 	}()
-	_, err = tx.Exec(`
-	CREATE TABLE IF NOT EXISTS projects (
-		key text PRIMARY KEY,
-		source_environment_key text NOT NULL,
-		context text NOT NULL,
-		last_sync_time timestamp NOT NULL,
-		flag_state TEXT NOT NULL,
-		payload_version INTEGER NOT NULL DEFAULT 1
-	)`)
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT flag_key, value, expires_at IS NOT NULL OR context_matcher IS NOT NULL
+		FROM overrides
+		WHERE project_key = ? AND active = true
+	`, projectKey)
 	if err != nil {
-		return err
+		return 0, errors.Wrapf(err, "unable to fetch overrides for project %s", projectKey)
 	}
-
-	// Migration: add payload_version to existing databases that predate this column.
-	_, err = tx.Exec(`ALTER TABLE projects ADD COLUMN payload_version INTEGER NOT NULL DEFAULT 1`)
-	if err != nil && !strings.Contains(err.Error(), "duplicate column name") {
-		return err
+	active := make(map[string]ldvalue.Value)
+	// Overrides with options are rewritten even when their value is unchanged, so that the options are cleared.
+	hasOptions := make(map[string]bool)
+	for rows.Next() {
+		var flagKey, value string
+		var options bool
+		if err = rows.Scan(&flagKey, &value, &options); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		var ldValue ldvalue.Value
+		if err = json.Unmarshal([]byte(value), &ldValue); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		active[flagKey] = ldValue
+		hasOptions[flagKey] = options
 	}
-	err = nil
-
-	_, err = tx.Exec(`
-	CREATE TABLE IF NOT EXISTS overrides (
-		project_key text NOT NULL,
-		flag_key text NOT NULL,
-		value text NOT NULL,
-		active boolean NOT NULL default TRUE,
-		version integer NOT NULL default 1,
-		UNIQUE (project_key, flag_key) ON CONFLICT REPLACE
-	)`)
-	if err != nil {
-		return err
+	if err = rows.Err(); err != nil {
+		_ = rows.Close()
+		return 0, err
+	}
+	if err = rows.Close(); err != nil {
+		return 0, err
 	}
 
-	_, err = tx.Exec(`
-	CREATE TABLE IF NOT EXISTS available_variations (
-		project_key text NOT NULL,
-		flag_key text NOT NULL,
-		id text NOT NULL,
-		value text NOT NULL, 
-		description text,
-		name text,
+	for flagKey := range active {
+		if _, ok := values[flagKey]; ok {
+			continue
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE overrides
+			SET active = false, version = version+1
+			WHERE project_key = ? AND flag_key = ?
+		`, projectKey, flagKey)
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable to deactivate override for flag %s", flagKey)
+		}
+	}
+	for flagKey, value := range values {
+		if current, ok := active[flagKey]; ok && current.Equal(value) && !hasOptions[flagKey] {
+			continue
+		}
+		var valueJson []byte
+		valueJson, err = value.MarshalJSON()
+		if err != nil {
+			return 0, errors.Wrap(err, "unable to marshal override value when writing override")
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO overrides (project_key, flag_key, value, active, expires_at, context_matcher)
+			VALUES (?, ?, ?, true, NULL, NULL)
+				ON CONFLICT(flag_key, project_key) DO UPDATE SET
+				    value=excluded.value,
+				    active=excluded.active,
+				    expires_at=excluded.expires_at,
+				    context_matcher=excluded.context_matcher,
+				    version=version+1
+		`, projectKey, flagKey, valueJson)
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable to upsert override for flag %s", flagKey)
+		}
+	}
+
+	row := tx.QueryRowContext(ctx, `
+		UPDATE projects
+		SET payload_version = payload_version + 1
+		WHERE key = ?
+		RETURNING payload_version
+	`, projectKey)
+	if err = row.Scan(&payloadVersion); err != nil {
+		return 0, errors.Wrap(err, "unable to increment payload version")
+	}
+	return payloadVersion, tx.Commit()
+}
+
+func (s *Sqlite) SetOverrides(ctx context.Context, projectKey string, overrides model.Overrides) (payloadVersion int, err error) {
+	tx, err := s.database.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, override := range overrides {
+		if !override.Active {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE overrides
+				SET active = false, version = version+1
+				WHERE project_key = ? AND flag_key = ?
+			`, projectKey, override.FlagKey)
+			if err != nil {
+				return 0, errors.Wrapf(err, "unable to deactivate override for flag %s", override.FlagKey)
+			}
+			continue
+		}
+		var valueJson []byte
+		valueJson, err = override.Value.MarshalJSON()
+		if err != nil {
+			return 0, errors.Wrap(err, "unable to marshal override value when writing override")
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO overrides (project_key, flag_key, value, active, expires_at, context_matcher)
+			VALUES (?, ?, ?, true, ?, ?)
+				ON CONFLICT(flag_key, project_key) DO UPDATE SET
+				    value=excluded.value,
+				    active=excluded.active,
+				    expires_at=excluded.expires_at,
+				    context_matcher=excluded.context_matcher,
+				    version=version+1
+		`,
+			projectKey,
+			override.FlagKey,
+			valueJson,
+			nullTime(override.ExpiresAt),
+			nullContextMatcher(override.ContextMatcher),
+		)
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable to upsert override for flag %s", override.FlagKey)
+		}
+	}
+
+	row := tx.QueryRowContext(ctx, `
+		UPDATE projects
+		SET payload_version = payload_version + 1
+		WHERE key = ?
+		RETURNING payload_version
+	`, projectKey)
+	if err = row.Scan(&payloadVersion); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, model.NewErrNotFound("project", projectKey)
+		}
+		return 0, errors.Wrap(err, "unable to increment payload version")
+	}
+	return payloadVersion, tx.Commit()
+}
+
+func (s *Sqlite) AppendHistory(ctx context.Context, projectKey string, entries []model.HistoryEntry) (recorded []model.HistoryEntry, err error) {
+	tx, err := s.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var changeID int
+	row := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(change_id), 0) + 1 FROM override_history`)
+	if err = row.Scan(&changeID); err != nil {
+		return nil, errors.Wrap(err, "unable to allocate change id")
+	}
+	recorded = make([]model.HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		var oldValue, newValue sql.NullString
+		oldValue, err = nullValue(entry.OldValue)
+		if err != nil {
+			return nil, err
+		}
+		newValue, err = nullValue(entry.NewValue)
+		if err != nil {
+			return nil, err
+		}
+		entry.ChangeID = changeID
+		entry.ProjectKey = projectKey
+		row = tx.QueryRowContext(ctx, `
+			INSERT INTO override_history
+				(change_id, project_key, flag_key, action, old_value, new_value, old_expires_at, old_context_matcher,
+				new_expires_at, new_context_matcher, source, reverts, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`,
+			changeID,
+			projectKey,
+			entry.FlagKey,
+			entry.Action,
+			oldValue,
+			newValue,
+			nullTime(entry.OldOptions.ExpiresAt),
+			nullContextMatcher(entry.OldOptions.ContextMatcher),
+			nullTime(entry.NewOptions.ExpiresAt),
+			nullContextMatcher(entry.NewOptions.ContextMatcher),
+			entry.Source,
+			sql.NullInt64{Int64: int64(entry.Reverts), Valid: entry.Reverts != 0},
+			entry.Timestamp,
+		)
+		if err = row.Scan(&entry.ID); err != nil {
+			return nil, errors.Wrapf(err, "unable to append history of flag %s", entry.FlagKey)
+		}
+		recorded = append(recorded, entry)
+	}
+	return recorded, tx.Commit()
+}
+
+// nullValue stores a missing value as NULL.
+func nullValue(value *ldvalue.Value) (sql.NullString, error) {
+	if value == nil {
+		return sql.NullString{}, nil
+	}
+	valueJson, err := value.MarshalJSON()
+	if err != nil {
+		return sql.NullString{}, errors.Wrap(err, "unable to marshal history value")
+	}
+	return sql.NullString{String: string(valueJson), Valid: true}, nil
+}
+
+func (s *Sqlite) GetHistory(ctx context.Context, projectKey string) ([]model.HistoryEntry, error) {
+	rows, err := s.database.QueryContext(ctx, `
+		SELECT id, change_id, flag_key, action, old_value, new_value, old_expires_at, old_context_matcher,
+			new_expires_at, new_context_matcher, source, reverts, created_at
+		FROM override_history
+		WHERE project_key = ?
+		ORDER BY id
+	`, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]model.HistoryEntry, 0)
+	for rows.Next() {
+		entry := model.HistoryEntry{ProjectKey: projectKey}
+		var oldValue, newValue, oldContextMatcher, newContextMatcher sql.NullString
+		var oldExpiresAt, newExpiresAt sql.NullTime
+		var reverts sql.NullInt64
+		err = rows.Scan(&entry.ID, &entry.ChangeID, &entry.FlagKey, &entry.Action, &oldValue, &newValue, &oldExpiresAt,
+			&oldContextMatcher, &newExpiresAt, &newContextMatcher, &entry.Source, &reverts, &entry.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		entry.OldOptions.ExpiresAt = oldExpiresAt.Time
+		entry.OldOptions.ContextMatcher, err = parseNullContextMatcher(oldContextMatcher)
+		if err != nil {
+			return nil, err
+		}
+		entry.NewOptions.ExpiresAt = newExpiresAt.Time
+		entry.NewOptions.ContextMatcher, err = parseNullContextMatcher(newContextMatcher)
+		if err != nil {
+			return nil, err
+		}
+		entry.OldValue, err = parseNullValue(oldValue)
+		if err != nil {
+			return nil, err
+		}
+		entry.NewValue, err = parseNullValue(newValue)
+		if err != nil {
+			return nil, err
+		}
+		entry.Reverts = int(reverts.Int64)
+		history = append(history, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func parseNullValue(value sql.NullString) (*ldvalue.Value, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	var ldValue ldvalue.Value
+	if err := json.Unmarshal([]byte(value.String), &ldValue); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal history value")
+	}
+	return &ldValue, nil
+}
+
+// snapshotOverride is how an override is kept in a snapshot.
+type snapshotOverride struct {
+	FlagKey        string        `json:"flagKey"`
+	Value          ldvalue.Value `json:"value"`
+	Active         bool          `json:"active"`
+	ExpiresAt      *time.Time    `json:"expiresAt,omitempty"`
+	ContextMatcher string        `json:"contextMatcher,omitempty"`
+}
+
+func (s *Sqlite) InsertSnapshot(ctx context.Context, snapshot model.Snapshot) error {
+	flagsStateJson, err := json.Marshal(snapshot.AllFlagsState)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal flags state when writing snapshot")
+	}
+	flagsDataJson, err := marshalFlagsData(model.Project{FlagsData: snapshot.FlagsData})
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal flags data when writing snapshot")
+	}
+	variationsJson, err := json.Marshal(snapshot.AvailableVariations)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal available variations when writing snapshot")
+	}
+	overrides := make([]snapshotOverride, 0, len(snapshot.Overrides))
+	for _, override := range snapshot.Overrides {
+		stored := snapshotOverride{FlagKey: override.FlagKey, Value: override.Value, Active: override.Active}
+		if !override.ExpiresAt.IsZero() {
+			stored.ExpiresAt = &override.ExpiresAt
+		}
+		if override.ContextMatcher != nil {
+			stored.ContextMatcher = override.ContextMatcher.String()
+		}
+		overrides = append(overrides, stored)
+	}
+	overridesJson, err := json.Marshal(overrides)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal overrides when writing snapshot")
+	}
+
+	_, err = s.database.ExecContext(ctx, `
+		INSERT INTO snapshots
+			(project_key, name, created_at, context, flag_state, flags_data, available_variations, overrides)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		snapshot.ProjectKey,
+		snapshot.Name,
+		snapshot.CreatedAt,
+		snapshot.Context.JSONString(),
+		string(flagsStateJson),
+		flagsDataJson,
+		string(variationsJson),
+		string(overridesJson),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return model.NewErrAlreadyExists("snapshot", snapshot.Name)
+		}
+		return errors.Wrap(err, "unable to insert snapshot")
+	}
+	return nil
+}
+
+func (s *Sqlite) GetSnapshotsForProject(ctx context.Context, projectKey string) ([]model.Snapshot, error) {
+	rows, err := s.database.QueryContext(ctx, `
+		SELECT name, created_at, context, flag_state, flags_data, available_variations, overrides
+		FROM snapshots
+		WHERE project_key = ?
+		ORDER BY created_at, name
+	`, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := make([]model.Snapshot, 0)
+	for rows.Next() {
+		snapshot, err := scanSnapshot(rows, projectKey)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (s *Sqlite) GetSnapshot(ctx context.Context, projectKey, name string) (*model.Snapshot, error) {
+	row := s.database.QueryRowContext(ctx, `
+		SELECT name, created_at, context, flag_state, flags_data, available_variations, overrides
+		FROM snapshots
+		WHERE project_key = ? AND name = ?
+	`, projectKey, name)
+	snapshot, err := scanSnapshot(row, projectKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewErrNotFound("snapshot", name)
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+func scanSnapshot(row interface{ Scan(...any) error }, projectKey string) (model.Snapshot, error) {
+	snapshot := model.Snapshot{ProjectKey: projectKey}
+	var contextData, flagStateData, flagsData, variationsData, overridesData string
+	err := row.Scan(&snapshot.Name, &snapshot.CreatedAt, &contextData, &flagStateData, &flagsData, &variationsData,
+		&overridesData)
+	if err != nil {
+		return model.Snapshot{}, err
+	}
+
+	if err := json.Unmarshal([]byte(contextData), &snapshot.Context); err != nil {
+		return model.Snapshot{}, errors.Wrapf(err, "unable to unmarshal context of snapshot %s", snapshot.Name)
+	}
+	if err := json.Unmarshal([]byte(flagStateData), &snapshot.AllFlagsState); err != nil {
+		return model.Snapshot{}, errors.Wrapf(err, "unable to unmarshal flag state of snapshot %s", snapshot.Name)
+	}
+	if flagsData != "" {
+		if err := json.Unmarshal([]byte(flagsData), &snapshot.FlagsData); err != nil {
+			return model.Snapshot{}, errors.Wrapf(err, "unable to unmarshal flags data of snapshot %s", snapshot.Name)
+		}
+	}
+	if err := json.Unmarshal([]byte(variationsData), &snapshot.AvailableVariations); err != nil {
+		return model.Snapshot{}, errors.Wrapf(err, "unable to unmarshal available variations of snapshot %s", snapshot.Name)
+	}
+	var overrides []snapshotOverride
+	if err := json.Unmarshal([]byte(overridesData), &overrides); err != nil {
+		return model.Snapshot{}, errors.Wrapf(err, "unable to unmarshal overrides of snapshot %s", snapshot.Name)
+	}
+	snapshot.Overrides = make(model.Overrides, 0, len(overrides))
+	for _, stored := range overrides {
+		override := model.Override{
+			ProjectKey: projectKey,
+			FlagKey:    stored.FlagKey,
+			Value:      stored.Value,
+			Active:     stored.Active,
+		}
+		if stored.ExpiresAt != nil {
+			override.ExpiresAt = *stored.ExpiresAt
+		}
+		if stored.ContextMatcher != "" {
+			matcher, err := model.ParseContextMatcher(stored.ContextMatcher)
+			if err != nil {
+				return model.Snapshot{}, errors.Wrapf(err, "unable to parse context matcher of override for flag %s", stored.FlagKey)
+			}
+			override.ContextMatcher = &matcher
+		}
+		snapshot.Overrides = append(snapshot.Overrides, override)
+	}
+	return snapshot, nil
+}
+
+func (s *Sqlite) DeleteSnapshot(ctx context.Context, projectKey, name string) (bool, error) {
+	result, err := s.database.ExecContext(ctx, "DELETE FROM snapshots WHERE project_key = ? AND name = ?", projectKey, name)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (s *Sqlite) InsertWebhook(ctx context.Context, webhook model.Webhook) error {
+	_, err := s.database.ExecContext(ctx, `
+		INSERT INTO webhooks (id, project_key, url, secret, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, webhook.ID, webhook.ProjectKey, webhook.URL, webhook.Secret, webhook.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "unable to insert webhook")
+	}
+	return nil
+}
+
+func (s *Sqlite) GetWebhooks(ctx context.Context, projectKey string) ([]model.Webhook, error) {
+	rows, err := s.database.QueryContext(ctx, `
+		SELECT id, project_key, url, secret, created_at
+		FROM webhooks
+		WHERE ? = '' OR project_key = ?
+		ORDER BY created_at, id
+	`, projectKey, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]model.Webhook, 0)
+	for rows.Next() {
+		var webhook model.Webhook
+		err = rows.Scan(&webhook.ID, &webhook.ProjectKey, &webhook.URL, &webhook.Secret, &webhook.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (s *Sqlite) DeleteWebhook(ctx context.Context, id string) (bool, error) {
+	result, err := s.database.ExecContext(ctx, "DELETE FROM webhooks WHERE id = ?", id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// RestoreSnapshot writes the snapshot's state over the project's. Overrides are upserted and deactivated rather than
+// deleted so that their versions keep increasing.
+func (s *Sqlite) RestoreSnapshot(ctx context.Context, snapshot model.Snapshot) (payloadVersion int, err error) {
+	flagsStateJson, err := json.Marshal(snapshot.AllFlagsState)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to marshal flags state when restoring snapshot")
+	}
+	flagsDataJson, err := marshalFlagsData(model.Project{FlagsData: snapshot.FlagsData})
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to marshal flags data when restoring snapshot")
+	}
+	active := make([]string, 0, len(snapshot.Overrides))
+	for _, override := range snapshot.Overrides {
+		if override.Active {
+			active = append(active, override.FlagKey)
+		}
+	}
+	activeJson, err := json.Marshal(active)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.database.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	row := tx.QueryRowContext(ctx, `
+		UPDATE projects
+		SET context = ?, flag_state = ?, flags_data = ?, payload_version = payload_version + 1
+		WHERE key = ?
+		RETURNING payload_version
+	`, snapshot.Context.JSONString(), string(flagsStateJson), flagsDataJson, snapshot.ProjectKey)
+	if err = row.Scan(&payloadVersion); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, model.NewErrNotFound("project", snapshot.ProjectKey)
+		}
+		return 0, errors.Wrap(err, "unable to restore project")
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM available_variations WHERE project_key = ?`, snapshot.ProjectKey)
+	if err != nil {
+		return 0, err
+	}
+	err = InsertAvailableVariations(ctx, tx, model.Project{
+		Key:                 snapshot.ProjectKey,
+		AvailableVariations: snapshot.AvailableVariations,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE overrides
+		SET active = false, version = version+1
+		WHERE project_key = ? AND active = true AND flag_key NOT IN (SELECT value FROM json_each(?))
+	`, snapshot.ProjectKey, string(activeJson))
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to deactivate overrides")
+	}
+	for _, override := range snapshot.Overrides {
+		if !override.Active {
+			continue
+		}
+		var valueJson []byte
+		valueJson, err = override.Value.MarshalJSON()
+		if err != nil {
+			return 0, errors.Wrap(err, "unable to marshal override value when restoring snapshot")
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO overrides (project_key, flag_key, value, active, expires_at, context_matcher)
+			VALUES (?, ?, ?, true, ?, ?)
+				ON CONFLICT(flag_key, project_key) DO UPDATE SET
+				    value=excluded.value,
+				    active=excluded.active,
+				    expires_at=excluded.expires_at,
+				    context_matcher=excluded.context_matcher,
+				    version=version+1
+		`,
+			snapshot.ProjectKey,
+			override.FlagKey,
+			valueJson,
+			nullTime(override.ExpiresAt),
+			nullContextMatcher(override.ContextMatcher),
+		)
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable to restore override for flag %s", override.FlagKey)
+		}
+	}
+	return payloadVersion, tx.Commit()
+}
+
+func (s *Sqlite) RestoreBackup(ctx context.Context, stream io.Reader) (string, error) {
+	if s.inMemory() {
+		return "", errors.New("backups can't be restored into an ephemeral dev server")
+	}
+	filepath, err := s.backupManager.RestoreToFile(ctx, stream)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to restore backup db")
+	}
+	err = s.database.Close()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to close database before restoring backup")
+	}
+	err = os.Rename(filepath, s.dbPath)
+	if err != nil {
+		// panic because this would really leave the app in an invalid state
+		panic(err)
+	}
+	s.database, err = sql.Open("sqlite3", s.dbPath)
+	if err != nil {
+		// panic because this would really leave the app in an invalid state
+		panic(err)
+	}
+
+	err = s.runMigrations(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to run migrations after restoring backup")
+	}
+
+	return filepath, err
+}
+
+func (s *Sqlite) CreateBackup(ctx context.Context) (io.ReadCloser, int64, error) {
+	backupPath, err := s.backupManager.MakeBackupFile(ctx)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "unable to make backup file, %s", backupPath)
+	}
+	fi, err := os.Open(backupPath)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "unable to open backup db at %s", backupPath)
+	}
+	stat, err := fi.Stat()
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "unable to stat backup db at %s", backupPath)
+	}
+	return fi, stat.Size(), nil
+}
+
+// Size returns the size of the database in bytes.
+func (s *Sqlite) Size(ctx context.Context) (int64, error) {
+	var size int64
+	err := s.database.QueryRowContext(ctx, "SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()").Scan(&size)
+	return size, err
+}
+
+func NewSqlite(ctx context.Context, dbPath string) (*Sqlite, error) {
+	store := new(Sqlite)
+	store.dbPath = dbPath
+	store.backupManager = backup.NewManager(dbPath, "main", "ld_cli_*.bak", "ld_cli_restore_*.db")
+	store.backupManager.AddValidationQueries(validationQueries...)
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return &Sqlite{}, err
+	}
+	store.database = db
+	err = store.runMigrations(ctx)
+	if err != nil {
+		return &Sqlite{}, err
+	}
+	return store, nil
+}
+
+// NewInMemorySqlite opens a store that keeps everything in memory, for dev servers whose state is thrown away when
+// they stop. The database is a named one in SQLite's memdb VFS, which every connection of the pool opens, so queries
+// run concurrently like they do on a file. A shared-cache :memory: database would fail concurrent writers with
+// "database table is locked" instead of waiting for each other.
+func NewInMemorySqlite(ctx context.Context) (*Sqlite, error) {
+	dsn := inMemoryDSN()
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return &Sqlite{}, err
+	}
+	keepAlive, err := db.Conn(ctx)
+	if err != nil {
+		_ = db.Close()
+		return &Sqlite{}, err
+	}
+	store := &Sqlite{database: db, keepAlive: keepAlive}
+	// The backup manager can open the database by its name while the store holds it open.
+	store.backupManager = backup.NewManager(dsn, "main", "ld_cli_*.bak", "ld_cli_restore_*.db")
+	err = store.runMigrations(ctx)
+	if err != nil {
+		_ = store.Close()
+		return &Sqlite{}, err
+	}
+	return store, nil
+}
+
+// inMemoryDSN names a new in-memory database, so that stores don't share one.
+func inMemoryDSN() string {
+	return fmt.Sprintf("file:/ldcli-%s?vfs=memdb", uuid.NewString())
+}
+
+func (s *Sqlite) inMemory() bool {
+	return s.keepAlive != nil
+}
+
+// Close closes the database, waiting for queries that are running to finish.
+func (s *Sqlite) Close() error {
+	if s.keepAlive != nil {
+		_ = s.keepAlive.Close()
+	}
+	return s.database.Close()
+}
+
+var validationQueries = []string{
+	"SELECT COUNT(1) from projects",
+	"SELECT COUNT(1) from overrides",
+	"SELECT COUNT(1) from available_variations",
+}
+
+func (s *Sqlite) runMigrations(ctx context.Context) error {
+	tx, err := s.database.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	_, err = tx.Exec(`
+	CREATE TABLE IF NOT EXISTS projects (
+		key text PRIMARY KEY,
+		source_environment_key text NOT NULL,
+		context text NOT NULL,
+		last_sync_time timestamp NOT NULL,
+		flag_state TEXT NOT NULL,
+		flags_data TEXT NOT NULL DEFAULT '',
+		payload_version INTEGER NOT NULL DEFAULT 1
+	)`)
+	if err != nil {
+		return err
+	}
+
+	// Migration: add flags_data to existing databases that predate this column.
+	_, err = tx.Exec(`ALTER TABLE projects ADD COLUMN flags_data TEXT NOT NULL DEFAULT ''`)
+	if err != nil && !strings.Contains(err.Error(), "duplicate column name") {
+		return err
+	}
+	err = nil
+
+	// Migration: add payload_version to existing databases that predate this column.
+	_, err = tx.Exec(`ALTER TABLE projects ADD COLUMN payload_version INTEGER NOT NULL DEFAULT 1`)
+	if err != nil && !strings.Contains(err.Error(), "duplicate column name") {
+		return err
+	}
+	err = nil
+
+	// Migration: add the source environment's credentials, which projects record from their next sync.
+	for _, column := range []string{"sdk_key", "mobile_key", "client_side_id"} {
+		_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE projects ADD COLUMN %s text NOT NULL DEFAULT ''`, column))
+		if err != nil && !strings.Contains(err.Error(), "duplicate column name") {
+			return err
+		}
+		err = nil
+	}
+
+	_, err = tx.Exec(`
+	CREATE TABLE IF NOT EXISTS overrides (
+		project_key text NOT NULL,
+		flag_key text NOT NULL,
+		value text NOT NULL,
+		active boolean NOT NULL default TRUE,
+		version integer NOT NULL default 1,
+		expires_at timestamp,
+		context_matcher text,
+		UNIQUE (project_key, flag_key) ON CONFLICT REPLACE
+	)`)
+	if err != nil {
+		return err
+	}
+
+	// Migration: add expires_at to existing databases that predate expiring overrides.
+	_, err = tx.Exec(`ALTER TABLE overrides ADD COLUMN expires_at timestamp`)
+	if err != nil && !strings.Contains(err.Error(), "duplicate column name") {
+		return err
+	}
+	err = nil
+
+	// Migration: add context_matcher to existing databases that predate context-scoped overrides.
+	_, err = tx.Exec(`ALTER TABLE overrides ADD COLUMN context_matcher text`)
+	if err != nil && !strings.Contains(err.Error(), "duplicate column name") {
+		return err
+	}
+	err = nil
+
+	_, err = tx.Exec(`
+	CREATE TABLE IF NOT EXISTS available_variations (
+		project_key text NOT NULL,
+		flag_key text NOT NULL,
+		id text NOT NULL,
+		value text NOT NULL, 
+		description text,
+		name text,
 		FOREIGN KEY (project_key) REFERENCES projects (key) ON DELETE CASCADE,
 		UNIQUE (project_key, flag_key, id) ON CONFLICT REPLACE
 	)`)
@@ -539,5 +1528,82 @@ func (s *Sqlite) runMigrations(ctx context.Context) error {
 		return err
 	}
 
+	_, err = tx.Exec(`
+	CREATE TABLE IF NOT EXISTS scenarios (
+		project_key text NOT NULL,
+		name text NOT NULL,
+		overrides text NOT NULL,
+		FOREIGN KEY (project_key) REFERENCES projects (key) ON DELETE CASCADE,
+		UNIQUE (project_key, name)
+	)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	CREATE TABLE IF NOT EXISTS override_history (
+		id integer PRIMARY KEY AUTOINCREMENT,
+		change_id integer NOT NULL,
+		project_key text NOT NULL,
+		flag_key text NOT NULL,
+		action text NOT NULL,
+		old_value text,
+		new_value text,
+		old_expires_at timestamp,
+		old_context_matcher text,
+		new_expires_at timestamp,
+		new_context_matcher text,
+		source text NOT NULL,
+		reverts integer,
+		created_at timestamp NOT NULL
+	)`)
+	if err != nil {
+		return err
+	}
+
+	// Migration: add the overrides' options to history recorded before undo and redo restored them.
+	for _, column := range []string{
+		"old_expires_at timestamp", "old_context_matcher text", "new_expires_at timestamp", "new_context_matcher text",
+	} {
+		_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE override_history ADD COLUMN %s`, column))
+		if err != nil && !strings.Contains(err.Error(), "duplicate column name") {
+			return err
+		}
+		err = nil
+	}
+
+	_, err = tx.Exec(`CREATE INDEX IF NOT EXISTS override_history_project_key ON override_history (project_key)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	CREATE TABLE IF NOT EXISTS snapshots (
+		project_key text NOT NULL,
+		name text NOT NULL,
+		created_at timestamp NOT NULL,
+		context text NOT NULL,
+		flag_state text NOT NULL,
+		flags_data text NOT NULL,
+		available_variations text NOT NULL,
+		overrides text NOT NULL,
+		UNIQUE (project_key, name)
+	)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	CREATE TABLE IF NOT EXISTS webhooks (
+		id text PRIMARY KEY,
+		project_key text NOT NULL,
+		url text NOT NULL,
+		secret text NOT NULL,
+		created_at timestamp NOT NULL
+	)`)
+	if err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
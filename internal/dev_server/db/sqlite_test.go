@@ -3,6 +3,8 @@ package db_test
 import (
 	"context"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,21 +14,39 @@ import (
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldbuilders"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	"github.com/launchdarkly/ldcli/internal/dev_server/db"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 )
 
 func TestDBFunctions(t *testing.T) {
 	ctx := context.Background()
-	dbName := "test.db"
 
-	store, err := db.NewSqlite(ctx, dbName)
-	require.NoError(t, err)
+	t.Run("on a file", func(t *testing.T) {
+		dbName := "test.db"
+		store, err := db.NewSqlite(ctx, dbName)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, store.Close())
+			require.NoError(t, os.Remove(dbName))
+		}()
+		testDBFunctions(t, store)
+	})
 
-	defer func() {
-		require.NoError(t, os.Remove(dbName))
-	}()
+	t.Run("in memory", func(t *testing.T) {
+		store, err := db.NewInMemorySqlite(ctx)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, store.Close())
+		}()
+		testDBFunctions(t, store)
+	})
+}
 
+func testDBFunctions(t *testing.T, store *db.Sqlite) {
+	ctx := context.Background()
 	ldContext := ldcontext.New(t.Name())
 	now := time.Now()
 
@@ -263,6 +283,59 @@ func TestDBFunctions(t *testing.T) {
 		assert.Equal(t, ldvalue.StringType, flag2Variations[0].Value.Type())
 	})
 
+	t.Run("UpdateProject stores flags data", func(t *testing.T) {
+		project := projects[0]
+		project.FlagsData = adapters.FlagsData{
+			Flags: map[string]ldmodel.FeatureFlag{
+				"flag-1": ldbuilders.NewFlagBuilder("flag-1").
+					Version(3).
+					On(true).
+					Variations(ldvalue.Bool(false), ldvalue.Bool(true)).
+					AddTarget(1, "targeted").
+					FallthroughVariation(0).
+					Build(),
+			},
+			Segments: map[string]ldmodel.Segment{
+				"segment-1": ldbuilders.NewSegmentBuilder("segment-1").Version(1).Build(),
+			},
+		}
+
+		updated, err := store.UpdateProject(ctx, project)
+		require.NoError(t, err)
+		require.True(t, updated)
+
+		newProj, err := store.GetDevProject(ctx, project.Key)
+		require.NoError(t, err)
+		assert.Contains(t, newProj.FlagsData.Segments, "segment-1")
+		assert.Equal(t,
+			model.EvaluateFlags(project.FlagsData, ldcontext.New("targeted")),
+			model.EvaluateFlags(newProj.FlagsData, ldcontext.New("targeted")),
+		)
+	})
+
+	t.Run("GetDevProjectKeyForCredential finds the project by any of its source environment's credentials", func(t *testing.T) {
+		_, err := store.GetDevProjectKeyForCredential(ctx, "sdk-1234")
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+
+		project := projects[0]
+		project.Credentials = adapters.EnvironmentCredentials{SdkKey: "sdk-1234", MobileKey: "mob-1234", ClientSideID: "abc123"}
+		updated, err := store.UpdateProject(ctx, project)
+		require.NoError(t, err)
+		require.True(t, updated)
+
+		for _, credential := range []string{"sdk-1234", "mob-1234", "abc123"} {
+			projectKey, err := store.GetDevProjectKeyForCredential(ctx, credential)
+			require.NoError(t, err)
+			assert.Equal(t, project.Key, projectKey)
+		}
+		_, err = store.GetDevProjectKeyForCredential(ctx, "")
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+
+		newProj, err := store.GetDevProject(ctx, project.Key)
+		require.NoError(t, err)
+		assert.Equal(t, project.Credentials, newProj.Credentials)
+	})
+
 	t.Run("UpdateProject returns false if project does not exist", func(t *testing.T) {
 		updated, err := store.UpdateProject(ctx, model.Project{Key: "nope"})
 		assert.NoError(t, err)
@@ -387,6 +460,118 @@ func TestDBFunctions(t *testing.T) {
 		assert.Equal(t, initialVersion+2, newVersion2)
 	})
 
+	t.Run("UpsertOverride stores the expiry and clears it when the override is made permanent", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+		override := model.Override{
+			ProjectKey: projects[1].Key,
+			FlagKey:    "flag-1",
+			Value:      ldvalue.Bool(false),
+			Active:     true,
+			ExpiresAt:  expiresAt,
+		}
+		_, err := store.UpsertOverride(ctx, override)
+		require.NoError(t, err)
+
+		overrides, err := store.GetOverridesForProject(ctx, projects[1].Key)
+		require.NoError(t, err)
+		stored, ok := overrides.GetFlag("flag-1")
+		require.True(t, ok)
+		assert.True(t, expiresAt.Equal(stored.ExpiresAt))
+
+		override.ExpiresAt = time.Time{}
+		_, err = store.UpsertOverride(ctx, override)
+		require.NoError(t, err)
+
+		overrides, err = store.GetOverridesForProject(ctx, projects[1].Key)
+		require.NoError(t, err)
+		stored, ok = overrides.GetFlag("flag-1")
+		require.True(t, ok)
+		assert.True(t, stored.ExpiresAt.IsZero())
+	})
+
+	t.Run("UpsertOverride stores the context matcher and ReplaceOverrides clears it", func(t *testing.T) {
+		matcher, err := model.ParseContextMatcher("org.tier in [enterprise, startup]")
+		require.NoError(t, err)
+		_, err = store.UpsertOverride(ctx, model.Override{
+			ProjectKey:     projects[0].Key,
+			FlagKey:        "flag-1",
+			Value:          ldvalue.Bool(true),
+			Active:         true,
+			ContextMatcher: &matcher,
+		})
+		require.NoError(t, err)
+
+		overrides, err := store.GetOverridesForProject(ctx, projects[0].Key)
+		require.NoError(t, err)
+		stored, ok := overrides.GetFlag("flag-1")
+		require.True(t, ok)
+		require.NotNil(t, stored.ContextMatcher)
+		assert.Equal(t, matcher, *stored.ContextMatcher)
+
+		_, err = store.ReplaceOverrides(ctx, projects[0].Key, map[string]ldvalue.Value{"flag-1": ldvalue.Bool(true)})
+		require.NoError(t, err)
+
+		overrides, err = store.GetOverridesForProject(ctx, projects[0].Key)
+		require.NoError(t, err)
+		stored, ok = overrides.GetFlag("flag-1")
+		require.True(t, ok)
+		assert.Nil(t, stored.ContextMatcher)
+	})
+
+	t.Run("scenarios can be saved, listed, fetched and deleted", func(t *testing.T) {
+		scenario := model.Scenario{
+			ProjectKey: projects[0].Key,
+			Name:       "checkout-on",
+			Overrides:  map[string]ldvalue.Value{"flag-1": ldvalue.Bool(false)},
+		}
+		require.NoError(t, store.UpsertScenario(ctx, scenario))
+		scenario.Overrides["flag-2"] = ldvalue.String("sale")
+		require.NoError(t, store.UpsertScenario(ctx, scenario))
+
+		scenarios, err := store.GetScenariosForProject(ctx, projects[0].Key)
+		require.NoError(t, err)
+		assert.Equal(t, []model.Scenario{scenario}, scenarios)
+
+		fetched, err := store.GetScenario(ctx, projects[0].Key, scenario.Name)
+		require.NoError(t, err)
+		assert.Equal(t, scenario, *fetched)
+
+		deleted, err := store.DeleteScenario(ctx, projects[0].Key, scenario.Name)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+
+		_, err = store.GetScenario(ctx, projects[0].Key, scenario.Name)
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+
+		deleted, err = store.DeleteScenario(ctx, projects[0].Key, scenario.Name)
+		require.NoError(t, err)
+		assert.False(t, deleted)
+	})
+
+	t.Run("ReplaceOverrides leaves only the given overrides active and increments the payload version once", func(t *testing.T) {
+		project := projects[0]
+		_, err := store.UpsertOverride(ctx, model.Override{ProjectKey: project.Key, FlagKey: "flag-1", Value: ldvalue.Bool(false), Active: true})
+		require.NoError(t, err)
+		before, err := store.GetDevProject(ctx, project.Key)
+		require.NoError(t, err)
+
+		payloadVersion, err := store.ReplaceOverrides(ctx, project.Key, map[string]ldvalue.Value{
+			"flag-2": ldvalue.String("sale"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, before.PayloadVersion+1, payloadVersion)
+
+		overrides, err := store.GetOverridesForProject(ctx, project.Key)
+		require.NoError(t, err)
+		active := make(map[string]ldvalue.Value)
+		for _, override := range overrides {
+			if override.Active {
+				active[override.FlagKey] = override.Value
+			}
+		}
+		assert.Equal(t, map[string]ldvalue.Value{"flag-2": ldvalue.String("sale")}, active)
+	})
+
 	t.Run("UpdateProject deletes overrides for flags that are no longer in the project", func(t *testing.T) {
 		project := projects[2]
 
@@ -452,4 +637,494 @@ func TestDBFunctions(t *testing.T) {
 		require.Len(t, overrides, 1)
 		assert.Equal(t, override, overrides[0])
 	})
+
+	t.Run("AppendHistory records each call as a change that GetHistory returns in order", func(t *testing.T) {
+		project := projects[0]
+		timestamp := time.Now().UTC().Truncate(time.Second)
+		oldValue, newValue := ldvalue.Bool(true), ldvalue.Bool(false)
+		first := []model.HistoryEntry{
+			{FlagKey: "flag-1", Action: model.HistoryActionOverride, NewValue: &oldValue, Source: model.HistorySourceCLI, Timestamp: timestamp},
+		}
+		second := []model.HistoryEntry{
+			{FlagKey: "flag-1", Action: model.HistoryActionUndo, OldValue: &oldValue, NewValue: &newValue, Source: model.HistorySourceUI, Timestamp: timestamp},
+			{FlagKey: "flag-2", Action: model.HistoryActionUndo, OldValue: &newValue, Source: model.HistorySourceUI, Timestamp: timestamp},
+		}
+
+		recordedFirst, err := store.AppendHistory(ctx, project.Key, first)
+		require.NoError(t, err)
+		recordedSecond, err := store.AppendHistory(ctx, project.Key, second)
+		require.NoError(t, err)
+		require.Len(t, recordedSecond, 2)
+		firstID, secondID := recordedFirst[0].ChangeID, recordedSecond[0].ChangeID
+		assert.Greater(t, secondID, firstID)
+		assert.Equal(t, secondID, recordedSecond[1].ChangeID)
+		assert.Greater(t, recordedSecond[1].ID, recordedSecond[0].ID)
+
+		history, err := store.GetHistory(ctx, project.Key)
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.Equal(t, firstID, history[0].ChangeID)
+		assert.Equal(t, secondID, history[1].ChangeID)
+		assert.Equal(t, secondID, history[2].ChangeID)
+		assert.Nil(t, history[0].OldValue)
+		assert.Equal(t, &oldValue, history[0].NewValue)
+		assert.Equal(t, model.HistorySourceCLI, history[0].Source)
+		assert.True(t, timestamp.Equal(history[0].Timestamp))
+		assert.Equal(t, "flag-2", history[2].FlagKey)
+		assert.Equal(t, &newValue, history[2].OldValue)
+		assert.Nil(t, history[2].NewValue)
+
+		other, err := store.GetHistory(ctx, projects[1].Key)
+		require.NoError(t, err)
+		assert.Empty(t, other)
+	})
+
+	t.Run("AppendHistory keeps the options of the overrides before and after the change", func(t *testing.T) {
+		project := projects[0]
+		matcher, err := model.ParseContextMatcher(`user.key == "alice"`)
+		require.NoError(t, err)
+		expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+		value := ldvalue.Bool(true)
+		_, err = store.AppendHistory(ctx, project.Key, []model.HistoryEntry{{
+			FlagKey:    "flag-1",
+			Action:     model.HistoryActionOverride,
+			NewValue:   &value,
+			OldOptions: model.OverrideOptions{ContextMatcher: &matcher},
+			NewOptions: model.OverrideOptions{ExpiresAt: expiresAt},
+			Source:     model.HistorySourceCLI,
+			Timestamp:  time.Now(),
+		}})
+		require.NoError(t, err)
+
+		history, err := store.GetHistory(ctx, project.Key)
+		require.NoError(t, err)
+		require.NotEmpty(t, history)
+		entry := history[len(history)-1]
+		require.NotNil(t, entry.OldOptions.ContextMatcher)
+		assert.Equal(t, matcher, *entry.OldOptions.ContextMatcher)
+		assert.True(t, entry.OldOptions.ExpiresAt.IsZero())
+		assert.Nil(t, entry.NewOptions.ContextMatcher)
+		assert.True(t, expiresAt.Equal(entry.NewOptions.ExpiresAt))
+	})
+
+	t.Run("SetOverrides writes the overrides with their options and increments the payload version", func(t *testing.T) {
+		project := projects[0]
+		before, err := store.GetDevProject(ctx, project.Key)
+		require.NoError(t, err)
+		matcher, err := model.ParseContextMatcher(`user.key == "alice"`)
+		require.NoError(t, err)
+		expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+		_, err = store.UpsertOverride(ctx, model.Override{ProjectKey: project.Key, FlagKey: "flag-2", Value: ldvalue.Bool(true), Active: true})
+		require.NoError(t, err)
+
+		payloadVersion, err := store.SetOverrides(ctx, project.Key, model.Overrides{
+			{ProjectKey: project.Key, FlagKey: "flag-1", Value: ldvalue.Bool(false), Active: true, ContextMatcher: &matcher, ExpiresAt: expiresAt},
+			{ProjectKey: project.Key, FlagKey: "flag-2", Value: ldvalue.Null(), Active: false},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, before.PayloadVersion+1, payloadVersion)
+
+		overrides, err := store.GetOverridesForProject(ctx, project.Key)
+		require.NoError(t, err)
+		scoped, ok := overrides.GetFlag("flag-1")
+		require.True(t, ok)
+		assert.True(t, scoped.Active)
+		assert.Equal(t, ldvalue.Bool(false), scoped.Value)
+		require.NotNil(t, scoped.ContextMatcher)
+		assert.Equal(t, matcher, *scoped.ContextMatcher)
+		assert.True(t, expiresAt.Equal(scoped.ExpiresAt))
+		deactivated, ok := overrides.GetFlag("flag-2")
+		require.True(t, ok)
+		assert.False(t, deactivated.Active)
+
+		_, err = store.SetOverrides(ctx, "missing", nil)
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+	})
+}
+
+func TestInMemorySqlite(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.NewInMemorySqlite(ctx)
+	require.NoError(t, err)
+	other, err := db.NewInMemorySqlite(ctx)
+	require.NoError(t, err)
+
+	project := model.Project{
+		Key:                  "proj",
+		SourceEnvironmentKey: "env",
+		Context:              ldcontext.New(t.Name()),
+		LastSyncTime:         time.Now(),
+		AllFlagsState:        model.FlagsState{"flag": model.FlagState{Value: ldvalue.Bool(true), Version: 1}},
+		PayloadVersion:       1,
+	}
+	require.NoError(t, store.InsertProject(ctx, project))
+
+	t.Run("keeps state apart from other stores", func(t *testing.T) {
+		keys, err := store.GetDevProjectKeys(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"proj"}, keys)
+
+		keys, err = other.GetDevProjectKeys(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("CreateBackup copies the database to a file", func(t *testing.T) {
+		backup, size, err := store.CreateBackup(ctx)
+		require.NoError(t, err)
+		require.NoError(t, backup.Close())
+		assert.Positive(t, size)
+
+		restored, err := db.NewSqlite(ctx, backup.(*os.File).Name())
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, restored.Close())
+			require.NoError(t, os.Remove(backup.(*os.File).Name()))
+		}()
+		got, err := restored.GetDevProject(ctx, "proj")
+		require.NoError(t, err)
+		assert.Equal(t, project.SourceEnvironmentKey, got.SourceEnvironmentKey)
+	})
+
+	t.Run("RestoreBackup is refused", func(t *testing.T) {
+		_, err := store.RestoreBackup(ctx, strings.NewReader(""))
+		assert.Error(t, err)
+	})
+
+	t.Run("serves concurrent writes and reads from its pool", func(t *testing.T) {
+		const writers = 20
+		errs := make(chan error, 2*writers)
+		var wg sync.WaitGroup
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, err := store.SetOverrides(ctx, "proj", model.Overrides{
+					{ProjectKey: "proj", FlagKey: "flag", Value: ldvalue.Int(i), Active: true},
+				})
+				errs <- err
+				_, err = store.GetOverridesForProject(ctx, "proj")
+				errs <- err
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		got, err := store.GetDevProject(ctx, "proj")
+		require.NoError(t, err)
+		assert.Equal(t, project.PayloadVersion+writers, got.PayloadVersion)
+	})
+}
+
+func TestSnapshots(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.NewInMemorySqlite(ctx)
+	require.NoError(t, err)
+
+	project := model.Project{
+		Key:                  "proj",
+		SourceEnvironmentKey: "env",
+		Context:              ldcontext.New("before"),
+		LastSyncTime:         time.Now(),
+		AllFlagsState: model.FlagsState{
+			"flag-1": model.FlagState{Value: ldvalue.Bool(true), Version: 1},
+		},
+		AvailableVariations: []model.FlagVariation{
+			{FlagKey: "flag-1", Variation: model.Variation{Id: "1", Value: ldvalue.Bool(true)}},
+			{FlagKey: "flag-1", Variation: model.Variation{Id: "2", Value: ldvalue.Bool(false)}},
+		},
+		PayloadVersion: 1,
+	}
+	require.NoError(t, store.InsertProject(ctx, project))
+	other := project
+	other.Key = "other"
+	require.NoError(t, store.InsertProject(ctx, other))
+
+	matcher, err := model.ParseContextMatcher(`user.key == "alice"`)
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	snapshot := model.Snapshot{
+		ProjectKey: "proj",
+		Name:       "base",
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		Context:    ldcontext.New("snapshot"),
+		AllFlagsState: model.FlagsState{
+			"flag-1": model.FlagState{Value: ldvalue.Bool(false), Version: 1},
+			"flag-2": model.FlagState{Value: ldvalue.String("cool"), Version: 1},
+		},
+		AvailableVariations: []model.FlagVariation{
+			{FlagKey: "flag-2", Variation: model.Variation{Id: "3", Name: lo.ToPtr("cool"), Value: ldvalue.String("cool")}},
+		},
+		Overrides: model.Overrides{
+			{ProjectKey: "proj", FlagKey: "flag-2", Value: ldvalue.String("hot"), Active: true, ExpiresAt: expiresAt, ContextMatcher: &matcher},
+			{ProjectKey: "proj", FlagKey: "flag-1", Value: ldvalue.Bool(true), Active: false},
+		},
+	}
+
+	t.Run("snapshots can be inserted, listed, fetched and deleted", func(t *testing.T) {
+		require.NoError(t, store.InsertSnapshot(ctx, snapshot))
+		later := snapshot
+		later.Name = "later"
+		later.CreatedAt = snapshot.CreatedAt.Add(time.Minute)
+		require.NoError(t, store.InsertSnapshot(ctx, later))
+
+		err := store.InsertSnapshot(ctx, snapshot)
+		assert.ErrorAs(t, err, &model.ErrAlreadyExists{})
+
+		got, err := store.GetSnapshot(ctx, "proj", "base")
+		require.NoError(t, err)
+		assert.True(t, snapshot.CreatedAt.Equal(got.CreatedAt))
+		got.CreatedAt = snapshot.CreatedAt
+		assert.True(t, expiresAt.Equal(got.Overrides[0].ExpiresAt))
+		got.Overrides[0].ExpiresAt = expiresAt
+		assert.Equal(t, snapshot, *got)
+
+		snapshots, err := store.GetSnapshotsForProject(ctx, "proj")
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		assert.Equal(t, "base", snapshots[0].Name)
+		assert.Equal(t, "later", snapshots[1].Name)
+
+		snapshots, err = store.GetSnapshotsForProject(ctx, "other")
+		require.NoError(t, err)
+		assert.Empty(t, snapshots)
+
+		deleted, err := store.DeleteSnapshot(ctx, "proj", "later")
+		require.NoError(t, err)
+		assert.True(t, deleted)
+		_, err = store.GetSnapshot(ctx, "proj", "later")
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+	})
+
+	t.Run("RestoreSnapshot replaces the project's state and leaves other projects alone", func(t *testing.T) {
+		_, err := store.UpsertOverride(ctx, model.Override{ProjectKey: "proj", FlagKey: "flag-1", Value: ldvalue.Bool(false), Active: true})
+		require.NoError(t, err)
+		_, err = store.UpsertOverride(ctx, model.Override{ProjectKey: "other", FlagKey: "flag-1", Value: ldvalue.Bool(false), Active: true})
+		require.NoError(t, err)
+
+		payloadVersion, err := store.RestoreSnapshot(ctx, snapshot)
+		require.NoError(t, err)
+		assert.Equal(t, 2, payloadVersion)
+
+		restored, err := store.GetDevProject(ctx, "proj")
+		require.NoError(t, err)
+		assert.Equal(t, snapshot.Context, restored.Context)
+		assert.Equal(t, snapshot.AllFlagsState, restored.AllFlagsState)
+		assert.Equal(t, project.SourceEnvironmentKey, restored.SourceEnvironmentKey)
+
+		variations, err := store.GetAvailableVariationsForProject(ctx, "proj")
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]model.Variation{"flag-2": {snapshot.AvailableVariations[0].Variation}}, variations)
+
+		overrides, err := store.GetOverridesForProject(ctx, "proj")
+		require.NoError(t, err)
+		flag1, ok := overrides.GetFlag("flag-1")
+		require.True(t, ok)
+		assert.False(t, flag1.Active)
+		assert.Equal(t, 2, flag1.Version)
+		flag2, ok := overrides.GetFlag("flag-2")
+		require.True(t, ok)
+		assert.True(t, flag2.Active)
+		assert.Equal(t, ldvalue.String("hot"), flag2.Value)
+		assert.True(t, expiresAt.Equal(flag2.ExpiresAt))
+		assert.Equal(t, matcher.String(), flag2.ContextMatcher.String())
+
+		untouched, err := store.GetOverridesForProject(ctx, "other")
+		require.NoError(t, err)
+		require.Len(t, untouched, 1)
+		assert.True(t, untouched[0].Active)
+		otherProject, err := store.GetDevProject(ctx, "other")
+		require.NoError(t, err)
+		assert.Equal(t, project.Context, otherProject.Context)
+	})
+
+	t.Run("DeleteDevProject deletes the project's snapshots", func(t *testing.T) {
+		_, err := store.DeleteDevProject(ctx, "proj")
+		require.NoError(t, err)
+		snapshots, err := store.GetSnapshotsForProject(ctx, "proj")
+		require.NoError(t, err)
+		assert.Empty(t, snapshots)
+	})
+}
+
+func TestDeleteDevProjectThenRecreate(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.NewInMemorySqlite(ctx)
+	require.NoError(t, err)
+
+	project := model.Project{
+		Key:                  "proj",
+		SourceEnvironmentKey: "env",
+		Context:              ldcontext.New("user"),
+		LastSyncTime:         time.Now(),
+		AllFlagsState:        model.FlagsState{"flag-1": model.FlagState{Value: ldvalue.Bool(true), Version: 1}},
+		PayloadVersion:       1,
+	}
+	require.NoError(t, store.InsertProject(ctx, project))
+	require.NoError(t, store.UpsertScenario(ctx, model.Scenario{
+		ProjectKey: "proj",
+		Name:       "checkout-on",
+		Overrides:  map[string]ldvalue.Value{"flag-1": ldvalue.Bool(false)},
+	}))
+
+	deleted, err := store.DeleteDevProject(ctx, "proj")
+	require.NoError(t, err)
+	require.True(t, deleted)
+	require.NoError(t, store.InsertProject(ctx, project))
+
+	scenarios, err := store.GetScenariosForProject(ctx, "proj")
+	require.NoError(t, err)
+	assert.Empty(t, scenarios)
+	_, err = store.GetScenario(ctx, "proj", "checkout-on")
+	assert.ErrorAs(t, err, &model.ErrNotFound{})
+}
+
+func TestProjectEnvironments(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.NewInMemorySqlite(ctx)
+	require.NoError(t, err)
+
+	for _, key := range []string{"proj", "proj:staging", "proj:test", "project", "proj2:staging"} {
+		require.NoError(t, store.InsertProject(ctx, model.Project{
+			Key:                  key,
+			SourceEnvironmentKey: "env",
+			Context:              ldcontext.New("user"),
+			LastSyncTime:         time.Now(),
+			AllFlagsState:        model.FlagsState{"flag-1": model.FlagState{Value: ldvalue.Bool(true), Version: 1}},
+			PayloadVersion:       1,
+		}))
+	}
+
+	t.Run("lists the keys with a prefix", func(t *testing.T) {
+		keys, err := store.GetDevProjectKeysWithPrefix(ctx, "proj:")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"proj:staging", "proj:test"}, keys)
+	})
+
+	t.Run("updates projects together", func(t *testing.T) {
+		newContext := ldcontext.New("new-user")
+		project, err := store.GetDevProject(ctx, "proj")
+		require.NoError(t, err)
+		environment, err := store.GetDevProject(ctx, "proj:staging")
+		require.NoError(t, err)
+		project.Context = newContext
+		environment.Context = newContext
+
+		payloadVersions, err := store.UpdateProjects(ctx, []model.Project{*project, *environment})
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 2}, payloadVersions)
+		environment, err = store.GetDevProject(ctx, "proj:staging")
+		require.NoError(t, err)
+		assert.Equal(t, newContext, environment.Context)
+	})
+
+	t.Run("updates none of the projects if one of them doesn't exist", func(t *testing.T) {
+		project, err := store.GetDevProject(ctx, "proj")
+		require.NoError(t, err)
+		project.Context = ldcontext.New("another-user")
+
+		_, err = store.UpdateProjects(ctx, []model.Project{*project, {Key: "proj:missing"}})
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+		project, err = store.GetDevProject(ctx, "proj")
+		require.NoError(t, err)
+		assert.Equal(t, ldcontext.New("new-user"), project.Context)
+		assert.Equal(t, 2, project.PayloadVersion)
+	})
+
+	t.Run("deletes projects together", func(t *testing.T) {
+		deleted, err := store.DeleteDevProjects(ctx, []string{"proj", "proj:staging", "proj:test", "proj:missing"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"proj", "proj:staging", "proj:test"}, deleted)
+		keys, err := store.GetDevProjectKeys(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"project", "proj2:staging"}, keys)
+	})
+}
+
+func TestWebhooks(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.NewInMemorySqlite(ctx)
+	require.NoError(t, err)
+
+	first := model.Webhook{
+		ID:         "1",
+		ProjectKey: "proj",
+		URL:        "http://localhost:9000/hook",
+		Secret:     "secret",
+		CreatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	second := model.Webhook{
+		ID:         "2",
+		ProjectKey: "other",
+		URL:        "http://localhost:9001/hook",
+		Secret:     "other-secret",
+		CreatedAt:  time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, store.InsertWebhook(ctx, second))
+	require.NoError(t, store.InsertWebhook(ctx, first))
+
+	t.Run("GetWebhooks lists every webhook oldest first without a project key", func(t *testing.T) {
+		webhooks, err := store.GetWebhooks(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, []model.Webhook{first, second}, webhooks)
+	})
+
+	t.Run("GetWebhooks lists the project's webhooks", func(t *testing.T) {
+		webhooks, err := store.GetWebhooks(ctx, "proj")
+		require.NoError(t, err)
+		assert.Equal(t, []model.Webhook{first}, webhooks)
+	})
+
+	t.Run("DeleteWebhook deletes the webhook", func(t *testing.T) {
+		deleted, err := store.DeleteWebhook(ctx, first.ID)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+
+		webhooks, err := store.GetWebhooks(ctx, "proj")
+		require.NoError(t, err)
+		assert.Empty(t, webhooks)
+
+		deleted, err = store.DeleteWebhook(ctx, first.ID)
+		require.NoError(t, err)
+		assert.False(t, deleted)
+	})
+}
+
+func TestGetExpiredOverrides(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.NewInMemorySqlite(ctx)
+	require.NoError(t, err)
+	require.NoError(t, store.InsertProject(ctx, model.Project{
+		Key:                  "proj",
+		SourceEnvironmentKey: "env",
+		Context:              ldcontext.New("user"),
+		LastSyncTime:         time.Now(),
+		PayloadVersion:       1,
+	}))
+
+	now := time.Now()
+	// Expiries written in another time zone still compare by instant.
+	ahead := time.FixedZone("ahead", 5*60*60)
+	for _, override := range []model.Override{
+		{FlagKey: "expired", ExpiresAt: now.Add(-time.Minute).In(ahead), Active: true},
+		{FlagKey: "expired-behind", ExpiresAt: now.Add(-time.Millisecond).UTC(), Active: true},
+		{FlagKey: "expiring", ExpiresAt: now.Add(time.Minute).UTC(), Active: true},
+		{FlagKey: "expiring-ahead", ExpiresAt: now.Add(time.Minute).In(ahead), Active: true},
+		{FlagKey: "permanent", Active: true},
+	} {
+		override.ProjectKey = "proj"
+		override.Value = ldvalue.Bool(true)
+		_, err := store.UpsertOverride(ctx, override)
+		require.NoError(t, err)
+	}
+	_, err = store.DeactivateOverride(ctx, "proj", "expired-behind")
+	require.NoError(t, err)
+
+	expired, err := store.GetExpiredOverrides(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, "proj", expired[0].ProjectKey)
+	assert.Equal(t, "expired", expired[0].FlagKey)
 }
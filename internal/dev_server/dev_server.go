@@ -2,19 +2,27 @@ package dev_server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
-	"github.com/adrg/xdg"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
 
 	"github.com/launchdarkly/ldcli/internal/client"
 	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	"github.com/launchdarkly/ldcli/internal/dev_server/api"
 	"github.com/launchdarkly/ldcli/internal/dev_server/api/events"
+	"github.com/launchdarkly/ldcli/internal/dev_server/certs"
+	"github.com/launchdarkly/ldcli/internal/dev_server/daemon"
 	"github.com/launchdarkly/ldcli/internal/dev_server/db"
 	"github.com/launchdarkly/ldcli/internal/dev_server/events_db"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
@@ -27,13 +35,27 @@ type Client interface {
 }
 
 type ServerParams struct {
-	AccessToken            string
-	BaseURI                string
-	DevStreamURI           string
-	Port                   string
-	CorsEnabled            bool
-	CorsOrigin             string
-	StreamFlagStartup      bool
+	AccessToken       string
+	BaseURI           string
+	DevStreamURI      string
+	Port              string
+	CorsEnabled       bool
+	CorsOrigin        string
+	StreamFlagStartup bool
+	LiveSync          bool
+	ForwardEvents     bool
+	EventsURI         string
+	TLS               bool
+	TLSCertFile       string
+	TLSKeyFile        string
+	TLSAllowPlain     bool
+	Ephemeral         bool
+	DataDir           string
+	// StateDir is where the server records its state for other commands: DataDir when it has one, a directory of the
+	// instance's own when it is ephemeral, and otherwise empty for the ldcli state directory. The local CA is kept in
+	// DataDir, or the ldcli state directory, even when the server is ephemeral, so that devices only trust it once.
+	StateDir               string
+	Metrics                bool
 	InitialProjectSettings model.InitialProjectSettings
 }
 
@@ -47,21 +69,44 @@ func NewClient(cliVersion string) LDClient {
 	return LDClient{cliVersion: cliVersion}
 }
 
+// shutdownTimeout is how long the dev server waits for requests to finish when it is asked to shut down.
+const shutdownTimeout = 10 * time.Second
+
 func (c LDClient) RunServer(ctx context.Context, serverParams ServerParams) {
+	// Cancelling the context on a shutdown signal also ends SSE streams and the background work that runs on it.
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
 	ldClient := client.New(serverParams.AccessToken, serverParams.BaseURI, c.cliVersion)
-	dbPath := getDBPath()
-	log.Printf("Using database at %s", dbPath)
-	sqlStore, err := db.NewSqlite(ctx, getDBPath())
-	if err != nil {
-		log.Fatal(err)
+	offline := serverParams.InitialProjectSettings.File != ""
+	if offline {
+		log.Printf("Running in offline mode, LaunchDarkly will not be called")
 	}
-
-	sqlEventStore, err := events_db.NewSqlite(ctx, getEventsDBPath())
+	sqlStore, sqlEventStore, err := openStores(ctx, serverParams)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	observers := model.NewObservers()
+	changeLog := model.NewChangeLog(model.DefaultChangeLogCapacity)
+	observers.RegisterObserver(changeLog)
+	webhooks := model.NewWebhookDispatcher()
+	observers.RegisterObserver(webhooks)
+	evaluations := model.NewEvaluations()
+	observers.RegisterObserver(evaluations)
+	faults := model.NewFaults()
+	observers.RegisterObserver(faults)
+	connections := model.NewConnections()
+	var metrics *model.Metrics
+	if serverParams.Metrics {
+		metrics = model.NewMetrics()
+		metrics.AddDatabase("store", sqlStore)
+		metrics.AddDatabase("events", sqlEventStore)
+		observers.RegisterObserver(metrics)
+	}
+	var eventForwarder *model.EventForwarder
+	if serverParams.ForwardEvents && !offline {
+		eventForwarder = model.NewEventForwarder(serverParams.EventsURI)
+	}
 	ss := api.NewStrictServer()
 	apiServer := api.NewStrictHandlerWithOptions(ss, nil, api.StrictHTTPServerOptions{
 		RequestErrorHandlerFunc:  api.RequestErrorHandler,
@@ -69,10 +114,25 @@ func (c LDClient) RunServer(ctx context.Context, serverParams ServerParams) {
 	})
 	r := mux.NewRouter()
 	r.Use(handlers.RecoveryHandler(handlers.PrintRecoveryStack(true)))
-	r.Use(adapters.Middleware(*ldClient, serverParams.DevStreamURI))
+	if offline {
+		r.Use(adapters.OfflineMiddleware())
+	} else {
+		r.Use(adapters.Middleware(*ldClient, serverParams.DevStreamURI))
+	}
 	r.Use(model.EventStoreMiddleware(sqlEventStore))
 	r.Use(model.StoreMiddleware(sqlStore))
 	r.Use(model.ObserversMiddleware(observers))
+	r.Use(model.ChangeLogMiddleware(changeLog))
+	r.Use(model.EvaluationsMiddleware(evaluations))
+	r.Use(model.FaultsMiddleware(faults))
+	r.Use(model.ConnectionsMiddleware(connections))
+	if eventForwarder != nil {
+		r.Use(model.EventForwarderMiddleware(eventForwarder))
+	}
+	if metrics != nil {
+		r.Use(model.MetricsMiddleware(metrics))
+		r.Handle("/metrics", metrics.Handler())
+	}
 	r.Use(model.StreamStartupMiddleware(serverParams.StreamFlagStartup))
 	r.Handle("/", http.RedirectHandler("/ui/", http.StatusFound))
 	r.Handle("/ui", http.RedirectHandler("/ui/", http.StatusMovedPermanently))
@@ -86,6 +146,8 @@ func (c LDClient) RunServer(ctx context.Context, serverParams ServerParams) {
 	sdk.BindRoutes(r)
 
 	apiRouter := r.PathPrefix("/dev").Subrouter()
+	apiRouter.Use(model.HistorySourceMiddleware())
+	apiRouter.Use(api.EnvironmentProjectKeyMiddleware)
 	if serverParams.CorsEnabled {
 		apiRouter.Use(handlers.CORS(
 			handlers.AllowedOrigins([]string{serverParams.CorsOrigin}),
@@ -102,40 +164,186 @@ func (c LDClient) RunServer(ctx context.Context, serverParams ServerParams) {
 	}
 	api.HandlerFromMux(apiServer, apiRouter) // this method actually mutates the passed router.
 
-	ctx = adapters.WithApiAndSdk(ctx, *ldClient, serverParams.DevStreamURI)
+	if offline {
+		ctx = adapters.WithOffline(ctx)
+	} else {
+		ctx = adapters.WithApiAndSdk(ctx, *ldClient, serverParams.DevStreamURI)
+	}
 	ctx = model.SetObserversOnContext(ctx, observers)
 	ctx = model.ContextWithStore(ctx, sqlStore)
 	ctx = model.WithStreamStartup(ctx, serverParams.StreamFlagStartup)
+	if metrics != nil {
+		ctx = model.WithMetrics(ctx, metrics)
+	}
 	syncErr := model.CreateOrSyncProject(ctx, serverParams.InitialProjectSettings)
 	if syncErr != nil {
 		log.Fatal(syncErr)
 	}
+	if settings := serverParams.InitialProjectSettings; settings.Enabled && settings.WatchFile != "" {
+		// A project seeded from the watched file already matches it; a synced one takes the file's values on top.
+		if settings.WatchFile != settings.File {
+			err = model.ApplyFlagFile(ctx, settings.ProjectKey, settings.WatchFile)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		err = model.WatchFlagFile(ctx, settings.ProjectKey, settings.WatchFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Watching %s for changes to project [%s]", settings.WatchFile, settings.ProjectKey)
+	}
+	if serverParams.LiveSync && !offline {
+		go model.NewLiveSync().Run(ctx)
+	}
+	if eventForwarder != nil {
+		log.Printf("Forwarding SDK events to %s", serverParams.EventsURI)
+		go eventForwarder.Run(ctx)
+	}
+	go webhooks.Run(ctx)
+	go model.RunOverrideReaper(ctx)
 	handler := handlers.CombinedLoggingHandler(os.Stdout, r)
 
 	addr := fmt.Sprintf("0.0.0.0:%s", serverParams.Port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	scheme := "http"
+	if serverParams.TLS {
+		tlsConfig, err := getTLSConfig(serverParams)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if serverParams.TLSAllowPlain {
+			listener = certs.NewListenerWithPlainFallback(listener, tlsConfig)
+		} else {
+			listener = tls.NewListener(listener, tlsConfig)
+		}
+		scheme = "https"
+	}
 	log.Printf("Server running on %s", addr)
-	log.Printf("Access the UI for toggling overrides at http://localhost:%s/ui or by running `ldcli dev-server ui`", serverParams.Port)
+	log.Printf("Access the UI for toggling overrides at %s://localhost:%s/ui or by running `ldcli dev-server ui`", scheme, serverParams.Port)
 
 	server := http.Server{
-		Addr:    addr,
-		Handler: handler,
+		Addr:        addr,
+		Handler:     handler,
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	err = daemon.Record(serverParams.StateDir, daemon.State{
+		PID:        os.Getpid(),
+		Port:       serverParams.Port,
+		TLS:        serverParams.TLS,
+		AllowPlain: serverParams.TLSAllowPlain,
+		StartedAt:  time.Now(),
+	})
+	if err != nil {
+		log.Printf("Unable to record the running server, other commands may not find it: %v", err)
+	}
+	defer daemon.Forget(serverParams.StateDir, os.Getpid())
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.Serve(listener) }()
+	select {
+	case err := <-serveErr:
+		daemon.Forget(serverParams.StateDir, os.Getpid())
+		log.Fatal(err)
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down")
+	// The context still carries the store and API clients that flushing events needs.
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Unable to finish serving requests: %v", err)
 	}
-	log.Fatal(server.ListenAndServe())
+	if eventForwarder != nil {
+		eventForwarder.Flush(shutdownCtx)
+	}
+	if err := sqlEventStore.Close(); err != nil {
+		log.Printf("Unable to close the events database: %v", err)
+	}
+	if err := sqlStore.Close(); err != nil {
+		log.Printf("Unable to close the database: %v", err)
+	}
+	if serverParams.Ephemeral && serverParams.StateDir != "" {
+		daemon.Forget(serverParams.StateDir, os.Getpid())
+		if err := daemon.RemoveDir(serverParams.StateDir); err != nil {
+			log.Printf("Unable to remove %s: %v", serverParams.StateDir, err)
+		}
+	}
+	log.Printf("Server stopped")
 }
 
-func getDBPath() string {
-	dbFilePath, err := xdg.StateFile("ldcli/dev_server.db")
-	log.Printf("Using database at %s", dbFilePath)
-	if err != nil {
-		log.Fatalf("Unable to create state directory: %s", err)
+// getTLSConfig loads the certificate the dev server was given, or issues one from the local CA for the machine's
+// names and addresses.
+func getTLSConfig(serverParams ServerParams) (*tls.Config, error) {
+	var certificate tls.Certificate
+	if serverParams.TLSCertFile != "" {
+		var err error
+		certificate, err = tls.LoadX509KeyPair(serverParams.TLSCertFile, serverParams.TLSKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load TLS certificate")
+		}
+		log.Printf("Serving HTTPS with the certificate in %s", serverParams.TLSCertFile)
+	} else {
+		dir, err := certs.Dir(serverParams.DataDir)
+		if err != nil {
+			return nil, err
+		}
+		ca, err := certs.LoadOrCreateCA(dir)
+		if err != nil {
+			return nil, err
+		}
+		hosts := certs.LocalHosts()
+		certificate, err = ca.LeafCertificate(hosts)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Serving HTTPS for %s with a certificate from the local CA at %s", strings.Join(hosts, ", "), ca.Path())
+		log.Printf("Run `ldcli dev-server trust-info` to see how to trust the CA on devices and in browsers")
 	}
-	return dbFilePath
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2", "http/1.1"},
+	}, nil
 }
-func getEventsDBPath() string {
-	dbFilePath, err := xdg.StateFile("ldcli/dev_server_events.db")
-	log.Printf("Using database at %s", dbFilePath)
+
+// openStores opens the dev server's databases: in memory when it is ephemeral, and otherwise in the data directory,
+// which defaults to the state directory.
+func openStores(ctx context.Context, serverParams ServerParams) (*db.Sqlite, *events_db.Sqlite, error) {
+	if serverParams.Ephemeral {
+		log.Printf("Running ephemerally, all state is kept in memory and discarded when the server stops")
+		sqlStore, err := db.NewInMemorySqlite(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		sqlEventStore, err := events_db.NewInMemorySqlite(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sqlStore, sqlEventStore, nil
+	}
+
+	dbPath, err := daemon.File(serverParams.DataDir, "dev_server.db")
+	if err != nil {
+		return nil, nil, err
+	}
+	eventsDBPath, err := daemon.File(serverParams.DataDir, "dev_server_events.db")
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Printf("Using database at %s", dbPath)
+	sqlStore, err := db.NewSqlite(ctx, dbPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Printf("Using database at %s", eventsDBPath)
+	sqlEventStore, err := events_db.NewSqlite(ctx, eventsDBPath)
 	if err != nil {
-		log.Fatalf("Unable to create state directory: %s", err)
+		return nil, nil, err
 	}
-	return dbFilePath
+	return sqlStore, sqlEventStore, nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -13,6 +15,8 @@ import (
 type Sqlite struct {
 	database *sql.DB
 	dbPath   string
+	// keepAlive holds a connection to an in-memory database open, which SQLite frees once its last one closes.
+	keepAlive *sql.Conn
 }
 
 func (s *Sqlite) CreateDebugSession(ctx context.Context, debugSessionKey string) error {
@@ -180,17 +184,49 @@ func (s *Sqlite) deleteOrphanedEvents(ctx context.Context) error {
 	return err
 }
 
+// Size returns the size of the database in bytes.
+func (s *Sqlite) Size(ctx context.Context) (int64, error) {
+	var size int64
+	err := s.database.QueryRowContext(ctx, "SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()").Scan(&size)
+	return size, err
+}
+
 var _ model.EventStore = &Sqlite{}
 
 func NewSqlite(ctx context.Context, dbPath string) (*Sqlite, error) {
-	store := new(Sqlite)
-	store.dbPath = dbPath
 	db, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
 		return &Sqlite{}, err
 	}
-	store.database = db
-	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	return newSqlite(ctx, db, dbPath)
+}
+
+// NewInMemorySqlite opens an event store that keeps events in memory, for dev servers whose state is thrown away when
+// they stop. Like the dev server's store, it is a named database in SQLite's memdb VFS that the pool's connections
+// share. Foreign keys are turned on by the DSN, as the pragma only applies to the connection that runs it.
+func NewInMemorySqlite(ctx context.Context) (*Sqlite, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:/ldcli-events-%s?vfs=memdb&_foreign_keys=1", uuid.NewString()))
+	if err != nil {
+		return &Sqlite{}, err
+	}
+	keepAlive, err := db.Conn(ctx)
+	if err != nil {
+		_ = db.Close()
+		return &Sqlite{}, err
+	}
+	store, err := newSqlite(ctx, db, "")
+	if err != nil {
+		_ = keepAlive.Close()
+		_ = db.Close()
+		return &Sqlite{}, err
+	}
+	store.keepAlive = keepAlive
+	return store, nil
+}
+
+func newSqlite(ctx context.Context, db *sql.DB, dbPath string) (*Sqlite, error) {
+	store := &Sqlite{database: db, dbPath: dbPath}
+	_, err := db.Exec("PRAGMA foreign_keys = ON")
 	if err != nil {
 		return &Sqlite{}, err
 	}
@@ -205,6 +241,14 @@ func NewSqlite(ctx context.Context, dbPath string) (*Sqlite, error) {
 	return store, nil
 }
 
+// Close closes the database, waiting for queries that are running to finish.
+func (s *Sqlite) Close() error {
+	if s.keepAlive != nil {
+		_ = s.keepAlive.Close()
+	}
+	return s.database.Close()
+}
+
 func (s *Sqlite) runMigrations(ctx context.Context) error {
 	tx, err := s.database.BeginTx(ctx, nil)
 	if err != nil {
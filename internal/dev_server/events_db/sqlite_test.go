@@ -17,14 +17,32 @@ var testEvent string
 
 func TestDBFunctions(t *testing.T) {
 	ctx := context.Background()
-	dbName := "events_test.db"
-	defer func() {
-		require.NoError(t, os.Remove(dbName))
-	}()
 
-	store, err := events_db.NewSqlite(ctx, dbName)
-	require.NoError(t, err)
+	t.Run("on a file", func(t *testing.T) {
+		dbName := "events_test.db"
+		defer func() {
+			require.NoError(t, os.Remove(dbName))
+		}()
+		store, err := events_db.NewSqlite(ctx, dbName)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, store.Close())
+		}()
+		testDBFunctions(t, store)
+	})
 
+	t.Run("in memory", func(t *testing.T) {
+		store, err := events_db.NewInMemorySqlite(ctx)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, store.Close())
+		}()
+		testDBFunctions(t, store)
+	})
+}
+
+func testDBFunctions(t *testing.T, store *events_db.Sqlite) {
+	ctx := context.Background()
 	require.NotNil(t, store)
 
 	debugSessionKey := "test"
@@ -170,3 +188,22 @@ func TestDBFunctions(t *testing.T) {
 
 	})
 }
+
+func TestInMemorySqlite(t *testing.T) {
+	ctx := context.Background()
+	store, err := events_db.NewInMemorySqlite(ctx)
+	require.NoError(t, err)
+	other, err := events_db.NewInMemorySqlite(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, store.CreateDebugSession(ctx, "test"))
+	require.NoError(t, store.WriteEvent(ctx, "test", "summary", []byte(testEvent)))
+
+	page, err := store.QueryEvents(ctx, "test", nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, page.Events, 1)
+
+	sessions, err := other.QueryDebugSessions(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, sessions.Sessions)
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/gorilla/mux"
+)
+
+// DefaultChangeLogCapacity is how many payload versions of each project the change log remembers.
+const DefaultChangeLogCapacity = 1000
+
+// ChangeLog is an Observer that records which flags changed at each payload version of a project, so that SDKs
+// reconnecting with a recent basis can be sent just those flags. It only remembers the most recent versions of each
+// project, and forgets a project's history on a full sync or import since those may have changed anything, when the
+// project is deleted, and when its payload version goes backwards.
+type ChangeLog struct {
+	mu       sync.Mutex
+	capacity int
+	projects map[string]map[int][]string
+}
+
+func NewChangeLog(capacity int) *ChangeLog {
+	return &ChangeLog{
+		capacity: capacity,
+		projects: make(map[string]map[int][]string),
+	}
+}
+
+func (l *ChangeLog) Handle(event interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch event := event.(type) {
+	case OverrideEvent:
+		versions, ok := l.projects[event.ProjectKey]
+		if ok && event.PayloadVersion < maxKey(versions) {
+			// The payload version was reset, so the versions remembered belong to an earlier payload.
+			ok = false
+		}
+		if !ok {
+			versions = make(map[int][]string)
+			l.projects[event.ProjectKey] = versions
+		}
+		if !slices.Contains(versions[event.PayloadVersion], event.FlagKey) {
+			versions[event.PayloadVersion] = append(versions[event.PayloadVersion], event.FlagKey)
+		}
+		if len(versions) > l.capacity {
+			delete(versions, minKey(versions))
+		}
+	case SyncEvent:
+		delete(l.projects, event.ProjectKey)
+	case ImportEvent:
+		delete(l.projects, event.ProjectKey)
+	case ProjectDeletedEvent:
+		delete(l.projects, event.ProjectKey)
+	}
+}
+
+func minKey(versions map[int][]string) int {
+	first := true
+	var oldest int
+	for version := range versions {
+		if first || version < oldest {
+			oldest = version
+			first = false
+		}
+	}
+	return oldest
+}
+
+func maxKey(versions map[int][]string) int {
+	first := true
+	var latest int
+	for version := range versions {
+		if first || version > latest {
+			latest = version
+			first = false
+		}
+	}
+	return latest
+}
+
+// FlagsChangedSince returns the keys of the flags that changed after payload version since, up to and including
+// current. It returns false when the log doesn't have every version in that range, in which case the caller has to
+// send the whole payload.
+func (l *ChangeLog) FlagsChangedSince(projectKey string, since, current int) ([]string, bool) {
+	if since >= current || current-since > l.capacity {
+		return nil, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	versions := l.projects[projectKey]
+	var flagKeys []string
+	for version := since + 1; version <= current; version++ {
+		changed, ok := versions[version]
+		if !ok {
+			return nil, false
+		}
+		for _, flagKey := range changed {
+			if !slices.Contains(flagKeys, flagKey) {
+				flagKeys = append(flagKeys, flagKey)
+			}
+		}
+	}
+	slices.Sort(flagKeys)
+	return flagKeys, true
+}
+
+const ctxKeyChangeLog = ctxKey("model.ChangeLog")
+
+func WithChangeLog(ctx context.Context, changeLog *ChangeLog) context.Context {
+	return context.WithValue(ctx, ctxKeyChangeLog, changeLog)
+}
+
+// ChangeLogFromContext returns the change log, or nil if there isn't one, in which case no deltas can be sent.
+func ChangeLogFromContext(ctx context.Context) *ChangeLog {
+	changeLog, _ := ctx.Value(ctxKeyChangeLog).(*ChangeLog)
+	return changeLog
+}
+
+// ChangeLogMiddleware puts the change log on the request context.
+func ChangeLogMiddleware(changeLog *ChangeLog) mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			request = request.WithContext(WithChangeLog(request.Context(), changeLog))
+			handler.ServeHTTP(writer, request)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func TestChangeLog(t *testing.T) {
+	newLog := func() *model.ChangeLog {
+		changeLog := model.NewChangeLog(3)
+		changeLog.Handle(model.OverrideEvent{ProjectKey: "proj", FlagKey: "flag-a", PayloadVersion: 2})
+		changeLog.Handle(model.OverrideEvent{ProjectKey: "proj", FlagKey: "flag-b", PayloadVersion: 3})
+		changeLog.Handle(model.OverrideEvent{ProjectKey: "proj", FlagKey: "flag-c", PayloadVersion: 3})
+		changeLog.Handle(model.OverrideEvent{ProjectKey: "proj", FlagKey: "flag-a", PayloadVersion: 4})
+		return changeLog
+	}
+
+	t.Run("returns the flags changed since a version", func(t *testing.T) {
+		flagKeys, ok := newLog().FlagsChangedSince("proj", 2, 4)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"flag-a", "flag-b", "flag-c"}, flagKeys)
+	})
+
+	t.Run("can't catch up from before the versions it remembers", func(t *testing.T) {
+		_, ok := newLog().FlagsChangedSince("proj", 0, 4)
+		assert.False(t, ok)
+	})
+
+	t.Run("forgets the oldest versions past its capacity", func(t *testing.T) {
+		changeLog := newLog()
+		changeLog.Handle(model.OverrideEvent{ProjectKey: "proj", FlagKey: "flag-d", PayloadVersion: 5})
+		_, ok := changeLog.FlagsChangedSince("proj", 1, 5)
+		assert.False(t, ok)
+		flagKeys, ok := changeLog.FlagsChangedSince("proj", 2, 5)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"flag-a", "flag-b", "flag-c", "flag-d"}, flagKeys)
+	})
+
+	t.Run("can't catch up across a version it wasn't told about", func(t *testing.T) {
+		_, ok := newLog().FlagsChangedSince("proj", 2, 5)
+		assert.False(t, ok)
+	})
+
+	t.Run("forgets a project's history on a full sync", func(t *testing.T) {
+		changeLog := newLog()
+		changeLog.Handle(model.SyncEvent{ProjectKey: "proj", PayloadVersion: 5})
+		changeLog.Handle(model.OverrideEvent{ProjectKey: "proj", FlagKey: "flag-b", PayloadVersion: 6})
+
+		_, ok := changeLog.FlagsChangedSince("proj", 4, 6)
+		assert.False(t, ok)
+		flagKeys, ok := changeLog.FlagsChangedSince("proj", 5, 6)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"flag-b"}, flagKeys)
+	})
+
+	for name, event := range map[string]interface{}{
+		"an import":              model.ImportEvent{ProjectKey: "proj", PayloadVersion: 1},
+		"the project's deletion": model.ProjectDeletedEvent{ProjectKey: "proj"},
+	} {
+		t.Run("forgets a project's history on "+name, func(t *testing.T) {
+			changeLog := newLog()
+			changeLog.Handle(event)
+			changeLog.Handle(model.OverrideEvent{ProjectKey: "proj", FlagKey: "flag-d", PayloadVersion: 3})
+
+			flagKeys, ok := changeLog.FlagsChangedSince("proj", 2, 3)
+			assert.True(t, ok)
+			assert.Equal(t, []string{"flag-d"}, flagKeys)
+			_, ok = changeLog.FlagsChangedSince("proj", 1, 3)
+			assert.False(t, ok)
+		})
+	}
+
+	t.Run("forgets a project's history when its payload version goes backwards", func(t *testing.T) {
+		changeLog := newLog()
+		changeLog.Handle(model.OverrideEvent{ProjectKey: "proj", FlagKey: "flag-d", PayloadVersion: 3})
+
+		flagKeys, ok := changeLog.FlagsChangedSince("proj", 2, 3)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"flag-d"}, flagKeys)
+		_, ok = changeLog.FlagsChangedSince("proj", 2, 4)
+		assert.False(t, ok)
+	})
+
+	t.Run("keeps projects apart", func(t *testing.T) {
+		_, ok := newLog().FlagsChangedSince("other", 2, 4)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,407 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+)
+
+// CodegenLanguage is a language that typed flag accessors can be generated in.
+type CodegenLanguage string
+
+const (
+	CodegenLanguageGo         CodegenLanguage = "go"
+	CodegenLanguageTypeScript CodegenLanguage = "typescript"
+)
+
+var CodegenLanguages = []CodegenLanguage{CodegenLanguageGo, CodegenLanguageTypeScript}
+
+// ParseCodegenLanguage returns the codegen language named s.
+func ParseCodegenLanguage(s string) (CodegenLanguage, error) {
+	lang := CodegenLanguage(s)
+	if !slices.Contains(CodegenLanguages, lang) {
+		return "", errors.Errorf("unsupported language %q, expected one of %v", s, CodegenLanguages)
+	}
+	return lang, nil
+}
+
+// DefaultCodegenPackage is the Go package of generated accessors when none is given.
+const DefaultCodegenPackage = "flags"
+
+// flagType is the type of a flag's value that a generated accessor returns, inferred from its variations.
+type flagType int
+
+const (
+	flagTypeJSON flagType = iota
+	flagTypeBool
+	flagTypeInt
+	flagTypeFloat
+	flagTypeString
+)
+
+// codegenFlag is a flag as the generated accessors see it.
+type codegenFlag struct {
+	key        string
+	name       string
+	typ        flagType
+	variations []ldvalue.Value
+	// variationNames are the names of the constants of a string flag's variations.
+	variationNames []string
+	defaultValue   ldvalue.Value
+}
+
+// GenerateFlagAccessors writes a typed accessor for each of the project's flags. Output only depends on the project's
+// flags, so regenerating an unchanged project gives the same source.
+func GenerateFlagAccessors(ctx context.Context, projectKey string, lang CodegenLanguage, packageName string) ([]byte, error) {
+	store := StoreFromContext(ctx)
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	variations, err := store.GetAvailableVariationsForProject(ctx, projectKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to fetch available variations for project %s", projectKey)
+	}
+	flags := codegenFlags(*project, variations)
+
+	switch lang {
+	case CodegenLanguageGo:
+		if packageName == "" {
+			packageName = DefaultCodegenPackage
+		}
+		return generateGo(projectKey, packageName, flags)
+	case CodegenLanguageTypeScript:
+		return generateTypeScript(projectKey, flags), nil
+	}
+	return nil, errors.Errorf("unsupported language %q", lang)
+}
+
+// codegenFlags describes the project's flags, sorted by key. Variations are taken in the order of the flag's
+// configuration when the project has it, and by ID otherwise. The default is the flag's off variation, then its
+// fallthrough variation, then its current value. A null value, which a flag serves when it is off without an off
+// variation, isn't one of the flag's variations, so the first variation is the default then.
+func codegenFlags(project Project, availableVariations map[string][]Variation) []codegenFlag {
+	keys := make([]string, 0, len(project.AllFlagsState))
+	for flagKey := range project.AllFlagsState {
+		keys = append(keys, flagKey)
+	}
+	sort.Strings(keys)
+
+	names := newCodegenNamer()
+	flags := make([]codegenFlag, 0, len(keys))
+	for _, flagKey := range keys {
+		flag := codegenFlag{key: flagKey, defaultValue: project.AllFlagsState[flagKey].Value}
+		if config, ok := project.FlagsData.Flags[flagKey]; ok && len(config.Variations) > 0 {
+			flag.variations = config.Variations
+			if index, ok := config.OffVariation.Get(); ok && index >= 0 && index < len(config.Variations) {
+				flag.defaultValue = config.Variations[index]
+			} else if index, ok := config.Fallthrough.Variation.Get(); ok && index >= 0 && index < len(config.Variations) {
+				flag.defaultValue = config.Variations[index]
+			}
+		} else {
+			flagVariations := slices.Clone(availableVariations[flagKey])
+			sort.SliceStable(flagVariations, func(i, j int) bool {
+				return lessVariationID(flagVariations[i].Id, flagVariations[j].Id)
+			})
+			for _, variation := range flagVariations {
+				if !slices.ContainsFunc(flag.variations, variation.Value.Equal) {
+					flag.variations = append(flag.variations, variation.Value)
+				}
+			}
+		}
+		if flag.defaultValue.IsNull() && len(flag.variations) > 0 {
+			flag.defaultValue = flag.variations[0]
+		}
+		if !slices.ContainsFunc(flag.variations, flag.defaultValue.Equal) {
+			flag.variations = append(flag.variations, flag.defaultValue)
+		}
+		flag.typ = inferFlagType(flag.variations)
+		if flag.typ == flagTypeString {
+			flag.variationNames = variationNames(flag.variations)
+		}
+		flag.name = names.flagName(flagKey, flag.variationNames)
+		flags = append(flags, flag)
+	}
+	return flags
+}
+
+// lessVariationID orders numeric IDs, such as those of flag files, by number.
+func lessVariationID(a, b string) bool {
+	x, errX := strconv.Atoi(a)
+	y, errY := strconv.Atoi(b)
+	if errX == nil && errY == nil {
+		return x < y
+	}
+	return a < b
+}
+
+func inferFlagType(values []ldvalue.Value) flagType {
+	is := func(typ ldvalue.ValueType) bool {
+		return !slices.ContainsFunc(values, func(value ldvalue.Value) bool { return value.Type() != typ })
+	}
+	switch {
+	case is(ldvalue.BoolType):
+		return flagTypeBool
+	case is(ldvalue.NumberType):
+		if slices.ContainsFunc(values, func(value ldvalue.Value) bool { return !value.IsInt() }) {
+			return flagTypeFloat
+		}
+		return flagTypeInt
+	case is(ldvalue.StringType):
+		return flagTypeString
+	}
+	return flagTypeJSON
+}
+
+// variationNames names each string variation after its value, falling back to its position for values with no
+// letters or digits.
+func variationNames(values []ldvalue.Value) []string {
+	names := make([]string, 0, len(values))
+	for i, value := range values {
+		name := pascalCase(value.StringValue())
+		if name == "" {
+			name = "Variation" + strconv.Itoa(i)
+		} else if unicode.IsDigit([]rune(name)[0]) {
+			name = "Value" + name
+		}
+		// Suffixes of the flag's own identifiers are taken.
+		for name == "Key" || name == "Variation" || slices.Contains(names, name) {
+			name += strconv.Itoa(i)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// pascalCase joins the letters and digits of s into an identifier, capitalizing each word.
+func pascalCase(s string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+func camelCase(s string) string {
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// codegenNamer gives each flag a name whose identifiers in every language don't clash with those of other flags or
+// the generated helpers.
+type codegenNamer struct {
+	used map[string]bool
+}
+
+// typeScriptReserved are the reserved words that a flag's function could be named in TypeScript.
+var typeScriptReserved = []string{
+	"await", "break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete", "do", "else",
+	"enum", "export", "extends", "false", "finally", "for", "function", "if", "implements", "import", "in",
+	"instanceof", "interface", "let", "new", "null", "package", "private", "protected", "public", "return", "static",
+	"super", "switch", "this", "throw", "true", "try", "typeof", "var", "void", "while", "with", "yield",
+}
+
+func newCodegenNamer() codegenNamer {
+	used := map[string]bool{"Evaluator": true, "FlagKeys": true, "Context": true}
+	for _, word := range typeScriptReserved {
+		used[word] = true
+	}
+	return codegenNamer{used: used}
+}
+
+func (n codegenNamer) flagName(flagKey string, variationNames []string) string {
+	base := pascalCase(flagKey)
+	if base == "" || unicode.IsDigit([]rune(base)[0]) {
+		base = "Flag" + base
+	}
+	for i := 1; ; i++ {
+		name := base
+		if i == 2 {
+			name += "Flag"
+		} else if i > 2 {
+			name += "Flag" + strconv.Itoa(i-1)
+		}
+		identifiers := []string{name, name + "Key", name + "Variation", camelCase(name)}
+		for _, variationName := range variationNames {
+			identifiers = append(identifiers, name+variationName)
+		}
+		if slices.ContainsFunc(identifiers, func(identifier string) bool { return n.used[identifier] }) {
+			continue
+		}
+		for _, identifier := range identifiers {
+			n.used[identifier] = true
+		}
+		return name
+	}
+}
+
+func codegenHeader(projectKey string) string {
+	return fmt.Sprintf("// Code generated by ldcli dev-server codegen from project %s. DO NOT EDIT.\n\n", projectKey)
+}
+
+// generateGo writes accessors that evaluate the flags with anything that has the LaunchDarkly Go SDK client's
+// variation methods.
+func generateGo(projectKey, packageName string, flags []codegenFlag) ([]byte, error) {
+	var src strings.Builder
+	src.WriteString(codegenHeader(projectKey))
+	fmt.Fprintf(&src, "package %s\n", packageName)
+	if len(flags) > 0 {
+		writeGoAccessors(&src, flags)
+	}
+
+	formatted, err := format.Source([]byte(src.String()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to format generated Go")
+	}
+	return formatted, nil
+}
+
+func writeGoAccessors(src *strings.Builder, flags []codegenFlag) {
+	types := map[flagType]bool{}
+	for _, flag := range flags {
+		types[flag.typ] = true
+	}
+
+	src.WriteString("\nimport (\n\t\"github.com/launchdarkly/go-sdk-common/v3/ldcontext\"\n")
+	if types[flagTypeJSON] {
+		src.WriteString("\t\"github.com/launchdarkly/go-sdk-common/v3/ldvalue\"\n")
+	}
+	src.WriteString(")\n\n")
+
+	src.WriteString("// Evaluator is the part of the LaunchDarkly SDK client that the flag accessors use, such as an *ldclient.LDClient.\n")
+	src.WriteString("type Evaluator interface {\n")
+	for _, typ := range []flagType{flagTypeBool, flagTypeInt, flagTypeFloat, flagTypeString, flagTypeJSON} {
+		if types[typ] {
+			method, goType := goVariationMethod(typ)
+			fmt.Fprintf(src, "\t%s(key string, context ldcontext.Context, defaultVal %s) (%s, error)\n", method, goType, goType)
+		}
+	}
+	src.WriteString("}\n")
+
+	for _, flag := range flags {
+		fmt.Fprintf(src, "\n// %sKey is the key of the %s flag.\nconst %sKey = %s\n", flag.name, flag.key, flag.name, strconv.Quote(flag.key))
+
+		method, goType := goVariationMethod(flag.typ)
+		defaultValue := goTypedValue(flag.typ, flag.defaultValue)
+		returnType := goType
+		result := "value"
+		if flag.typ == flagTypeString {
+			returnType = flag.name + "Variation"
+			result = returnType + "(value)"
+			fmt.Fprintf(src, "\n// %s is a variation of the %s flag.\ntype %s string\n\nconst (\n", returnType, flag.key, returnType)
+			for i, value := range flag.variations {
+				name := flag.name + flag.variationNames[i]
+				fmt.Fprintf(src, "\t%s %s = %s\n", name, returnType, strconv.Quote(value.StringValue()))
+				if value.Equal(flag.defaultValue) {
+					defaultValue = name
+				}
+			}
+			src.WriteString(")\n")
+		}
+
+		described := defaultValue
+		if flag.typ == flagTypeJSON {
+			described = jsonLiteral(flag.defaultValue)
+		}
+		fmt.Fprintf(src, "\n// %s evaluates the %s flag for context, returning %s if it can't be evaluated.\n", flag.name, flag.key, described)
+		fmt.Fprintf(src, "func %s(client Evaluator, context ldcontext.Context) %s {\n", flag.name, returnType)
+		if flag.typ == flagTypeString {
+			defaultValue = "string(" + defaultValue + ")"
+		}
+		fmt.Fprintf(src, "\tvalue, _ := client.%s(%sKey, context, %s)\n\treturn %s\n}\n", method, flag.name, defaultValue, result)
+	}
+}
+
+func goVariationMethod(typ flagType) (method string, goType string) {
+	switch typ {
+	case flagTypeBool:
+		return "BoolVariation", "bool"
+	case flagTypeInt:
+		return "IntVariation", "int"
+	case flagTypeFloat:
+		return "Float64Variation", "float64"
+	case flagTypeString:
+		return "StringVariation", "string"
+	}
+	return "JSONVariation", "ldvalue.Value"
+}
+
+func goTypedValue(typ flagType, value ldvalue.Value) string {
+	switch typ {
+	case flagTypeBool:
+		return strconv.FormatBool(value.BoolValue())
+	case flagTypeInt:
+		return strconv.Itoa(value.IntValue())
+	case flagTypeFloat:
+		return strconv.FormatFloat(value.Float64Value(), 'g', -1, 64)
+	case flagTypeString:
+		return strconv.Quote(value.StringValue())
+	}
+	return goValue(value)
+}
+
+// generateTypeScript writes accessors that evaluate the flags with anything that has the variation method of the
+// LaunchDarkly Node.js server-side SDK client.
+func generateTypeScript(projectKey string, flags []codegenFlag) []byte {
+	var src strings.Builder
+	src.WriteString(codegenHeader(projectKey))
+	src.WriteString("/** The part of the LaunchDarkly SDK client that the flag accessors use, such as an LDClient. */\n")
+	src.WriteString("export interface Evaluator<Context> {\n")
+	src.WriteString("  variation(key: string, context: Context, defaultValue: any): Promise<any>;\n")
+	src.WriteString("}\n\n")
+
+	src.WriteString("/** The keys of the flags. */\nexport const FlagKeys = {\n")
+	for _, flag := range flags {
+		fmt.Fprintf(&src, "  %s: %s,\n", camelCase(flag.name), jsonLiteral(ldvalue.String(flag.key)))
+	}
+	src.WriteString("} as const;\n")
+
+	for _, flag := range flags {
+		function := camelCase(flag.name)
+		defaultValue := jsonLiteral(flag.defaultValue)
+		var returnType, check string
+		switch flag.typ {
+		case flagTypeBool:
+			returnType, check = "boolean", `typeof value === "boolean"`
+		case flagTypeInt, flagTypeFloat:
+			returnType, check = "number", `typeof value === "number"`
+		case flagTypeString:
+			returnType, check = flag.name, fmt.Sprintf("Object.values(%s).includes(value)", flag.name)
+			fmt.Fprintf(&src, "\n/** The variations of the %s flag. */\nexport const %s = {\n", flag.key, flag.name)
+			for i, value := range flag.variations {
+				fmt.Fprintf(&src, "  %s: %s,\n", flag.variationNames[i], jsonLiteral(value))
+				if value.Equal(flag.defaultValue) {
+					defaultValue = flag.name + "." + flag.variationNames[i]
+				}
+			}
+			src.WriteString("} as const;\n")
+			fmt.Fprintf(&src, "export type %s = (typeof %s)[keyof typeof %s];\n", flag.name, flag.name, flag.name)
+		default:
+			returnType, check = "unknown", "value !== undefined"
+		}
+
+		fmt.Fprintf(&src, "\n/** Evaluates the %s flag for context, returning %s if it can't be evaluated. */\n", flag.key, defaultValue)
+		fmt.Fprintf(&src, "export async function %s<Context>(client: Evaluator<Context>, context: Context): Promise<%s> {\n", function, returnType)
+		fmt.Fprintf(&src, "  const value = await client.variation(FlagKeys.%s, context, %s);\n", function, defaultValue)
+		fmt.Fprintf(&src, "  return %s ? value : %s;\n}\n", check, defaultValue)
+	}
+	return []byte(src.String())
+}
+
+func jsonLiteral(value ldvalue.Value) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
@@ -0,0 +1,141 @@
+package model_test
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func TestGenerateFlagAccessors(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+
+	projectKey := "proj"
+	project := &model.Project{
+		Key: projectKey,
+		AllFlagsState: model.FlagsState{
+			"banner":       {Value: ldvalue.String("sale"), Version: 1},
+			"new-checkout": {Value: ldvalue.Bool(true), Version: 1},
+			"max-items":    {Value: ldvalue.Int(10), Version: 1},
+			"limits":       {Value: ldvalue.ObjectBuild().SetInt("max", 3).Build(), Version: 1},
+		},
+		FlagsData: adapters.FlagsData{Flags: map[string]ldmodel.FeatureFlag{
+			"banner": {
+				Key:          "banner",
+				Variations:   []ldvalue.Value{ldvalue.String("welcome"), ldvalue.String("sale"), ldvalue.String("")},
+				OffVariation: ldvalue.NewOptionalInt(0),
+			},
+		}},
+	}
+	variations := map[string][]model.Variation{
+		"new-checkout": {{Id: "1", Value: ldvalue.Bool(false)}, {Id: "0", Value: ldvalue.Bool(true)}},
+		"max-items":    {{Id: "10", Value: ldvalue.Int(10)}, {Id: "2", Value: ldvalue.Int(5)}},
+	}
+	generate := func(lang model.CodegenLanguage) string {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetAvailableVariationsForProject(gomock.Any(), projectKey).Return(variations, nil)
+		src, err := model.GenerateFlagAccessors(ctx, projectKey, lang, "")
+		require.NoError(t, err)
+		return string(src)
+	}
+
+	t.Run("go has a typed accessor per flag", func(t *testing.T) {
+		src := generate(model.CodegenLanguageGo)
+
+		_, err := parser.ParseFile(token.NewFileSet(), "flags.go", src, 0)
+		require.NoError(t, err)
+		assert.Contains(t, src, "// Code generated by ldcli dev-server codegen from project proj. DO NOT EDIT.\n\npackage flags\n")
+		assert.Contains(t, src, "\tBoolVariation(key string, context ldcontext.Context, defaultVal bool) (bool, error)\n")
+		assert.Contains(t, src, "const NewCheckoutKey = \"new-checkout\"\n")
+		assert.Contains(t, src, "func NewCheckout(client Evaluator, context ldcontext.Context) bool {\n"+
+			"\tvalue, _ := client.BoolVariation(NewCheckoutKey, context, true)\n")
+		assert.Contains(t, src, "func MaxItems(client Evaluator, context ldcontext.Context) int {\n"+
+			"\tvalue, _ := client.IntVariation(MaxItemsKey, context, 10)\n")
+		assert.Contains(t, src, "func Limits(client Evaluator, context ldcontext.Context) ldvalue.Value {\n")
+	})
+
+	t.Run("go has constants for the variations of string flags", func(t *testing.T) {
+		src := generate(model.CodegenLanguageGo)
+
+		assert.Contains(t, src, "type BannerVariation string\n")
+		assert.Contains(t, src, "\tBannerWelcome    BannerVariation = \"welcome\"\n"+
+			"\tBannerSale       BannerVariation = \"sale\"\n"+
+			"\tBannerVariation2 BannerVariation = \"\"\n")
+		assert.Contains(t, src, "// Banner evaluates the banner flag for context, returning BannerWelcome if it can't be evaluated.\n"+
+			"func Banner(client Evaluator, context ldcontext.Context) BannerVariation {\n"+
+			"\tvalue, _ := client.StringVariation(BannerKey, context, string(BannerWelcome))\n"+
+			"\treturn BannerVariation(value)\n")
+	})
+
+	t.Run("typescript has a typed accessor per flag", func(t *testing.T) {
+		src := generate(model.CodegenLanguageTypeScript)
+
+		assert.Contains(t, src, "  newCheckout: \"new-checkout\",\n")
+		assert.Contains(t, src, "export const Banner = {\n  Welcome: \"welcome\",\n  Sale: \"sale\",\n  Variation2: \"\",\n} as const;\n")
+		assert.Contains(t, src, "export async function banner<Context>(client: Evaluator<Context>, context: Context): Promise<Banner> {\n"+
+			"  const value = await client.variation(FlagKeys.banner, context, Banner.Welcome);\n"+
+			"  return Object.values(Banner).includes(value) ? value : Banner.Welcome;\n")
+		assert.Contains(t, src, "export async function maxItems<Context>(client: Evaluator<Context>, context: Context): Promise<number> {\n")
+	})
+
+	t.Run("output doesn't depend on the order variations are stored in", func(t *testing.T) {
+		first := generate(model.CodegenLanguageGo)
+		variations["new-checkout"][0], variations["new-checkout"][1] = variations["new-checkout"][1], variations["new-checkout"][0]
+		assert.Equal(t, first, generate(model.CodegenLanguageGo))
+	})
+
+	t.Run("flags that are off without an off variation default to their first variation", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(&model.Project{
+			Key:           projectKey,
+			AllFlagsState: model.FlagsState{"max-items": {Value: ldvalue.Null()}},
+		}, nil)
+		store.EXPECT().GetAvailableVariationsForProject(gomock.Any(), projectKey).Return(variations, nil)
+
+		src, err := model.GenerateFlagAccessors(ctx, projectKey, model.CodegenLanguageGo, "")
+		require.NoError(t, err)
+		assert.Contains(t, string(src), "func MaxItems(client Evaluator, context ldcontext.Context) int {\n"+
+			"\tvalue, _ := client.IntVariation(MaxItemsKey, context, 5)\n")
+	})
+
+	t.Run("flags whose names clash get distinct identifiers", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(&model.Project{
+			Key: projectKey,
+			AllFlagsState: model.FlagsState{
+				"new-checkout": {Value: ldvalue.Bool(true)},
+				"new_checkout": {Value: ldvalue.Bool(true)},
+				"delete":       {Value: ldvalue.Bool(true)},
+				"2fa":          {Value: ldvalue.Bool(true)},
+			},
+		}, nil)
+		store.EXPECT().GetAvailableVariationsForProject(gomock.Any(), projectKey).Return(nil, nil)
+
+		src, err := model.GenerateFlagAccessors(ctx, projectKey, model.CodegenLanguageTypeScript, "")
+		require.NoError(t, err)
+		assert.Contains(t, string(src), "function newCheckout<")
+		assert.Contains(t, string(src), "function newCheckoutFlag<")
+		assert.Contains(t, string(src), "function deleteFlag<")
+		assert.Contains(t, string(src), "function flag2fa<")
+	})
+}
+
+func TestParseCodegenLanguage(t *testing.T) {
+	lang, err := model.ParseCodegenLanguage("typescript")
+	require.NoError(t, err)
+	assert.Equal(t, model.CodegenLanguageTypeScript, lang)
+
+	_, err = model.ParseCodegenLanguage("rust")
+	assert.Error(t, err)
+}
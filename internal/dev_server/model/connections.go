@@ -0,0 +1,190 @@
+package model
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// pollingConnectionTimeout is how long a polling SDK is listed after its last poll. SDKs poll every 30 seconds to 5
+// minutes by default.
+const pollingConnectionTimeout = 10 * time.Minute
+
+type ConnectionKind string
+
+const (
+	StreamingConnection ConnectionKind = "streaming"
+	PollingConnection   ConnectionKind = "polling"
+)
+
+// Connection is an SDK connected to the dev server.
+type Connection struct {
+	ID         string         `json:"id"`
+	ProjectKey string         `json:"projectKey"`
+	Endpoint   string         `json:"endpoint"`
+	Kind       ConnectionKind `json:"kind"`
+	UserAgent  string         `json:"userAgent"`
+	Wrapper    string         `json:"wrapper,omitempty"`
+	RemoteAddr string         `json:"remoteAddr"`
+	// ConnectedAt is when a stream was opened, or when a polling SDK first polled.
+	ConnectedAt time.Time `json:"connectedAt"`
+	// LastPayloadVersion is the payload version of the flags last sent to the SDK.
+	LastPayloadVersion *int `json:"lastPayloadVersion,omitempty"`
+	// LastHeartbeat is when a stream last sent a heartbeat, or when a polling SDK last polled.
+	LastHeartbeat *time.Time `json:"lastHeartbeat,omitempty"`
+}
+
+// pollingClient identifies the SDK behind a series of polls, which each come on a request, and maybe a TCP
+// connection, of their own.
+type pollingClient struct {
+	projectKey string
+	endpoint   string
+	userAgent  string
+	wrapper    string
+	host       string
+}
+
+// Connections is the registry of the SDKs connected to the dev server: open streams until they close, and polling
+// SDKs until they stop polling. It is kept in memory.
+type Connections struct {
+	mu      sync.Mutex
+	streams map[string]*Connection
+	polls   map[pollingClient]*Connection
+}
+
+func NewConnections() *Connections {
+	return &Connections{
+		streams: make(map[string]*Connection),
+		polls:   make(map[pollingClient]*Connection),
+	}
+}
+
+// StreamOpened registers a stream until the returned function is called. The connection's ID and connect time are
+// set by the registry.
+func (c *Connections) StreamOpened(connection Connection) (id string, closed func()) {
+	connection.ID = uuid.New().String()
+	connection.Kind = StreamingConnection
+	connection.ConnectedAt = time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.streams[connection.ID] = &connection
+	return connection.ID, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.streams, connection.ID)
+	}
+}
+
+// Polled registers a poll, as a connection of the SDK that polled until it stops polling.
+func (c *Connections) Polled(connection Connection) {
+	now := time.Now()
+	client := pollingClient{
+		projectKey: connection.ProjectKey,
+		endpoint:   connection.Endpoint,
+		userAgent:  connection.UserAgent,
+		wrapper:    connection.Wrapper,
+		host:       remoteHost(connection.RemoteAddr),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expirePolls(now)
+	if existing, ok := c.polls[client]; ok {
+		connection.ID = existing.ID
+		connection.ConnectedAt = existing.ConnectedAt
+	} else {
+		connection.ID = uuid.New().String()
+		connection.ConnectedAt = now
+	}
+	connection.Kind = PollingConnection
+	connection.LastHeartbeat = &now
+	c.polls[client] = &connection
+}
+
+// PayloadSent records the payload version of flags written to the stream.
+func (c *Connections) PayloadSent(id string, payloadVersion int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if connection, ok := c.streams[id]; ok {
+		connection.LastPayloadVersion = &payloadVersion
+	}
+}
+
+// Heartbeat records a heartbeat sent on the stream.
+func (c *Connections) Heartbeat(id string) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if connection, ok := c.streams[id]; ok {
+		connection.LastHeartbeat = &now
+	}
+}
+
+// List returns the connections of every project, or of one project if projectKey isn't empty, oldest first.
+func (c *Connections) List(projectKey string) []Connection {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expirePolls(time.Now())
+	connections := make([]Connection, 0, len(c.streams)+len(c.polls))
+	for _, connection := range c.streams {
+		if projectKey == "" || connection.ProjectKey == projectKey {
+			connections = append(connections, *connection)
+		}
+	}
+	for _, connection := range c.polls {
+		if projectKey == "" || connection.ProjectKey == projectKey {
+			connections = append(connections, *connection)
+		}
+	}
+	sort.Slice(connections, func(i, j int) bool {
+		if connections[i].ConnectedAt.Equal(connections[j].ConnectedAt) {
+			return connections[i].ID < connections[j].ID
+		}
+		return connections[i].ConnectedAt.Before(connections[j].ConnectedAt)
+	})
+	return connections
+}
+
+// expirePolls drops the polling SDKs that stopped polling. The caller holds the lock.
+func (c *Connections) expirePolls(now time.Time) {
+	for client, connection := range c.polls {
+		if now.Sub(*connection.LastHeartbeat) > pollingConnectionTimeout {
+			delete(c.polls, client)
+		}
+	}
+}
+
+func remoteHost(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+const ctxKeyConnections = ctxKey("model.Connections")
+
+func WithConnections(ctx context.Context, connections *Connections) context.Context {
+	return context.WithValue(ctx, ctxKeyConnections, connections)
+}
+
+// ConnectionsFromContext returns the connection registry, or nil if connections aren't tracked.
+func ConnectionsFromContext(ctx context.Context) *Connections {
+	connections, _ := ctx.Value(ctxKeyConnections).(*Connections)
+	return connections
+}
+
+// ConnectionsMiddleware puts the connection registry on the request context.
+func ConnectionsMiddleware(connections *Connections) mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			request = request.WithContext(WithConnections(request.Context(), connections))
+			handler.ServeHTTP(writer, request)
+		})
+	}
+}
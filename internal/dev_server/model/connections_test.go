@@ -0,0 +1,85 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func TestConnections(t *testing.T) {
+	t.Run("lists streams until they close", func(t *testing.T) {
+		connections := model.NewConnections()
+		id, closed := connections.StreamOpened(model.Connection{ProjectKey: "proj", Endpoint: "/all", UserAgent: "GoClient/7.0.0"})
+
+		listed := connections.List("")
+		require.Len(t, listed, 1)
+		assert.Equal(t, id, listed[0].ID)
+		assert.Equal(t, model.StreamingConnection, listed[0].Kind)
+		assert.Equal(t, "GoClient/7.0.0", listed[0].UserAgent)
+		assert.False(t, listed[0].ConnectedAt.IsZero())
+		assert.Nil(t, listed[0].LastHeartbeat)
+
+		connections.Heartbeat(id)
+		require.NotNil(t, connections.List("")[0].LastHeartbeat)
+
+		closed()
+		assert.Empty(t, connections.List(""))
+	})
+
+	t.Run("lists an SDK's polls as one connection", func(t *testing.T) {
+		connections := model.NewConnections()
+		poll := model.Connection{ProjectKey: "proj", Endpoint: "/sdk/latest-all", UserAgent: "GoClient/7.0.0", RemoteAddr: "10.0.0.1:5000"}
+		connections.Polled(poll)
+		first := connections.List("")[0]
+
+		poll.RemoteAddr = "10.0.0.1:5001"
+		connections.Polled(poll)
+		listed := connections.List("")
+		require.Len(t, listed, 1)
+		assert.Equal(t, first.ID, listed[0].ID)
+		assert.Equal(t, first.ConnectedAt, listed[0].ConnectedAt)
+		assert.Equal(t, "10.0.0.1:5001", listed[0].RemoteAddr)
+		assert.Equal(t, model.PollingConnection, listed[0].Kind)
+
+		poll.RemoteAddr = "10.0.0.2:5000"
+		connections.Polled(poll)
+		assert.Len(t, connections.List(""), 2)
+	})
+
+	t.Run("lists a project's connections", func(t *testing.T) {
+		connections := model.NewConnections()
+		connections.StreamOpened(model.Connection{ProjectKey: "proj", Endpoint: "/all"})
+		connections.StreamOpened(model.Connection{ProjectKey: "other", Endpoint: "/all"})
+		connections.Polled(model.Connection{ProjectKey: "other", Endpoint: "/sdk/poll"})
+
+		assert.Len(t, connections.List("proj"), 1)
+		assert.Len(t, connections.List("other"), 2)
+		assert.Len(t, connections.List(""), 3)
+	})
+
+	t.Run("records the payload versions sent on a stream", func(t *testing.T) {
+		connections := model.NewConnections()
+		version := 1
+		id, _ := connections.StreamOpened(model.Connection{ProjectKey: "proj", LastPayloadVersion: &version})
+		connections.StreamOpened(model.Connection{ProjectKey: "proj", LastPayloadVersion: &version})
+
+		connections.PayloadSent(id, 2)
+		connections.PayloadSent("unknown", 3)
+
+		versions := map[string]int{}
+		for _, connection := range connections.List("proj") {
+			versions[connection.ID] = *connection.LastPayloadVersion
+		}
+		require.Len(t, versions, 2)
+		for connectionID, payloadVersion := range versions {
+			if connectionID == id {
+				assert.Equal(t, 2, payloadVersion)
+			} else {
+				assert.Equal(t, 1, payloadVersion)
+			}
+		}
+	})
+}
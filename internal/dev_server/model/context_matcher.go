@@ -0,0 +1,119 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldattr"
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+)
+
+// ContextMatcher limits an override to contexts of a kind whose attribute has one of the given values. Matchers are
+// written as `user.key == "alice"` or `org.tier in [enterprise, startup]`.
+type ContextMatcher struct {
+	Kind      ldcontext.Kind
+	Attribute string
+	Values    []ldvalue.Value
+}
+
+var contextMatcherPattern = regexp.MustCompile(`^\s*([\w-]+)\.([\w-]+)\s*(==|in)\s*(.+?)\s*$`)
+
+// ParseContextMatcher parses a matcher expression. Values may be JSON literals or bare words, which are read as
+// strings.
+func ParseContextMatcher(expr string) (ContextMatcher, error) {
+	parts := contextMatcherPattern.FindStringSubmatch(expr)
+	if parts == nil {
+		return ContextMatcher{}, errors.Errorf(`invalid context matcher %q: expected <kind>.<attribute> == <value> or <kind>.<attribute> in [<values>]`, expr)
+	}
+	matcher := ContextMatcher{
+		Kind:      ldcontext.Kind(parts[1]),
+		Attribute: parts[2],
+	}
+	if parts[3] == "==" {
+		matcher.Values = []ldvalue.Value{parseMatcherValue(parts[4])}
+		return matcher, nil
+	}
+
+	list := parts[4]
+	if !strings.HasPrefix(list, "[") || !strings.HasSuffix(list, "]") {
+		return ContextMatcher{}, errors.Errorf("invalid context matcher %q: expected a list like [a, b] after in", expr)
+	}
+	var values []ldvalue.Value
+	if err := json.Unmarshal([]byte(list), &values); err != nil {
+		values = nil
+		for _, item := range strings.Split(strings.Trim(list, "[]"), ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, parseMatcherValue(item))
+			}
+		}
+	}
+	if len(values) == 0 {
+		return ContextMatcher{}, errors.Errorf("invalid context matcher %q: the list of values is empty", expr)
+	}
+	matcher.Values = values
+	return matcher, nil
+}
+
+func parseMatcherValue(raw string) ldvalue.Value {
+	var value ldvalue.Value
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return ldvalue.String(raw)
+	}
+	return value
+}
+
+func (m ContextMatcher) String() string {
+	values := make([]string, 0, len(m.Values))
+	for _, value := range m.Values {
+		values = append(values, value.JSONString())
+	}
+	if len(values) == 1 {
+		return fmt.Sprintf("%s.%s == %s", m.Kind, m.Attribute, values[0])
+	}
+	return fmt.Sprintf("%s.%s in [%s]", m.Kind, m.Attribute, strings.Join(values, ", "))
+}
+
+// Matches reports whether the context, or the context of the matcher's kind within a multi-kind context, has the
+// attribute set to one of the matcher's values. Array attributes match if any element does.
+func (m ContextMatcher) Matches(ldCtx ldcontext.Context) bool {
+	individual := ldCtx.IndividualContextByKind(m.Kind)
+	if !individual.IsDefined() {
+		return false
+	}
+	actual := individual.GetValue(m.Attribute)
+	if actual.IsNull() {
+		return false
+	}
+	if actual.Type() == ldvalue.ArrayType {
+		for _, element := range actual.AsValueArray().AsSlice() {
+			if slices.ContainsFunc(m.Values, element.Equal) {
+				return true
+			}
+		}
+		return false
+	}
+	return slices.ContainsFunc(m.Values, actual.Equal)
+}
+
+func (m *ContextMatcher) equal(other *ContextMatcher) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	return m.String() == other.String()
+}
+
+func (m ContextMatcher) clause() ldmodel.Clause {
+	return ldmodel.Clause{
+		ContextKind: m.Kind,
+		Attribute:   ldattr.NewLiteralRef(m.Attribute),
+		Op:          ldmodel.OperatorIn,
+		Values:      m.Values,
+	}
+}
@@ -0,0 +1,67 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func TestParseContextMatcher(t *testing.T) {
+	t.Run("parses an equality with a JSON value", func(t *testing.T) {
+		matcher, err := model.ParseContextMatcher(`user.key == "alice"`)
+		require.NoError(t, err)
+		assert.Equal(t, model.ContextMatcher{Kind: "user", Attribute: "key", Values: []ldvalue.Value{ldvalue.String("alice")}}, matcher)
+	})
+
+	t.Run("reads bare words as strings", func(t *testing.T) {
+		matcher, err := model.ParseContextMatcher("org.tier in [enterprise, startup]")
+		require.NoError(t, err)
+		assert.Equal(t, []ldvalue.Value{ldvalue.String("enterprise"), ldvalue.String("startup")}, matcher.Values)
+		assert.Equal(t, `org.tier in ["enterprise", "startup"]`, matcher.String())
+	})
+
+	t.Run("parses a JSON list", func(t *testing.T) {
+		matcher, err := model.ParseContextMatcher("user.age in [30, 40]")
+		require.NoError(t, err)
+		assert.Equal(t, []ldvalue.Value{ldvalue.Int(30), ldvalue.Int(40)}, matcher.Values)
+	})
+
+	t.Run("string form parses back to the same matcher", func(t *testing.T) {
+		matcher, err := model.ParseContextMatcher(`user.beta == true`)
+		require.NoError(t, err)
+		parsed, err := model.ParseContextMatcher(matcher.String())
+		require.NoError(t, err)
+		assert.Equal(t, matcher, parsed)
+	})
+
+	for _, expr := range []string{"", "key == alice", "user.key = alice", "user.key in alice", "user.key in []"} {
+		t.Run("rejects "+expr, func(t *testing.T) {
+			_, err := model.ParseContextMatcher(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestContextMatcherMatches(t *testing.T) {
+	matcher, err := model.ParseContextMatcher("org.tier in [enterprise, startup]")
+	require.NoError(t, err)
+
+	enterprise := ldcontext.NewBuilder("acme").Kind("org").SetString("tier", "enterprise").Build()
+	free := ldcontext.NewBuilder("tiny").Kind("org").SetString("tier", "free").Build()
+
+	assert.True(t, matcher.Matches(enterprise))
+	assert.False(t, matcher.Matches(free))
+	assert.False(t, matcher.Matches(ldcontext.NewBuilder("alice").SetString("tier", "enterprise").Build()),
+		"contexts of another kind don't match")
+	assert.True(t, matcher.Matches(ldcontext.NewMulti(ldcontext.New("alice"), enterprise)))
+
+	tagged := ldcontext.NewBuilder("alice").SetValue("groups", ldvalue.ArrayOf(ldvalue.String("qa"), ldvalue.String("beta"))).Build()
+	groups, err := model.ParseContextMatcher("user.groups == beta")
+	require.NoError(t, err)
+	assert.True(t, groups.Matches(tagged), "array attributes match if any element does")
+}
@@ -13,7 +13,7 @@ type Environment struct {
 
 func GetEnvironmentsForProject(ctx context.Context, projectKey string, query string, limit *int) ([]Environment, error) {
 	apiAdapter := adapters.GetApi(ctx)
-	environments, err := apiAdapter.GetProjectEnvironments(ctx, projectKey, query, limit)
+	environments, err := apiAdapter.GetProjectEnvironments(ctx, SourceProjectKey(projectKey), query, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	ldeval "github.com/launchdarkly/go-server-sdk-evaluation/v3"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+)
+
+// flagsDataProvider lets the evaluator resolve prerequisites and segments from a project's synced configuration.
+type flagsDataProvider adapters.FlagsData
+
+func (p flagsDataProvider) GetFeatureFlag(key string) *ldmodel.FeatureFlag {
+	flag, ok := p.Flags[key]
+	if !ok || flag.Deleted {
+		return nil
+	}
+	return &flag
+}
+
+func (p flagsDataProvider) GetSegment(key string) *ldmodel.Segment {
+	segment, ok := p.Segments[key]
+	if !ok || segment.Deleted {
+		return nil
+	}
+	return &segment
+}
+
+// EvaluateFlags evaluates every flag in the configuration for the given context.
+func EvaluateFlags(data adapters.FlagsData, ldCtx ldcontext.Context) FlagsState {
+	evaluator := ldeval.NewEvaluator(flagsDataProvider(data))
+	flagsState := make(FlagsState, len(data.Flags))
+	for key, flag := range data.Flags {
+		if flag.Deleted {
+			continue
+		}
+		result := evaluator.Evaluate(&flag, ldCtx, nil)
+		flagsState[key] = FlagState{
+			Value:   result.Detail.Value,
+			Version: flag.Version,
+		}
+	}
+	return flagsState
+}
+
+// GetFlagStateWithOverridesForContext evaluates the project's flags for the given context and applies overrides.
+// Projects synced before flag configurations were stored have nothing to evaluate, so they fall back to the flag
+// state of the project's own context. Overrides scoped by a context matcher only apply when ldCtx matches.
+func (project Project) GetFlagStateWithOverridesForContext(ctx context.Context, ldCtx ldcontext.Context) (FlagsState, error) {
+	if project.FlagsData.Flags == nil {
+		return project.applyOverrides(ctx, project.AllFlagsState, ldCtx)
+	}
+	return project.applyOverrides(ctx, EvaluateFlags(project.FlagsData, ldCtx), ldCtx)
+}
+
+// GetFlagsDataWithOverridesForProject returns the flag and segment configuration served to server-side SDKs, with
+// each active override forced on as the flag's only outcome.
+func (project Project) GetFlagsDataWithOverridesForProject(ctx context.Context) (adapters.FlagsData, error) {
+	store := StoreFromContext(ctx)
+	overrides, err := store.GetOverridesForProject(ctx, project.Key)
+	if err != nil {
+		return adapters.FlagsData{}, errors.Wrapf(err, "unable to fetch overrides for project %s", project.Key)
+	}
+	return project.flagsDataWithOverrides(overrides), nil
+}
+
+// flagsDataWithOverrides applies the overrides to the project's flag configuration.
+func (project Project) flagsDataWithOverrides(overrides Overrides) adapters.FlagsData {
+	flagsData := project.FlagsData
+	if flagsData.Flags == nil {
+		flagsData = FlagsDataFromFlagsState(project.AllFlagsState)
+	}
+	withOverrides := adapters.FlagsData{
+		Flags:    make(map[string]ldmodel.FeatureFlag, len(flagsData.Flags)),
+		Segments: make(map[string]ldmodel.Segment, len(flagsData.Segments)),
+	}
+	for flagKey, flag := range flagsData.Flags {
+		if override, ok := overrides.GetFlag(flagKey); ok {
+			flag = override.ApplyToFlag(flag)
+		}
+		withOverrides.Flags[flagKey] = flag
+	}
+	for segmentKey, segment := range flagsData.Segments {
+		withOverrides.Segments[segmentKey] = segment
+	}
+	return withOverrides
+}
+
+// FlagsDataFromFlagsState builds flag configurations that serve each flag's stored value to every context, for
+// projects that have no synced flag configurations.
+func FlagsDataFromFlagsState(flagsState FlagsState) adapters.FlagsData {
+	flags := make(map[string]ldmodel.FeatureFlag, len(flagsState))
+	for flagKey, state := range flagsState {
+		flags[flagKey] = ldmodel.FeatureFlag{
+			Key:                    flagKey,
+			On:                     true,
+			Fallthrough:            ldmodel.VariationOrRollout{Variation: ldvalue.NewOptionalInt(0)},
+			OffVariation:           ldvalue.NewOptionalInt(0),
+			Variations:             []ldvalue.Value{state.Value},
+			ClientSideAvailability: ldmodel.ClientSideAvailability{UsingMobileKey: true, UsingEnvironmentID: true, Explicit: true},
+			TrackEvents:            state.TrackEvents,
+			TrackEventsFallthrough: state.TrackEvents,
+			Version:                state.Version,
+		}
+	}
+	return adapters.FlagsData{Flags: flags, Segments: map[string]ldmodel.Segment{}}
+}
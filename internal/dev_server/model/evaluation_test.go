@@ -0,0 +1,193 @@
+package model_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldbuilders"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func betaFlagsData() adapters.FlagsData {
+	return adapters.FlagsData{
+		Flags: map[string]ldmodel.FeatureFlag{
+			"betaFlag": ldbuilders.NewFlagBuilder("betaFlag").
+				Version(3).
+				On(true).
+				Variations(ldvalue.String("everyone"), ldvalue.String("beta")).
+				AddRule(ldbuilders.NewRuleBuilder().ID("beta-rule").Variation(1).
+					Clauses(ldbuilders.SegmentMatchClause("beta-users"))).
+				FallthroughVariation(0).
+				Build(),
+			"planFlag": ldbuilders.NewFlagBuilder("planFlag").
+				Version(1).
+				On(true).
+				Variations(ldvalue.Bool(false), ldvalue.Bool(true)).
+				AddRule(ldbuilders.NewRuleBuilder().ID("plan-rule").Variation(1).
+					Clauses(ldbuilders.Clause("plan", ldmodel.OperatorIn, ldvalue.String("pro")))).
+				FallthroughVariation(0).
+				Build(),
+		},
+		Segments: map[string]ldmodel.Segment{
+			"beta-users": ldbuilders.NewSegmentBuilder("beta-users").Included("beta-user").Build(),
+		},
+	}
+}
+
+func TestEvaluateFlags(t *testing.T) {
+	data := betaFlagsData()
+
+	t.Run("context matching a segment rule gets the rule's variation", func(t *testing.T) {
+		flagsState := model.EvaluateFlags(data, ldcontext.New("beta-user"))
+		assert.Equal(t, model.FlagState{Value: ldvalue.String("beta"), Version: 3}, flagsState["betaFlag"])
+	})
+
+	t.Run("context matching an attribute rule gets the rule's variation", func(t *testing.T) {
+		flagsState := model.EvaluateFlags(data, ldcontext.NewBuilder("someone").SetString("plan", "pro").Build())
+		assert.Equal(t, model.FlagState{Value: ldvalue.Bool(true), Version: 1}, flagsState["planFlag"])
+	})
+
+	t.Run("other contexts get the fallthrough", func(t *testing.T) {
+		flagsState := model.EvaluateFlags(data, ldcontext.New("someone"))
+		assert.Equal(t, ldvalue.String("everyone"), flagsState["betaFlag"].Value)
+		assert.Equal(t, ldvalue.Bool(false), flagsState["planFlag"].Value)
+	})
+}
+
+func TestGetFlagStateWithOverridesForContext(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+
+	project := model.Project{
+		Key:       "proj",
+		FlagsData: betaFlagsData(),
+	}
+
+	t.Run("overrides win over evaluated values", func(t *testing.T) {
+		store.EXPECT().GetOverridesForProject(gomock.Any(), "proj").Return(model.Overrides{{
+			ProjectKey: "proj",
+			FlagKey:    "betaFlag",
+			Value:      ldvalue.String("overridden"),
+			Active:     true,
+			Version:    1,
+		}}, nil)
+
+		flagsState, err := project.GetFlagStateWithOverridesForContext(ctx, ldcontext.New("beta-user"))
+		require.NoError(t, err)
+		assert.Equal(t, ldvalue.String("overridden"), flagsState["betaFlag"].Value)
+		assert.Equal(t, 4, flagsState["betaFlag"].Version)
+	})
+
+	t.Run("scoped overrides only apply to matching contexts", func(t *testing.T) {
+		matcher, err := model.ParseContextMatcher(`user.plan == "pro"`)
+		require.NoError(t, err)
+		overrides := model.Overrides{{
+			ProjectKey:     "proj",
+			FlagKey:        "betaFlag",
+			Value:          ldvalue.String("overridden"),
+			Active:         true,
+			Version:        1,
+			ContextMatcher: &matcher,
+		}}
+		store.EXPECT().GetOverridesForProject(gomock.Any(), "proj").Return(overrides, nil).Times(2)
+
+		flagsState, err := project.GetFlagStateWithOverridesForContext(ctx, ldcontext.NewBuilder("someone").SetString("plan", "pro").Build())
+		require.NoError(t, err)
+		assert.Equal(t, ldvalue.String("overridden"), flagsState["betaFlag"].Value)
+
+		flagsState, err = project.GetFlagStateWithOverridesForContext(ctx, ldcontext.New("beta-user"))
+		require.NoError(t, err)
+		assert.Equal(t, ldvalue.String("beta"), flagsState["betaFlag"].Value)
+		assert.Equal(t, 4, flagsState["betaFlag"].Version, "non-matching contexts still see the override's version")
+	})
+
+	t.Run("projects without flag configurations fall back to their flag state", func(t *testing.T) {
+		legacyProject := model.Project{
+			Key:           "proj",
+			AllFlagsState: model.FlagsState{"betaFlag": {Value: ldvalue.String("stored"), Version: 1}},
+		}
+		store.EXPECT().GetOverridesForProject(gomock.Any(), "proj").Return(model.Overrides{}, nil)
+
+		flagsState, err := legacyProject.GetFlagStateWithOverridesForContext(ctx, ldcontext.New("beta-user"))
+		require.NoError(t, err)
+		assert.Equal(t, ldvalue.String("stored"), flagsState["betaFlag"].Value)
+	})
+}
+
+func TestScopedOverridesMatchAcrossSdks(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+
+	flagsData := betaFlagsData()
+	flagsData.Flags["gatedFlag"] = ldbuilders.NewFlagBuilder("gatedFlag").
+		Version(2).
+		On(true).
+		Variations(ldvalue.String("off"), ldvalue.String("targeted"), ldvalue.String("on")).
+		OffVariation(0).
+		AddPrerequisite("planFlag", 1).
+		AddTarget(1, "pro-target").
+		FallthroughVariation(2).
+		Build()
+	project := model.Project{Key: "proj", FlagsData: flagsData}
+
+	matcher, err := model.ParseContextMatcher("user.key in [pro-user, pro-target, free-user]")
+	require.NoError(t, err)
+	overrides := model.Overrides{{
+		ProjectKey:     "proj",
+		FlagKey:        "gatedFlag",
+		Value:          ldvalue.String("overridden"),
+		Active:         true,
+		Version:        1,
+		ContextMatcher: &matcher,
+	}}
+	store.EXPECT().GetOverridesForProject(gomock.Any(), "proj").Return(overrides, nil).AnyTimes()
+
+	serverData, err := project.GetFlagsDataWithOverridesForProject(ctx)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		ldCtx    ldcontext.Context
+		expected ldvalue.Value
+	}{
+		"matching context gets the override": {
+			ldCtx:    ldcontext.NewBuilder("pro-user").SetString("plan", "pro").Build(),
+			expected: ldvalue.String("overridden"),
+		},
+		"individual targets take precedence": {
+			ldCtx:    ldcontext.NewBuilder("pro-target").SetString("plan", "pro").Build(),
+			expected: ldvalue.String("targeted"),
+		},
+		"failed prerequisites take precedence": {
+			ldCtx:    ldcontext.New("free-user"),
+			expected: ldvalue.String("off"),
+		},
+		"other contexts keep their evaluated value": {
+			ldCtx:    ldcontext.NewBuilder("someone").SetString("plan", "pro").Build(),
+			expected: ldvalue.String("on"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			clientState, err := project.GetFlagStateWithOverridesForContext(ctx, tc.ldCtx)
+			require.NoError(t, err)
+			serverState := model.EvaluateFlags(serverData, tc.ldCtx)
+
+			assert.Equal(t, tc.expected, clientState["gatedFlag"].Value)
+			assert.Equal(t, tc.expected, serverState["gatedFlag"].Value)
+			assert.Equal(t, serverState["gatedFlag"].Version, clientState["gatedFlag"].Version)
+		})
+	}
+}
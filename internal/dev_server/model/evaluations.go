@@ -0,0 +1,378 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+)
+
+const (
+	maxContextsPerFlag    = 100
+	maxContextsPerProject = 1000
+)
+
+// FlagEvaluations summarizes how a flag has been evaluated by SDKs connected to the dev server.
+type FlagEvaluations struct {
+	FlagKey       string    `json:"flagKey"`
+	Count         int       `json:"count"`
+	LastEvaluated time.Time `json:"lastEvaluated"`
+	ContextKinds  []string  `json:"contextKinds"`
+	// Contexts are the contexts named by feature events, which SDKs only send for flags with event tracking on.
+	Contexts []SeenContext `json:"contexts"`
+	Values   []ServedValue `json:"values"`
+}
+
+// ServedValue is a value served for a flag and how many evaluations it was served for. The variation is missing when
+// the SDK served its default value, e.g. because the flag doesn't exist.
+type ServedValue struct {
+	Value     ldvalue.Value `json:"value"`
+	Variation *int          `json:"variation,omitempty"`
+	Count     int           `json:"count"`
+}
+
+type SeenContext struct {
+	Kind     string    `json:"kind"`
+	Key      string    `json:"key"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// ProjectEvaluations is everything the dev server has learned from a project's SDK events: the flags that were
+// evaluated, and the contexts that were seen.
+type ProjectEvaluations struct {
+	Flags    []FlagEvaluations `json:"flags"`
+	Contexts []SeenContext     `json:"contexts"`
+}
+
+// Evaluations aggregates the summary, feature and index events that SDKs send to the dev server, per project. The
+// aggregates are kept in memory and start over when the dev server restarts.
+type Evaluations struct {
+	mu       sync.Mutex
+	projects map[string]*projectEvaluations
+}
+
+type projectEvaluations struct {
+	flags    map[string]*flagEvaluations
+	contexts map[contextRef]time.Time
+}
+
+type flagEvaluations struct {
+	count         int
+	lastEvaluated time.Time
+	contextKinds  []string
+	contexts      map[contextRef]time.Time
+	values        map[servedValueKey]int
+}
+
+type contextRef struct {
+	kind string
+	key  string
+}
+
+type servedValueKey struct {
+	value     string
+	variation int
+}
+
+const noVariation = -1
+
+func NewEvaluations() *Evaluations {
+	return &Evaluations{projects: make(map[string]*projectEvaluations)}
+}
+
+// sdkEvent holds the fields of the SDK event kinds that are aggregated.
+type sdkEvent struct {
+	Kind         string                    `json:"kind"`
+	CreationDate int64                     `json:"creationDate"`
+	EndDate      int64                     `json:"endDate"`
+	Key          string                    `json:"key"`
+	Context      json.RawMessage           `json:"context"`
+	ContextKeys  map[string]string         `json:"contextKeys"`
+	UserKey      string                    `json:"userKey"`
+	Features     map[string]summaryFeature `json:"features"`
+}
+
+type summaryFeature struct {
+	ContextKinds []string         `json:"contextKinds"`
+	Counters     []summaryCounter `json:"counters"`
+}
+
+type summaryCounter struct {
+	Value     ldvalue.Value `json:"value"`
+	Variation *int          `json:"variation"`
+	Count     int           `json:"count"`
+}
+
+// Record adds an SDK event to the project's aggregates. Events of other kinds, and events that can't be parsed, are
+// ignored.
+func (e *Evaluations) Record(projectKey string, data json.RawMessage) {
+	var event sdkEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	project := e.project(projectKey)
+
+	switch event.Kind {
+	case "summary":
+		at := eventTime(event.EndDate)
+		for flagKey, feature := range event.Features {
+			flag := project.flag(flagKey)
+			flag.lastEvaluated = later(flag.lastEvaluated, at)
+			for _, kind := range feature.ContextKinds {
+				if !slices.Contains(flag.contextKinds, kind) {
+					flag.contextKinds = append(flag.contextKinds, kind)
+				}
+			}
+			for _, counter := range feature.Counters {
+				flag.count += counter.Count
+				flag.values[newServedValueKey(counter.Value, counter.Variation)] += counter.Count
+			}
+		}
+	case "feature":
+		at := eventTime(event.CreationDate)
+		flag := project.flag(event.Key)
+		flag.lastEvaluated = later(flag.lastEvaluated, at)
+		for _, ref := range event.contextRefs() {
+			seeContext(flag.contexts, ref, at, maxContextsPerFlag)
+			seeContext(project.contexts, ref, at, maxContextsPerProject)
+		}
+	case "index":
+		at := eventTime(event.CreationDate)
+		for _, ref := range event.contextRefs() {
+			seeContext(project.contexts, ref, at, maxContextsPerProject)
+		}
+	}
+}
+
+// ForProject returns the project's aggregates, with flags sorted by key and contexts by when they were last seen.
+func (e *Evaluations) ForProject(projectKey string) ProjectEvaluations {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	result := ProjectEvaluations{
+		Flags:    []FlagEvaluations{},
+		Contexts: []SeenContext{},
+	}
+	project, ok := e.projects[projectKey]
+	if !ok {
+		return result
+	}
+	for flagKey, flag := range project.flags {
+		result.Flags = append(result.Flags, flag.summarize(flagKey))
+	}
+	slices.SortFunc(result.Flags, func(a, b FlagEvaluations) int {
+		return strings.Compare(a.FlagKey, b.FlagKey)
+	})
+	result.Contexts = seenContexts(project.contexts)
+	return result
+}
+
+// ForFlag returns the flag's aggregates, and false if no SDK has reported evaluating it.
+func (e *Evaluations) ForFlag(projectKey, flagKey string) (FlagEvaluations, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	project, ok := e.projects[projectKey]
+	if !ok {
+		return FlagEvaluations{}, false
+	}
+	flag, ok := project.flags[flagKey]
+	if !ok {
+		return FlagEvaluations{}, false
+	}
+	return flag.summarize(flagKey), true
+}
+
+// Clear forgets everything recorded for the project.
+func (e *Evaluations) Clear(projectKey string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.projects, projectKey)
+}
+
+// Handle forgets a project's aggregates when the project is deleted or its flags are replaced by an import.
+func (e *Evaluations) Handle(event interface{}) {
+	switch event := event.(type) {
+	case ProjectDeletedEvent:
+		e.Clear(event.ProjectKey)
+	case ImportEvent:
+		e.Clear(event.ProjectKey)
+	}
+}
+
+func (e *Evaluations) project(projectKey string) *projectEvaluations {
+	project, ok := e.projects[projectKey]
+	if !ok {
+		project = &projectEvaluations{
+			flags:    make(map[string]*flagEvaluations),
+			contexts: make(map[contextRef]time.Time),
+		}
+		e.projects[projectKey] = project
+	}
+	return project
+}
+
+func (p *projectEvaluations) flag(flagKey string) *flagEvaluations {
+	flag, ok := p.flags[flagKey]
+	if !ok {
+		flag = &flagEvaluations{
+			contexts: make(map[contextRef]time.Time),
+			values:   make(map[servedValueKey]int),
+		}
+		p.flags[flagKey] = flag
+	}
+	return flag
+}
+
+func (f *flagEvaluations) summarize(flagKey string) FlagEvaluations {
+	result := FlagEvaluations{
+		FlagKey:       flagKey,
+		Count:         f.count,
+		LastEvaluated: f.lastEvaluated,
+		ContextKinds:  slices.Sorted(slices.Values(f.contextKinds)),
+		Contexts:      seenContexts(f.contexts),
+		Values:        []ServedValue{},
+	}
+	if result.ContextKinds == nil {
+		result.ContextKinds = []string{}
+	}
+	for key, count := range f.values {
+		served := ServedValue{Value: ldvalue.Parse([]byte(key.value)), Count: count}
+		if key.variation != noVariation {
+			variation := key.variation
+			served.Variation = &variation
+		}
+		result.Values = append(result.Values, served)
+	}
+	slices.SortFunc(result.Values, func(a, b ServedValue) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Value.JSONString(), b.Value.JSONString())
+	})
+	return result
+}
+
+func newServedValueKey(value ldvalue.Value, variation *int) servedValueKey {
+	key := servedValueKey{value: value.JSONString(), variation: noVariation}
+	if variation != nil {
+		key.variation = *variation
+	}
+	return key
+}
+
+// contextRefs returns the kind and key of each context the event names. Events carry either the full context, or,
+// from older SDKs, just its keys.
+func (event sdkEvent) contextRefs() []contextRef {
+	var refs []contextRef
+	if len(event.Context) > 0 {
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(event.Context, &attributes); err != nil {
+			return nil
+		}
+		kind := stringAttribute(attributes["kind"])
+		switch kind {
+		case "multi":
+			for kind, nested := range attributes {
+				if kind == "kind" {
+					continue
+				}
+				var individual map[string]json.RawMessage
+				if err := json.Unmarshal(nested, &individual); err == nil {
+					refs = append(refs, contextRef{kind: kind, key: stringAttribute(individual["key"])})
+				}
+			}
+		case "":
+			refs = append(refs, contextRef{kind: "user", key: stringAttribute(attributes["key"])})
+		default:
+			refs = append(refs, contextRef{kind: kind, key: stringAttribute(attributes["key"])})
+		}
+	}
+	for kind, key := range event.ContextKeys {
+		refs = append(refs, contextRef{kind: kind, key: key})
+	}
+	if event.UserKey != "" {
+		refs = append(refs, contextRef{kind: "user", key: event.UserKey})
+	}
+	return refs
+}
+
+func stringAttribute(raw json.RawMessage) string {
+	var value string
+	_ = json.Unmarshal(raw, &value)
+	return value
+}
+
+// seeContext records that the context was seen, forgetting the least recently seen context once there are more
+// than max.
+func seeContext(contexts map[contextRef]time.Time, ref contextRef, at time.Time, max int) {
+	contexts[ref] = later(contexts[ref], at)
+	if len(contexts) <= max {
+		return
+	}
+	var oldest contextRef
+	first := true
+	for ref, lastSeen := range contexts {
+		if first || lastSeen.Before(contexts[oldest]) {
+			oldest = ref
+			first = false
+		}
+	}
+	delete(contexts, oldest)
+}
+
+func seenContexts(contexts map[contextRef]time.Time) []SeenContext {
+	result := make([]SeenContext, 0, len(contexts))
+	for ref, lastSeen := range contexts {
+		result = append(result, SeenContext{Kind: ref.kind, Key: ref.key, LastSeen: lastSeen})
+	}
+	slices.SortFunc(result, func(a, b SeenContext) int {
+		if c := b.LastSeen.Compare(a.LastSeen); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Kind+"/"+a.Key, b.Kind+"/"+b.Key)
+	})
+	return result
+}
+
+// eventTime converts an event's Unix millisecond timestamp, falling back to now for events without one.
+func eventTime(millis int64) time.Time {
+	if millis == 0 {
+		return time.Now().UTC()
+	}
+	return time.UnixMilli(millis).UTC()
+}
+
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+const ctxKeyEvaluations = ctxKey("model.Evaluations")
+
+func WithEvaluations(ctx context.Context, evaluations *Evaluations) context.Context {
+	return context.WithValue(ctx, ctxKeyEvaluations, evaluations)
+}
+
+func EvaluationsFromContext(ctx context.Context) *Evaluations {
+	return ctx.Value(ctxKeyEvaluations).(*Evaluations)
+}
+
+// EvaluationsMiddleware puts the evaluation aggregates on the request context.
+func EvaluationsMiddleware(evaluations *Evaluations) mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			request = request.WithContext(WithEvaluations(request.Context(), evaluations))
+			handler.ServeHTTP(writer, request)
+		})
+	}
+}
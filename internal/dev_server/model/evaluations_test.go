@@ -0,0 +1,123 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func TestEvaluations(t *testing.T) {
+	summary := json.RawMessage(`{
+		"kind": "summary",
+		"startDate": 1700000000000,
+		"endDate": 1700000060000,
+		"features": {
+			"new-checkout": {
+				"default": false,
+				"contextKinds": ["user", "org"],
+				"counters": [
+					{"variation": 1, "version": 3, "value": true, "count": 4},
+					{"variation": 0, "version": 3, "value": false, "count": 1}
+				]
+			},
+			"missing-flag": {
+				"default": "fallback",
+				"contextKinds": ["user"],
+				"counters": [{"value": "fallback", "count": 2, "unknown": true}]
+			}
+		}
+	}`)
+	feature := json.RawMessage(`{
+		"kind": "feature",
+		"creationDate": 1700000090000,
+		"key": "new-checkout",
+		"version": 3,
+		"variation": 1,
+		"value": true,
+		"context": {"kind": "multi", "user": {"key": "alice"}, "org": {"key": "acme"}}
+	}`)
+	index := json.RawMessage(`{"kind": "index", "creationDate": 1700000030000, "context": {"key": "bob"}}`)
+
+	newEvaluations := func() *model.Evaluations {
+		evaluations := model.NewEvaluations()
+		for _, event := range []json.RawMessage{summary, feature, index, json.RawMessage(`{"kind": "custom"}`), json.RawMessage(`not json`)} {
+			evaluations.Record("proj", event)
+		}
+		return evaluations
+	}
+
+	t.Run("summarizes the counters of summary events", func(t *testing.T) {
+		flag, ok := newEvaluations().ForFlag("proj", "new-checkout")
+		require.True(t, ok)
+		assert.Equal(t, 5, flag.Count)
+		assert.Equal(t, []string{"org", "user"}, flag.ContextKinds)
+		one, zero := 1, 0
+		assert.Equal(t, []model.ServedValue{
+			{Value: ldvalue.Bool(true), Variation: &one, Count: 4},
+			{Value: ldvalue.Bool(false), Variation: &zero, Count: 1},
+		}, flag.Values)
+		assert.Equal(t, time.UnixMilli(1700000090000).UTC(), flag.LastEvaluated, "the later feature event is the last evaluation")
+	})
+
+	t.Run("reports flags served the default without a variation", func(t *testing.T) {
+		flag, ok := newEvaluations().ForFlag("proj", "missing-flag")
+		require.True(t, ok)
+		assert.Equal(t, []model.ServedValue{{Value: ldvalue.String("fallback"), Count: 2}}, flag.Values)
+	})
+
+	t.Run("records the contexts of feature events on the flag", func(t *testing.T) {
+		flag, _ := newEvaluations().ForFlag("proj", "new-checkout")
+		assert.ElementsMatch(t, []model.SeenContext{
+			{Kind: "user", Key: "alice", LastSeen: time.UnixMilli(1700000090000).UTC()},
+			{Kind: "org", Key: "acme", LastSeen: time.UnixMilli(1700000090000).UTC()},
+		}, flag.Contexts)
+	})
+
+	t.Run("lists the project's flags and the contexts seen, most recent first", func(t *testing.T) {
+		project := newEvaluations().ForProject("proj")
+		require.Len(t, project.Flags, 2)
+		assert.Equal(t, "missing-flag", project.Flags[0].FlagKey)
+		assert.Equal(t, "new-checkout", project.Flags[1].FlagKey)
+		require.Len(t, project.Contexts, 3)
+		assert.Equal(t, model.SeenContext{Kind: "user", Key: "bob", LastSeen: time.UnixMilli(1700000030000).UTC()}, project.Contexts[2])
+	})
+
+	t.Run("keeps projects apart and can be cleared", func(t *testing.T) {
+		evaluations := newEvaluations()
+		_, ok := evaluations.ForFlag("other", "new-checkout")
+		assert.False(t, ok)
+
+		evaluations.Clear("proj")
+		assert.Empty(t, evaluations.ForProject("proj").Flags)
+	})
+}
+
+func TestEvaluationsHandle(t *testing.T) {
+	summary := json.RawMessage(`{"kind": "summary", "endDate": 1700000060000, "features": {"new-checkout": {"counters": [{"variation": 0, "value": false, "count": 1}]}}}`)
+
+	t.Run("forgets a deleted project", func(t *testing.T) {
+		evaluations := model.NewEvaluations()
+		evaluations.Record("proj", summary)
+		evaluations.Record("other", summary)
+
+		evaluations.Handle(model.ProjectDeletedEvent{ProjectKey: "proj"})
+
+		assert.Empty(t, evaluations.ForProject("proj").Flags)
+		assert.Len(t, evaluations.ForProject("other").Flags, 1)
+	})
+
+	t.Run("forgets a project whose flags were imported", func(t *testing.T) {
+		evaluations := model.NewEvaluations()
+		evaluations.Record("proj", summary)
+
+		evaluations.Handle(model.ImportEvent{ProjectKey: "proj"})
+
+		assert.Empty(t, evaluations.ForProject("proj").Flags)
+	})
+}
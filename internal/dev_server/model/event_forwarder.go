@@ -0,0 +1,317 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+)
+
+// EventPayloadKind is the kind of payload an SDK posted to one of the dev server's event endpoints.
+type EventPayloadKind string
+
+const (
+	AnalyticsEvents  EventPayloadKind = "bulk"
+	DiagnosticEvents EventPayloadKind = "diagnostic"
+)
+
+const (
+	DefaultEventsURI = "https://events.launchdarkly.com"
+
+	eventFlushInterval   = 5 * time.Second
+	maxQueuedEvents      = 10000
+	maxEventSendAttempts = 3
+)
+
+// EventForwarder relays the events SDKs send to the dev server on to LaunchDarkly, authorized with the SDK key of
+// each project's source environment. Analytics events are queued and sent in batches; diagnostic events are sent one
+// at a time. Sends that fail are retried with backoff, and batches that still fail go back on the queue for the next
+// flush. Once the queue is full, new events are dropped.
+type EventForwarder struct {
+	eventsURI  string
+	httpClient *http.Client
+	// RetryBackoff is the wait before the first retry of a send, doubled for each retry after it.
+	RetryBackoff time.Duration
+
+	mu      sync.Mutex
+	queue   []eventBatch
+	queued  int
+	dropped int
+	sdkKeys map[sdkKeyRef]string
+}
+
+type eventBatch struct {
+	eventSource
+	events []json.RawMessage
+}
+
+// eventSource is what the events of a batch have in common.
+type eventSource struct {
+	projectKey string
+	kind       EventPayloadKind
+	schema     string
+	userAgent  string
+}
+
+type sdkKeyRef struct {
+	projectKey           string
+	sourceEnvironmentKey string
+}
+
+func NewEventForwarder(eventsURI string) *EventForwarder {
+	return &EventForwarder{
+		eventsURI:    strings.TrimSuffix(eventsURI, "/"),
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+		RetryBackoff: time.Second,
+		sdkKeys:      make(map[sdkKeyRef]string),
+	}
+}
+
+// Enqueue queues the body of an event request from an SDK for the project. header is the SDK's request header, whose
+// user agent and event schema are passed on.
+func (f *EventForwarder) Enqueue(projectKey string, kind EventPayloadKind, header http.Header, body []byte) {
+	var events []json.RawMessage
+	if kind == DiagnosticEvents {
+		events = []json.RawMessage{body}
+	} else if err := json.Unmarshal(body, &events); err != nil {
+		log.Printf("event forwarding: unable to parse events for project %s: %v", projectKey, err)
+		return
+	}
+	if len(events) == 0 {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.queued+len(events) > maxQueuedEvents {
+		if f.dropped == 0 {
+			log.Printf("event forwarding: queue is full, dropping events until it drains")
+		}
+		f.dropped += len(events)
+		return
+	}
+	f.queue = append(f.queue, eventBatch{
+		eventSource: eventSource{
+			projectKey: projectKey,
+			kind:       kind,
+			schema:     header.Get("X-LaunchDarkly-Event-Schema"),
+			userAgent:  header.Get("User-Agent"),
+		},
+		events: events,
+	})
+	f.queued += len(events)
+}
+
+// Run flushes the queue periodically until ctx is done.
+func (f *EventForwarder) Run(ctx context.Context) {
+	ticker := time.NewTicker(eventFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.Flush(ctx)
+		}
+	}
+}
+
+// Flush sends everything that is queued, merging the analytics events of each project and SDK into one batch.
+func (f *EventForwarder) Flush(ctx context.Context) {
+	f.mu.Lock()
+	queue := f.queue
+	f.queue = nil
+	f.queued = 0
+	if f.dropped > 0 {
+		log.Printf("event forwarding: dropped %d events while the queue was full", f.dropped)
+		f.dropped = 0
+	}
+	f.mu.Unlock()
+
+	var failed []eventBatch
+	for _, batch := range mergeEventBatches(queue) {
+		err := f.send(ctx, batch)
+		if err == nil {
+			continue
+		}
+		log.Printf("event forwarding: unable to send %d %s events for project %s: %v", len(batch.events), batch.kind, batch.projectKey, err)
+		if errors.Is(err, errEventsRejected) {
+			continue
+		}
+		failed = append(failed, batch)
+	}
+	f.requeue(failed)
+}
+
+func mergeEventBatches(queue []eventBatch) []eventBatch {
+	var merged []eventBatch
+	analytics := make(map[eventSource]int)
+	for _, batch := range queue {
+		if batch.kind != AnalyticsEvents {
+			merged = append(merged, batch)
+			continue
+		}
+		if i, ok := analytics[batch.eventSource]; ok {
+			merged[i].events = append(merged[i].events, batch.events...)
+			continue
+		}
+		analytics[batch.eventSource] = len(merged)
+		merged = append(merged, batch)
+	}
+	return merged
+}
+
+// requeue puts batches that failed back at the front of the queue, as far as there is room for them.
+func (f *EventForwarder) requeue(batches []eventBatch) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var kept []eventBatch
+	for _, batch := range batches {
+		if f.queued+len(batch.events) > maxQueuedEvents {
+			f.dropped += len(batch.events)
+			continue
+		}
+		kept = append(kept, batch)
+		f.queued += len(batch.events)
+	}
+	f.queue = append(kept, f.queue...)
+}
+
+// errEventsRejected is returned for sends that LaunchDarkly refused and that would be refused again if retried.
+var errEventsRejected = errors.New("events were rejected")
+
+func (f *EventForwarder) send(ctx context.Context, batch eventBatch) error {
+	sdkKey, err := f.sdkKey(ctx, batch.projectKey)
+	if err != nil {
+		return err
+	}
+	var body []byte
+	if batch.kind == DiagnosticEvents {
+		body = batch.events[0]
+	} else if body, err = json.Marshal(batch.events); err != nil {
+		return errors.Wrap(err, "unable to marshal events")
+	}
+
+	// The payload ID stays the same across retries so that LaunchDarkly can drop duplicates.
+	payloadID := uuid.New().String()
+	backoff := f.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err = f.post(ctx, batch, sdkKey, payloadID, body)
+		if err == nil || errors.Is(err, errEventsRejected) || attempt == maxEventSendAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (f *EventForwarder) post(ctx context.Context, batch eventBatch, sdkKey, payloadID string, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s", f.eventsURI, batch.kind), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", sdkKey)
+	request.Header.Set("Content-Type", "application/json")
+	if batch.userAgent != "" {
+		request.Header.Set("User-Agent", batch.userAgent)
+	}
+	if batch.kind == AnalyticsEvents {
+		request.Header.Set("X-LaunchDarkly-Payload-ID", payloadID)
+		if batch.schema != "" {
+			request.Header.Set("X-LaunchDarkly-Event-Schema", batch.schema)
+		}
+	}
+
+	response, err := f.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+	switch {
+	case response.StatusCode < 300:
+		return nil
+	case response.StatusCode >= 500, response.StatusCode == http.StatusTooManyRequests, response.StatusCode == http.StatusRequestTimeout:
+		return errors.Errorf("events service responded %s", response.Status)
+	case response.StatusCode == http.StatusUnauthorized, response.StatusCode == http.StatusForbidden:
+		f.forgetSdkKey(batch.projectKey)
+		return errors.Wrapf(errEventsRejected, "events service responded %s", response.Status)
+	default:
+		return errors.Wrapf(errEventsRejected, "events service responded %s", response.Status)
+	}
+}
+
+// sdkKey returns the SDK key of the project's source environment: the one recorded when the project synced, or for
+// projects that haven't synced since credentials were recorded, one looked up once per environment.
+func (f *EventForwarder) sdkKey(ctx context.Context, projectKey string) (string, error) {
+	project, err := StoreFromContext(ctx).GetDevProject(ctx, projectKey)
+	if err != nil {
+		return "", errors.Wrapf(errEventsRejected, "unable to get project: %v", err)
+	}
+	if project.IsOffline() {
+		return "", errors.Wrap(errEventsRejected, "offline projects have no source environment to send events to")
+	}
+	if project.Credentials.SdkKey != "" {
+		return project.Credentials.SdkKey, nil
+	}
+	ref := sdkKeyRef{projectKey: projectKey, sourceEnvironmentKey: project.SourceEnvironmentKey}
+	f.mu.Lock()
+	sdkKey, ok := f.sdkKeys[ref]
+	f.mu.Unlock()
+	if ok {
+		return sdkKey, nil
+	}
+	sdkKey, err = adapters.GetApi(ctx).GetSdkKey(ctx, SourceProjectKey(projectKey), project.SourceEnvironmentKey)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to get sdk key")
+	}
+	f.mu.Lock()
+	f.sdkKeys[ref] = sdkKey
+	f.mu.Unlock()
+	return sdkKey, nil
+}
+
+func (f *EventForwarder) forgetSdkKey(projectKey string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ref := range f.sdkKeys {
+		if ref.projectKey == projectKey {
+			delete(f.sdkKeys, ref)
+		}
+	}
+}
+
+const ctxKeyEventForwarder = ctxKey("model.EventForwarder")
+
+func WithEventForwarder(ctx context.Context, forwarder *EventForwarder) context.Context {
+	return context.WithValue(ctx, ctxKeyEventForwarder, forwarder)
+}
+
+// EventForwarderFromContext returns the event forwarder, or nil if events aren't forwarded.
+func EventForwarderFromContext(ctx context.Context) *EventForwarder {
+	forwarder, _ := ctx.Value(ctxKeyEventForwarder).(*EventForwarder)
+	return forwarder
+}
+
+// EventForwarderMiddleware puts the event forwarder on the request context.
+func EventForwarderMiddleware(forwarder *EventForwarder) mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			request = request.WithContext(WithEventForwarder(request.Context(), forwarder))
+			handler.ServeHTTP(writer, request)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package model_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	adapters_mocks "github.com/launchdarkly/ldcli/internal/dev_server/adapters/mocks"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+type receivedEvents struct {
+	path   string
+	header http.Header
+	body   string
+}
+
+// eventsService stands in for LaunchDarkly's events service, answering with the given statuses in turn and 202 once
+// they run out.
+type eventsService struct {
+	mu       sync.Mutex
+	statuses []int
+	received []receivedEvents
+}
+
+func (s *eventsService) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.received = append(s.received, receivedEvents{path: request.URL.Path, header: request.Header, body: string(body)})
+	status := http.StatusAccepted
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	writer.WriteHeader(status)
+}
+
+func TestEventForwarder(t *testing.T) {
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx := model.ContextWithStore(context.Background(), store)
+	ctx, api, _ := adapters_mocks.WithMockApiAndSdk(ctx, mockController)
+	store.EXPECT().GetDevProject(gomock.Any(), "proj").Return(&model.Project{Key: "proj", SourceEnvironmentKey: "test"}, nil).AnyTimes()
+	api.EXPECT().GetSdkKey(gomock.Any(), "proj", "test").Return("sdk-key", nil).AnyTimes()
+
+	header := http.Header{}
+	header.Set("User-Agent", "GoClient/7.0.0")
+	header.Set("X-LaunchDarkly-Event-Schema", "4")
+
+	newForwarder := func(t *testing.T, statuses ...int) (*model.EventForwarder, *eventsService) {
+		service := &eventsService{statuses: statuses}
+		server := httptest.NewServer(service)
+		t.Cleanup(server.Close)
+		forwarder := model.NewEventForwarder(server.URL)
+		forwarder.RetryBackoff = time.Millisecond
+		return forwarder, service
+	}
+
+	t.Run("sends the events of a project in one batch", func(t *testing.T) {
+		forwarder, service := newForwarder(t)
+		forwarder.Enqueue("proj", model.AnalyticsEvents, header, []byte(`[{"kind":"index"}]`))
+		forwarder.Enqueue("proj", model.AnalyticsEvents, header, []byte(`[{"kind":"summary"},{"kind":"custom"}]`))
+		forwarder.Enqueue("proj", model.DiagnosticEvents, header, []byte(`{"kind":"diagnostic"}`))
+		forwarder.Flush(ctx)
+
+		require.Len(t, service.received, 2)
+		bulk := service.received[0]
+		assert.Equal(t, "/bulk", bulk.path)
+		assert.Equal(t, "sdk-key", bulk.header.Get("Authorization"))
+		assert.Equal(t, "GoClient/7.0.0", bulk.header.Get("User-Agent"))
+		assert.Equal(t, "4", bulk.header.Get("X-LaunchDarkly-Event-Schema"))
+		assert.NotEmpty(t, bulk.header.Get("X-LaunchDarkly-Payload-ID"))
+		var events []json.RawMessage
+		require.NoError(t, json.Unmarshal([]byte(bulk.body), &events))
+		assert.Len(t, events, 3)
+
+		diagnostic := service.received[1]
+		assert.Equal(t, "/diagnostic", diagnostic.path)
+		assert.Equal(t, "sdk-key", diagnostic.header.Get("Authorization"))
+		assert.JSONEq(t, `{"kind":"diagnostic"}`, diagnostic.body)
+	})
+
+	t.Run("retries with the same payload id", func(t *testing.T) {
+		forwarder, service := newForwarder(t, http.StatusServiceUnavailable)
+		forwarder.Enqueue("proj", model.AnalyticsEvents, header, []byte(`[{"kind":"index"}]`))
+		forwarder.Flush(ctx)
+
+		require.Len(t, service.received, 2)
+		payloadID := service.received[0].header.Get("X-LaunchDarkly-Payload-ID")
+		assert.Equal(t, payloadID, service.received[1].header.Get("X-LaunchDarkly-Payload-ID"))
+	})
+
+	t.Run("keeps events that couldn't be sent for the next flush", func(t *testing.T) {
+		forwarder, service := newForwarder(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		forwarder.Enqueue("proj", model.AnalyticsEvents, header, []byte(`[{"kind":"index"}]`))
+		forwarder.Flush(ctx)
+		require.Len(t, service.received, 3)
+
+		forwarder.Flush(ctx)
+		require.Len(t, service.received, 4)
+		assert.JSONEq(t, `[{"kind":"index"}]`, service.received[3].body)
+	})
+
+	t.Run("drops events that were rejected", func(t *testing.T) {
+		forwarder, service := newForwarder(t, http.StatusBadRequest)
+		forwarder.Enqueue("proj", model.AnalyticsEvents, header, []byte(`[{"kind":"index"}]`))
+		forwarder.Flush(ctx)
+		forwarder.Flush(ctx)
+
+		assert.Len(t, service.received, 1)
+	})
+
+	t.Run("ignores malformed events", func(t *testing.T) {
+		forwarder, service := newForwarder(t)
+		forwarder.Enqueue("proj", model.AnalyticsEvents, header, []byte(`not json`))
+		forwarder.Flush(ctx)
+
+		assert.Empty(t, service.received)
+	})
+}
@@ -14,3 +14,15 @@ type SyncEvent struct {
 	AllFlagsState  FlagsState
 	PayloadVersion int
 }
+
+// Event for a project created from import data
+type ImportEvent struct {
+	ProjectKey     string
+	AllFlagsState  FlagsState
+	PayloadVersion int
+}
+
+// Event for a deleted project
+type ProjectDeletedEvent struct {
+	ProjectKey string
+}
@@ -0,0 +1,196 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+)
+
+// ExportFormat is a kind of test fixture that a project can be exported as.
+type ExportFormat string
+
+const (
+	// ExportFormatJSON and ExportFormatYAML are the flagValues form of the SDKs' file data source.
+	ExportFormatJSON ExportFormat = "json"
+	ExportFormatYAML ExportFormat = "yaml"
+	// ExportFormatImport is the format that import-project and --offline-file read.
+	ExportFormatImport ExportFormat = "import"
+	// ExportFormatGo is Go code that sets the flags up on an ldtestdata data source.
+	ExportFormatGo ExportFormat = "go"
+)
+
+var ExportFormats = []ExportFormat{ExportFormatJSON, ExportFormatYAML, ExportFormatImport, ExportFormatGo}
+
+// ParseExportFormat returns the export format named s.
+func ParseExportFormat(s string) (ExportFormat, error) {
+	format := ExportFormat(s)
+	if !slices.Contains(ExportFormats, format) {
+		return "", errors.Errorf("unsupported export format %q, expected one of %v", s, ExportFormats)
+	}
+	return format, nil
+}
+
+// ExportProject renders the project's effective flag values, with its active overrides applied for the project's
+// context, as a test fixture in the given format.
+func ExportProject(ctx context.Context, projectKey string, format ExportFormat) ([]byte, error) {
+	store := StoreFromContext(ctx)
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	flagsState, err := project.GetFlagStateWithOverridesForProject(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get overrides for project, %s", projectKey)
+	}
+
+	switch format {
+	case ExportFormatJSON:
+		values := make(map[string]ldvalue.Value, len(flagsState))
+		for flagKey, state := range flagsState {
+			values[flagKey] = state.Value
+		}
+		return marshalJSON(map[string]interface{}{"flagValues": values})
+	case ExportFormatYAML:
+		values := make(map[string]interface{}, len(flagsState))
+		for flagKey, state := range flagsState {
+			values[flagKey] = state.Value.AsArbitraryValue()
+		}
+		return yaml.Marshal(map[string]interface{}{"flagValues": values})
+	}
+
+	variations, err := store.GetAvailableVariationsForProject(ctx, projectKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to fetch available variations for project %s", projectKey)
+	}
+	switch format {
+	case ExportFormatImport:
+		return marshalJSON(importDataFor(*project, flagsState, variations))
+	case ExportFormatGo:
+		return testDataFor(projectKey, flagsState, variations)
+	}
+	return nil, errors.Errorf("unsupported export format %q", format)
+}
+
+func marshalJSON(v interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// importDataFor describes the effective flag values as import data with no overrides, so that importing it
+// reproduces them exactly.
+func importDataFor(project Project, flagsState FlagsState, variations map[string][]Variation) ImportData {
+	availableVariations := make(map[string][]ImportVariation, len(variations))
+	for flagKey, flagVariations := range variations {
+		importVariations := make([]ImportVariation, 0, len(flagVariations))
+		for _, variation := range flagVariations {
+			importVariations = append(importVariations, ImportVariation{
+				Id:          variation.Id,
+				Name:        variation.Name,
+				Description: variation.Description,
+				Value:       variation.Value,
+			})
+		}
+		availableVariations[flagKey] = importVariations
+	}
+	return ImportData{
+		Context:              project.Context,
+		SourceEnvironmentKey: project.SourceEnvironmentKey,
+		FlagsState:           flagsState,
+		AvailableVariations:  &availableVariations,
+	}
+}
+
+// testDataFor writes a function that returns an ldtestdata data source serving the effective flag values to every
+// context. Flags keep their available variations so that tests can switch between them.
+func testDataFor(projectKey string, flagsState FlagsState, variations map[string][]Variation) ([]byte, error) {
+	flagKeys := make([]string, 0, len(flagsState))
+	for flagKey := range flagsState {
+		flagKeys = append(flagKeys, flagKey)
+	}
+	sort.Strings(flagKeys)
+
+	var body strings.Builder
+	usesLdvalue := false
+	for _, flagKey := range flagKeys {
+		value := flagsState[flagKey].Value
+		var values []ldvalue.Value
+		for _, variation := range variations[flagKey] {
+			if !slices.ContainsFunc(values, variation.Value.Equal) {
+				values = append(values, variation.Value)
+			}
+		}
+		index := slices.IndexFunc(values, value.Equal)
+		if index == -1 {
+			values = append(values, value)
+			index = len(values) - 1
+		}
+		allBool := !slices.ContainsFunc(values, func(v ldvalue.Value) bool { return v.Type() != ldvalue.BoolType })
+		switch {
+		case allBool:
+			fmt.Fprintf(&body, "\ttd.Update(td.Flag(%q).VariationForAll(%t))\n", flagKey, value.BoolValue())
+		case len(values) == 1:
+			usesLdvalue = true
+			fmt.Fprintf(&body, "\ttd.Update(td.Flag(%q).ValueForAll(%s))\n", flagKey, goValue(value))
+		default:
+			usesLdvalue = true
+			literals := make([]string, 0, len(values))
+			for _, v := range values {
+				literals = append(literals, goValue(v))
+			}
+			fmt.Fprintf(&body, "\ttd.Update(td.Flag(%q).\n\t\tVariations(%s).\n\t\tVariationForAllIndex(%d))\n",
+				flagKey, strings.Join(literals, ", "), index)
+		}
+	}
+
+	var src strings.Builder
+	src.WriteString("import (\n")
+	if usesLdvalue {
+		src.WriteString("\t\"github.com/launchdarkly/go-sdk-common/v3/ldvalue\"\n")
+	}
+	src.WriteString("\t\"github.com/launchdarkly/go-server-sdk/v7/testhelpers/ldtestdata\"\n)\n\n")
+	fmt.Fprintf(&src, "// newTestData returns a test data source serving the flag values of dev server project %s.\n", projectKey)
+	src.WriteString("func newTestData() *ldtestdata.TestDataSource {\n\ttd := ldtestdata.DataSource()\n")
+	src.WriteString(body.String())
+	src.WriteString("\treturn td\n}\n")
+
+	formatted, err := format.Source([]byte(src.String()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to format Go test data")
+	}
+	return formatted, nil
+}
+
+// goValue is a Go expression for value.
+func goValue(value ldvalue.Value) string {
+	switch value.Type() {
+	case ldvalue.NullType:
+		return "ldvalue.Null()"
+	case ldvalue.BoolType:
+		return fmt.Sprintf("ldvalue.Bool(%t)", value.BoolValue())
+	case ldvalue.NumberType:
+		if value.IsInt() {
+			return fmt.Sprintf("ldvalue.Int(%d)", value.IntValue())
+		}
+		return fmt.Sprintf("ldvalue.Float64(%s)", strconv.FormatFloat(value.Float64Value(), 'g', -1, 64))
+	case ldvalue.StringType:
+		return fmt.Sprintf("ldvalue.String(%s)", strconv.Quote(value.StringValue()))
+	}
+	raw := value.JSONString()
+	if strings.Contains(raw, "`") {
+		return fmt.Sprintf("ldvalue.Parse([]byte(%s))", strconv.Quote(raw))
+	}
+	return fmt.Sprintf("ldvalue.Parse([]byte(`%s`))", raw)
+}
@@ -0,0 +1,121 @@
+package model_test
+
+import (
+	"context"
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func TestExportProject(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+
+	projectKey := "proj"
+	project := &model.Project{
+		Key:                  projectKey,
+		SourceEnvironmentKey: "test",
+		Context:              ldcontext.New("dev"),
+		AllFlagsState: model.FlagsState{
+			"banner":       {Value: ldvalue.String("welcome"), Version: 1},
+			"new-checkout": {Value: ldvalue.Bool(false), Version: 1},
+			"limits":       {Value: ldvalue.ObjectBuild().SetInt("max", 3).Build(), Version: 1},
+		},
+	}
+	overrides := model.Overrides{
+		{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true, Version: 1},
+		{ProjectKey: projectKey, FlagKey: "banner", Value: ldvalue.String("sale"), Active: true, Version: 2},
+	}
+	variations := map[string][]model.Variation{
+		"banner": {
+			{Id: "1", Value: ldvalue.String("welcome")},
+			{Id: "2", Value: ldvalue.String("sale")},
+		},
+		"new-checkout": {
+			{Id: "3", Value: ldvalue.Bool(true)},
+			{Id: "4", Value: ldvalue.Bool(false)},
+		},
+	}
+
+	t.Run("json is the file data source format with overrides applied", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(overrides, nil)
+
+		fixture, err := model.ExportProject(ctx, projectKey, model.ExportFormatJSON)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"flagValues": {"banner": "sale", "new-checkout": true, "limits": {"max": 3}}}`, string(fixture))
+	})
+
+	t.Run("yaml is the file data source format", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(overrides, nil)
+
+		fixture, err := model.ExportProject(ctx, projectKey, model.ExportFormatYAML)
+		require.NoError(t, err)
+		assert.Equal(t, "flagValues:\n    banner: sale\n    limits:\n        max: 3\n    new-checkout: true\n", string(fixture))
+	})
+
+	t.Run("import data reproduces the effective values without overrides", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(overrides, nil)
+		store.EXPECT().GetAvailableVariationsForProject(gomock.Any(), projectKey).Return(variations, nil)
+
+		fixture, err := model.ExportProject(ctx, projectKey, model.ExportFormatImport)
+		require.NoError(t, err)
+
+		var importData model.ImportData
+		require.NoError(t, json.Unmarshal(fixture, &importData))
+		assert.Equal(t, "test", importData.SourceEnvironmentKey)
+		assert.Equal(t, project.Context, importData.Context)
+		assert.Equal(t, ldvalue.String("sale"), importData.FlagsState["banner"].Value)
+		assert.Equal(t, ldvalue.Bool(true), importData.FlagsState["new-checkout"].Value)
+		assert.Nil(t, importData.Overrides)
+		require.NotNil(t, importData.AvailableVariations)
+		assert.Len(t, (*importData.AvailableVariations)["banner"], 2)
+	})
+
+	t.Run("go sets up an ldtestdata data source", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(overrides, nil)
+		store.EXPECT().GetAvailableVariationsForProject(gomock.Any(), projectKey).Return(variations, nil)
+
+		fixture, err := model.ExportProject(ctx, projectKey, model.ExportFormatGo)
+		require.NoError(t, err)
+
+		src := string(fixture)
+		assert.Contains(t, src, `"github.com/launchdarkly/go-server-sdk/v7/testhelpers/ldtestdata"`)
+		assert.Contains(t, src, "td.Update(td.Flag(\"banner\").\n\t\tVariations(ldvalue.String(\"welcome\"), ldvalue.String(\"sale\")).\n\t\tVariationForAllIndex(1))")
+		assert.Contains(t, src, "td.Update(td.Flag(\"limits\").ValueForAll(ldvalue.Parse([]byte(`{\"max\":3}`))))")
+		assert.Contains(t, src, `td.Update(td.Flag("new-checkout").VariationForAll(true))`)
+		_, err = parser.ParseFile(token.NewFileSet(), "fixture.go", "package fixtures\n\n"+src, 0)
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns ErrNotFound for unknown projects", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), "missing").Return(nil, model.NewErrNotFound("project", "missing"))
+
+		_, err := model.ExportProject(ctx, "missing", model.ExportFormatJSON)
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+	})
+}
+
+func TestParseExportFormat(t *testing.T) {
+	format, err := model.ParseExportFormat("go")
+	require.NoError(t, err)
+	assert.Equal(t, model.ExportFormatGo, format)
+
+	_, err = model.ParseExportFormat("xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,172 @@
+package model
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// FaultKind is a way the dev server can misbehave towards SDKs.
+type FaultKind string
+
+const (
+	// FaultLatency delays the response by the fault's latency.
+	FaultLatency FaultKind = "latency"
+	// FaultError answers with the fault's status instead of handling the request.
+	FaultError FaultKind = "error"
+	// FaultDropStream ends streams after the fault's dropAfter.
+	FaultDropStream FaultKind = "drop-stream"
+	// FaultStallHeartbeats stops streams from sending the heartbeats that keep idle connections alive.
+	FaultStallHeartbeats FaultKind = "stall-heartbeats"
+	// FaultMalformed corrupts the JSON of the response's first payload.
+	FaultMalformed FaultKind = "malformed"
+	// FaultTruncated cuts the response's first payload in half and ends the response.
+	FaultTruncated FaultKind = "truncated"
+)
+
+// Fault is a fault injected into a project's SDK endpoints. Each request rolls against the probability, so that a
+// fault with probability 0.5 hits about half of the requests, until the fault expires.
+type Fault struct {
+	Kind        FaultKind  `json:"kind"`
+	Probability float64    `json:"probability"`
+	LatencyMs   int        `json:"latencyMs,omitempty"`
+	Status      int        `json:"status,omitempty"`
+	DropAfterMs int        `json:"dropAfterMs,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+func (f Fault) Latency() time.Duration {
+	return time.Duration(f.LatencyMs) * time.Millisecond
+}
+
+func (f Fault) DropAfter() time.Duration {
+	return time.Duration(f.DropAfterMs) * time.Millisecond
+}
+
+// Validate checks that the fault has what its kind needs.
+func (f Fault) Validate() error {
+	if f.Probability <= 0 || f.Probability > 1 {
+		return errors.New("probability must be greater than 0 and at most 1")
+	}
+	switch f.Kind {
+	case FaultLatency:
+		if f.LatencyMs <= 0 {
+			return errors.New("latency faults need a positive latencyMs")
+		}
+	case FaultError:
+		if f.Status != http.StatusTooManyRequests && (f.Status < 500 || f.Status > 599) {
+			return errors.New("error faults need a status of 429 or 5xx")
+		}
+	case FaultDropStream:
+		if f.DropAfterMs <= 0 {
+			return errors.New("drop-stream faults need a positive dropAfterMs")
+		}
+	case FaultStallHeartbeats, FaultMalformed, FaultTruncated:
+	default:
+		return errors.Errorf("unknown fault kind %q", f.Kind)
+	}
+	return nil
+}
+
+func (f Fault) expired(now time.Time) bool {
+	return f.ExpiresAt != nil && !now.Before(*f.ExpiresAt)
+}
+
+// Faults holds the faults injected into each project's SDK endpoints. Faults are kept in memory, so a restart of the
+// dev server clears them. As an Observer, it clears a project's faults when the project is deleted.
+type Faults struct {
+	mu       sync.Mutex
+	projects map[string][]Fault
+}
+
+func NewFaults() *Faults {
+	return &Faults{projects: make(map[string][]Fault)}
+}
+
+// Add injects a fault into the project's SDK endpoints.
+func (f *Faults) Add(projectKey string, fault Fault) error {
+	if err := fault.Validate(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.projects[projectKey] = append(f.active(projectKey, time.Now()), fault)
+	return nil
+}
+
+// ForProject returns the project's faults that haven't expired.
+func (f *Faults) ForProject(projectKey string) []Fault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Fault{}, f.active(projectKey, time.Now())...)
+}
+
+// Clear removes the project's faults.
+func (f *Faults) Clear(projectKey string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.projects, projectKey)
+}
+
+func (f *Faults) Handle(event interface{}) {
+	if event, ok := event.(ProjectDeletedEvent); ok {
+		f.Clear(event.ProjectKey)
+	}
+}
+
+// Roll returns the project's faults that hit a request, each one rolled against its probability.
+func (f *Faults) Roll(projectKey string) []Fault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var hit []Fault
+	for _, fault := range f.active(projectKey, time.Now()) {
+		if rand.Float64() < fault.Probability {
+			hit = append(hit, fault)
+		}
+	}
+	return hit
+}
+
+// active drops the project's expired faults and returns the rest. The caller holds the lock.
+func (f *Faults) active(projectKey string, now time.Time) []Fault {
+	faults := f.projects[projectKey]
+	var active []Fault
+	for _, fault := range faults {
+		if !fault.expired(now) {
+			active = append(active, fault)
+		}
+	}
+	if len(active) == 0 {
+		delete(f.projects, projectKey)
+	} else if len(active) < len(faults) {
+		f.projects[projectKey] = active
+	}
+	return active
+}
+
+const ctxKeyFaults = ctxKey("model.Faults")
+
+func WithFaults(ctx context.Context, faults *Faults) context.Context {
+	return context.WithValue(ctx, ctxKeyFaults, faults)
+}
+
+// FaultsFromContext returns the injected faults, or nil if fault injection isn't set up.
+func FaultsFromContext(ctx context.Context) *Faults {
+	faults, _ := ctx.Value(ctxKeyFaults).(*Faults)
+	return faults
+}
+
+// FaultsMiddleware puts the injected faults on the request context.
+func FaultsMiddleware(faults *Faults) mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			request = request.WithContext(WithFaults(request.Context(), faults))
+			handler.ServeHTTP(writer, request)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+func TestFaults(t *testing.T) {
+	t.Run("rejects faults without what their kind needs", func(t *testing.T) {
+		faults := model.NewFaults()
+		assert.Error(t, faults.Add("proj", model.Fault{Kind: model.FaultLatency, Probability: 1}))
+		assert.Error(t, faults.Add("proj", model.Fault{Kind: model.FaultError, Probability: 1, Status: 404}))
+		assert.Error(t, faults.Add("proj", model.Fault{Kind: model.FaultDropStream, Probability: 1}))
+		assert.Error(t, faults.Add("proj", model.Fault{Kind: model.FaultMalformed, Probability: 0}))
+		assert.Error(t, faults.Add("proj", model.Fault{Kind: "unknown", Probability: 1}))
+		assert.Empty(t, faults.ForProject("proj"))
+	})
+
+	t.Run("rolls each fault against its probability", func(t *testing.T) {
+		faults := model.NewFaults()
+		require.NoError(t, faults.Add("proj", model.Fault{Kind: model.FaultError, Probability: 1, Status: 503}))
+		require.NoError(t, faults.Add("proj", model.Fault{Kind: model.FaultMalformed, Probability: 0.000001}))
+
+		hit := faults.Roll("proj")
+		require.Len(t, hit, 1)
+		assert.Equal(t, model.FaultError, hit[0].Kind)
+		assert.Empty(t, faults.Roll("other"))
+	})
+
+	t.Run("drops faults once they expire", func(t *testing.T) {
+		faults := model.NewFaults()
+		expiresAt := time.Now().Add(-time.Second)
+		require.NoError(t, faults.Add("proj", model.Fault{Kind: model.FaultTruncated, Probability: 1, ExpiresAt: &expiresAt}))
+		require.NoError(t, faults.Add("proj", model.Fault{Kind: model.FaultStallHeartbeats, Probability: 1}))
+
+		active := faults.ForProject("proj")
+		require.Len(t, active, 1)
+		assert.Equal(t, model.FaultStallHeartbeats, active[0].Kind)
+	})
+
+	t.Run("clears a project's faults", func(t *testing.T) {
+		faults := model.NewFaults()
+		require.NoError(t, faults.Add("proj", model.Fault{Kind: model.FaultMalformed, Probability: 1}))
+		faults.Clear("proj")
+		assert.Empty(t, faults.ForProject("proj"))
+	})
+
+	t.Run("clears a project's faults when the project is deleted", func(t *testing.T) {
+		faults := model.NewFaults()
+		require.NoError(t, faults.Add("proj", model.Fault{Kind: model.FaultMalformed, Probability: 1}))
+		require.NoError(t, faults.Add("other", model.Fault{Kind: model.FaultMalformed, Probability: 1}))
+		faults.Handle(model.ProjectDeletedEvent{ProjectKey: "proj"})
+		assert.Empty(t, faults.ForProject("proj"))
+		assert.Len(t, faults.ForProject("other"), 1)
+	})
+}
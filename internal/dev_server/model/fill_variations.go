@@ -33,7 +33,7 @@ func FillVariations(ctx context.Context, projectKey string) {
 	var flags []ldapi.FeatureFlag
 	var err error
 	for attempt := 0; ; attempt++ {
-		if flags, err = api.GetAllFlags(ctx, projectKey); err == nil {
+		if flags, err = api.GetAllFlags(ctx, SourceProjectKey(projectKey)); err == nil {
 			break
 		}
 		if attempt >= fillRetries {
@@ -6,11 +6,11 @@ import (
 
 	ldapi "github.com/launchdarkly/api-client-go/v14"
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
-	"github.com/launchdarkly/go-server-sdk/v7/interfaces/flagstate"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	adapters_mocks "github.com/launchdarkly/ldcli/internal/dev_server/adapters/mocks"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
@@ -59,12 +59,10 @@ func TestStreamStartupDefersVariationFetch(t *testing.T) {
 	ctx = model.SetObserversOnContext(ctx, model.NewObservers())
 	ctx = model.WithStreamStartup(ctx, true)
 
-	allFlagsState := flagstate.NewAllFlagsBuilder().
-		AddFlag("boolFlag", flagstate.FlagState{Value: ldvalue.Bool(true)}).
-		Build()
+	flagsData := adapters_mocks.FlagsDataFromValues(map[string]ldvalue.Value{"boolFlag": ldvalue.Bool(true)}, 1)
 
-	api.EXPECT().GetSdkKey(gomock.Any(), "proj", "env").Return("sdk", nil)
-	sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), "sdk").Return(allFlagsState, nil)
+	api.EXPECT().GetEnvironmentCredentials(gomock.Any(), "proj", "env").Return(adapters.EnvironmentCredentials{SdkKey: "sdk"}, nil)
+	sdk.EXPECT().GetFlagsData(gomock.Any(), "sdk").Return(flagsData, nil)
 	// Stream mode preserves existing variations; GetAllFlags has no expectation, so the mock fails if it's called here.
 	store.EXPECT().GetAvailableVariationsForProject(gomock.Any(), "proj").Return(map[string][]model.Variation{}, nil)
 	store.EXPECT().InsertProject(gomock.Any(), gomock.Any()).Return(nil)
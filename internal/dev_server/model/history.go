@@ -0,0 +1,339 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+)
+
+// HistorySource is where a change recorded in a project's history came from.
+type HistorySource string
+
+const (
+	HistorySourceCLI      HistorySource = "cli"
+	HistorySourceUI       HistorySource = "ui"
+	HistorySourceAPI      HistorySource = "api"
+	HistorySourceScenario HistorySource = "scenario"
+	// HistorySourceServer is a change the dev server made on its own, such as a sync, an expired override or an
+	// edit to a watched flag file.
+	HistorySourceServer HistorySource = "server"
+)
+
+// HistoryAction is the kind of change recorded in a project's history.
+type HistoryAction string
+
+const (
+	HistoryActionOverride   HistoryAction = "override"
+	HistoryActionDeactivate HistoryAction = "deactivate"
+	HistoryActionImport     HistoryAction = "import"
+	HistoryActionSync       HistoryAction = "sync"
+	HistoryActionUndo       HistoryAction = "undo"
+	HistoryActionRedo       HistoryAction = "redo"
+)
+
+// HistoryEntry records a change to one flag of a project. Entries written together, such as the overrides a scenario
+// replaced, share a change ID and are undone and redone together.
+type HistoryEntry struct {
+	ID         int
+	ChangeID   int
+	ProjectKey string
+	FlagKey    string
+	Action     HistoryAction
+	// OldValue and NewValue are the override's values before and after the change, or the flag's values for a sync.
+	// A nil value means there was no active override, or that the flag wasn't in the project.
+	OldValue *ldvalue.Value
+	NewValue *ldvalue.Value
+	// OldOptions and NewOptions are the override's expiry and context matcher before and after the change.
+	OldOptions OverrideOptions
+	NewOptions OverrideOptions
+	Source     HistorySource
+	// Reverts is the change that an undo reverted or a redo replayed.
+	Reverts   int
+	Timestamp time.Time
+}
+
+// ErrNoChange is returned when a project has no change to undo or redo.
+type ErrNoChange struct {
+	action     string
+	projectKey string
+}
+
+func (e ErrNoChange) Error() string {
+	return fmt.Sprintf("project %s has no change to %s", e.projectKey, e.action)
+}
+
+const ctxKeyHistorySource = ctxKey("model.HistorySource")
+
+func WithHistorySource(ctx context.Context, source HistorySource) context.Context {
+	return context.WithValue(ctx, ctxKeyHistorySource, source)
+}
+
+// HistorySourceFromContext returns where changes made with ctx come from, which is the dev server itself unless the
+// context says otherwise.
+func HistorySourceFromContext(ctx context.Context) HistorySource {
+	if source, ok := ctx.Value(ctxKeyHistorySource).(HistorySource); ok {
+		return source
+	}
+	return HistorySourceServer
+}
+
+// HistorySourceMiddleware attributes the changes made by a request to the CLI or the UI when it came from one of
+// them, and to the API otherwise.
+func HistorySourceMiddleware() mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			request = request.WithContext(WithHistorySource(request.Context(), requestHistorySource(request)))
+			handler.ServeHTTP(writer, request)
+		})
+	}
+}
+
+func requestHistorySource(request *http.Request) HistorySource {
+	if strings.HasPrefix(request.UserAgent(), "launchdarkly-cli/") {
+		return HistorySourceCLI
+	}
+	if referer, err := url.Parse(request.Referer()); err == nil && referer.Host == request.Host &&
+		strings.HasPrefix(referer.Path, "/ui") {
+		return HistorySourceUI
+	}
+	return HistorySourceAPI
+}
+
+// recordHistory appends the entries to the project's history as a single change, returning them as recorded. History
+// is only a record, so a failure to write it is logged rather than failing the change itself.
+func recordHistory(ctx context.Context, projectKey string, entries ...HistoryEntry) []HistoryEntry {
+	if len(entries) == 0 {
+		return entries
+	}
+	now := time.Now()
+	source := HistorySourceFromContext(ctx)
+	for i := range entries {
+		entries[i].ProjectKey = projectKey
+		entries[i].Timestamp = now
+		if entries[i].Source == "" {
+			entries[i].Source = source
+		}
+	}
+	recorded, err := StoreFromContext(ctx).AppendHistory(ctx, projectKey, entries)
+	if err != nil {
+		log.Printf("unable to record history of project %s: %v", projectKey, err)
+		return entries
+	}
+	return recorded
+}
+
+// syncHistory describes the flags whose values changed from before to after as sync entries.
+func syncHistory(before, after FlagsState) []HistoryEntry {
+	var entries []HistoryEntry
+	for flagKey, state := range after {
+		old, ok := before[flagKey]
+		if ok && old.Value.Equal(state.Value) {
+			continue
+		}
+		entry := HistoryEntry{FlagKey: flagKey, Action: HistoryActionSync, NewValue: valuePtr(state.Value)}
+		if ok {
+			entry.OldValue = valuePtr(old.Value)
+		}
+		entries = append(entries, entry)
+	}
+	for flagKey, old := range before {
+		if _, ok := after[flagKey]; !ok {
+			entries = append(entries, HistoryEntry{FlagKey: flagKey, Action: HistoryActionSync, OldValue: valuePtr(old.Value)})
+		}
+	}
+	return entries
+}
+
+func valuePtr(value ldvalue.Value) *ldvalue.Value {
+	return &value
+}
+
+// GetHistory returns the project's history, oldest first.
+func GetHistory(ctx context.Context, projectKey string) ([]HistoryEntry, error) {
+	store := StoreFromContext(ctx)
+	_, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetHistory(ctx, projectKey)
+}
+
+// UndoChange reverts the project's most recent override change that hasn't been undone, restoring the overrides'
+// values, expiries and context matchers in a single update that connected SDKs are notified of. Syncs aren't undone
+// since they come from the source environment. The entries recorded for the undo are returned.
+func UndoChange(ctx context.Context, projectKey string) ([]HistoryEntry, error) {
+	history, err := GetHistory(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	undoable, _ := undoStacks(history)
+	if len(undoable) == 0 {
+		return nil, ErrNoChange{action: "undo", projectKey: projectKey}
+	}
+	changeID := undoable[len(undoable)-1]
+	change := changeEntries(history, changeID)
+	changes := make([]overrideChange, 0, len(change))
+	for i := len(change) - 1; i >= 0; i-- {
+		changes = append(changes, overrideChange{flagKey: change[i].FlagKey, value: change[i].OldValue, options: change[i].OldOptions})
+	}
+	entries, err := replayChange(ctx, projectKey, changes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to undo change %d", changeID)
+	}
+	return recordReplay(ctx, projectKey, HistoryActionUndo, changeID, entries), nil
+}
+
+// RedoChange replays the project's most recently undone change, as long as no other override change was made since.
+// The entries recorded for the redo are returned.
+func RedoChange(ctx context.Context, projectKey string) ([]HistoryEntry, error) {
+	history, err := GetHistory(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	_, redoable := undoStacks(history)
+	if len(redoable) == 0 {
+		return nil, ErrNoChange{action: "redo", projectKey: projectKey}
+	}
+	changeID := redoable[len(redoable)-1]
+	var changes []overrideChange
+	for _, change := range changeEntries(history, changeID) {
+		changes = append(changes, overrideChange{flagKey: change.FlagKey, value: change.NewValue, options: change.NewOptions})
+	}
+	entries, err := replayChange(ctx, projectKey, changes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to redo change %d", changeID)
+	}
+	return recordReplay(ctx, projectKey, HistoryActionRedo, changeID, entries), nil
+}
+
+// recordReplay records the entries of an undo or redo of changeID. When none of the change's flags could be updated,
+// an entry without a flag is recorded instead so that the change still counts as undone or redone.
+func recordReplay(ctx context.Context, projectKey string, action HistoryAction, changeID int, entries []HistoryEntry) []HistoryEntry {
+	if len(entries) == 0 {
+		entries = []HistoryEntry{{}}
+	}
+	for i := range entries {
+		entries[i].Action = action
+		entries[i].Reverts = changeID
+	}
+	return recordHistory(ctx, projectKey, entries...)
+}
+
+// overrideChange is the state an undo or redo puts a flag's override in. A nil value deactivates the override.
+type overrideChange struct {
+	flagKey string
+	value   *ldvalue.Value
+	options OverrideOptions
+}
+
+// replayChange puts the project's overrides in the given states in a single update, returning the history entries of
+// the overrides it changed. Flags that have left the project and overrides that are already inactive are skipped.
+func replayChange(ctx context.Context, projectKey string, changes []overrideChange) ([]HistoryEntry, error) {
+	store := StoreFromContext(ctx)
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	current, err := store.GetOverridesForProject(ctx, projectKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to fetch overrides for project %s", projectKey)
+	}
+
+	var overrides Overrides
+	var entries []HistoryEntry
+	for _, change := range changes {
+		if _, ok := project.AllFlagsState[change.flagKey]; !ok {
+			continue
+		}
+		entry := HistoryEntry{FlagKey: change.flagKey, NewValue: change.value, NewOptions: change.options}
+		if override, ok := current.GetFlag(change.flagKey); ok && override.Active {
+			entry.OldValue = valuePtr(override.Value)
+			entry.OldOptions = override.options()
+		} else if change.value == nil {
+			continue
+		}
+		override := Override{
+			ProjectKey: projectKey,
+			FlagKey:    change.flagKey,
+			Value:      ldvalue.Null(),
+			Active:     change.value != nil,
+
+			ExpiresAt:      change.options.ExpiresAt,
+			ContextMatcher: change.options.ContextMatcher,
+		}
+		if change.value != nil {
+			override.Value = *change.value
+		}
+		overrides = append(overrides, override)
+		entries = append(entries, entry)
+	}
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+
+	project.PayloadVersion, err = store.SetOverrides(ctx, projectKey, overrides)
+	if err != nil {
+		return nil, err
+	}
+	allFlagsWithOverrides, err := project.GetFlagStateWithOverridesForProject(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get overrides for project, %s", projectKey)
+	}
+	GetObserversFromContext(ctx).Notify(SyncEvent{
+		ProjectKey:     projectKey,
+		AllFlagsState:  allFlagsWithOverrides,
+		PayloadVersion: project.PayloadVersion,
+	})
+	return entries, nil
+}
+
+// undoStacks replays the history to find the changes that can be undone and redone, most recent last. A new override
+// change can't be redone over, so it empties the redo stack.
+func undoStacks(history []HistoryEntry) (undoable, redoable []int) {
+	for i, entry := range history {
+		if i > 0 && history[i-1].ChangeID == entry.ChangeID {
+			continue
+		}
+		switch entry.Action {
+		case HistoryActionSync:
+		case HistoryActionUndo:
+			undoable = remove(undoable, entry.Reverts)
+			redoable = append(redoable, entry.Reverts)
+		case HistoryActionRedo:
+			redoable = remove(redoable, entry.Reverts)
+			undoable = append(undoable, entry.Reverts)
+		default:
+			undoable = append(undoable, entry.ChangeID)
+			redoable = nil
+		}
+	}
+	return undoable, redoable
+}
+
+func remove(changeIDs []int, changeID int) []int {
+	for i := len(changeIDs) - 1; i >= 0; i-- {
+		if changeIDs[i] == changeID {
+			return append(changeIDs[:i:i], changeIDs[i+1:]...)
+		}
+	}
+	return changeIDs
+}
+
+func changeEntries(history []HistoryEntry, changeID int) []HistoryEntry {
+	var entries []HistoryEntry
+	for _, entry := range history {
+		if entry.ChangeID == changeID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,245 @@
+package model_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func valuePtr(value ldvalue.Value) *ldvalue.Value {
+	return &value
+}
+
+func echoHistory(ctx context.Context, projectKey string, entries []model.HistoryEntry) ([]model.HistoryEntry, error) {
+	return entries, nil
+}
+
+func TestUndoChange(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+	observers := model.NewObservers()
+	observer := mocks.NewMockObserver(mockController)
+	observers.RegisterObserver(observer)
+	ctx = model.SetObserversOnContext(ctx, observers)
+	ctx = model.WithHistorySource(ctx, model.HistorySourceCLI)
+
+	projectKey := "proj"
+	project := &model.Project{
+		Key:           projectKey,
+		AllFlagsState: model.FlagsState{"new-checkout": {Value: ldvalue.Bool(false), Version: 1}},
+	}
+	history := []model.HistoryEntry{
+		{ID: 1, ChangeID: 1, FlagKey: "new-checkout", Action: model.HistoryActionOverride, NewValue: valuePtr(ldvalue.Bool(true))},
+		{ID: 2, ChangeID: 2, FlagKey: "new-checkout", Action: model.HistoryActionSync, OldValue: valuePtr(ldvalue.Bool(true)), NewValue: valuePtr(ldvalue.Bool(false))},
+		{ID: 3, ChangeID: 3, FlagKey: "new-checkout", Action: model.HistoryActionOverride, OldValue: valuePtr(ldvalue.Bool(true)), NewValue: valuePtr(ldvalue.Bool(false))},
+	}
+
+	t.Run("restores the value the override had before the most recent change", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil).Times(2)
+		store.EXPECT().GetHistory(gomock.Any(), projectKey).Return(history, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(false), Active: true, Version: 2},
+		}, nil)
+		store.EXPECT().SetOverrides(gomock.Any(), projectKey, model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true},
+		}).Return(5, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true, Version: 3},
+		}, nil)
+		observer.EXPECT().Handle(model.SyncEvent{
+			ProjectKey:     projectKey,
+			AllFlagsState:  model.FlagsState{"new-checkout": {Value: ldvalue.Bool(true), Version: 4, TrackEvents: true}},
+			PayloadVersion: 5,
+		})
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).DoAndReturn(echoHistory)
+
+		entries, err := model.UndoChange(ctx, projectKey)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, model.HistoryActionUndo, entries[0].Action)
+		assert.Equal(t, 3, entries[0].Reverts)
+		assert.Equal(t, model.HistorySourceCLI, entries[0].Source)
+		assert.Equal(t, valuePtr(ldvalue.Bool(false)), entries[0].OldValue)
+		assert.Equal(t, valuePtr(ldvalue.Bool(true)), entries[0].NewValue)
+	})
+
+	t.Run("deactivates an override that didn't exist before the change, skipping syncs", func(t *testing.T) {
+		undone := append(history, model.HistoryEntry{ID: 4, ChangeID: 4, FlagKey: "new-checkout", Action: model.HistoryActionUndo, Reverts: 3})
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil).Times(2)
+		store.EXPECT().GetHistory(gomock.Any(), projectKey).Return(undone, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true, Version: 3},
+		}, nil)
+		store.EXPECT().SetOverrides(gomock.Any(), projectKey, model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Null(), Active: false},
+		}).Return(6, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil)
+		observer.EXPECT().Handle(gomock.AssignableToTypeOf(model.SyncEvent{}))
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).DoAndReturn(echoHistory)
+
+		entries, err := model.UndoChange(ctx, projectKey)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, 1, entries[0].Reverts)
+		assert.Nil(t, entries[0].NewValue)
+	})
+
+	t.Run("restores a scoped override's context matcher and a TTL override's expiry", func(t *testing.T) {
+		project := &model.Project{
+			Key: projectKey,
+			AllFlagsState: model.FlagsState{
+				"new-checkout": {Value: ldvalue.Bool(false), Version: 1},
+				"banner":       {Value: ldvalue.String("off"), Version: 1},
+			},
+		}
+		matcher, err := model.ParseContextMatcher(`user.key == "alice"`)
+		require.NoError(t, err)
+		expiresAt := time.Now().Add(time.Hour).UTC()
+		history := []model.HistoryEntry{
+			{ID: 1, ChangeID: 1, FlagKey: "new-checkout", Action: model.HistoryActionOverride, OldValue: valuePtr(ldvalue.Bool(true)),
+				NewValue: valuePtr(ldvalue.Bool(false)), OldOptions: model.OverrideOptions{ContextMatcher: &matcher}},
+			{ID: 2, ChangeID: 1, FlagKey: "banner", Action: model.HistoryActionDeactivate, OldValue: valuePtr(ldvalue.String("on")),
+				OldOptions: model.OverrideOptions{ExpiresAt: expiresAt}},
+		}
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil).Times(2)
+		store.EXPECT().GetHistory(gomock.Any(), projectKey).Return(history, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(false), Active: true, Version: 2},
+		}, nil)
+		store.EXPECT().SetOverrides(gomock.Any(), projectKey, model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "banner", Value: ldvalue.String("on"), Active: true, ExpiresAt: expiresAt},
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true, ContextMatcher: &matcher},
+		}).Return(7, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil)
+		observer.EXPECT().Handle(gomock.AssignableToTypeOf(model.SyncEvent{}))
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(2)).DoAndReturn(echoHistory)
+
+		entries, err := model.UndoChange(ctx, projectKey)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "banner", entries[0].FlagKey)
+		assert.Equal(t, model.OverrideOptions{ExpiresAt: expiresAt}, entries[0].NewOptions)
+		assert.Equal(t, "new-checkout", entries[1].FlagKey)
+		assert.Equal(t, model.OverrideOptions{}, entries[1].OldOptions)
+		assert.Equal(t, model.OverrideOptions{ContextMatcher: &matcher}, entries[1].NewOptions)
+	})
+
+	t.Run("returns ErrNoChange when there's nothing to undo", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetHistory(gomock.Any(), projectKey).Return(history[1:2], nil)
+
+		_, err := model.UndoChange(ctx, projectKey)
+		assert.ErrorAs(t, err, &model.ErrNoChange{})
+	})
+}
+
+func TestRedoChange(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+	observers := model.NewObservers()
+	observer := mocks.NewMockObserver(mockController)
+	observers.RegisterObserver(observer)
+	ctx = model.SetObserversOnContext(ctx, observers)
+
+	projectKey := "proj"
+	project := &model.Project{
+		Key:           projectKey,
+		AllFlagsState: model.FlagsState{"new-checkout": {Value: ldvalue.Bool(false), Version: 1}},
+	}
+	history := []model.HistoryEntry{
+		{ID: 1, ChangeID: 1, FlagKey: "new-checkout", Action: model.HistoryActionOverride, NewValue: valuePtr(ldvalue.Bool(true))},
+		{ID: 2, ChangeID: 2, FlagKey: "new-checkout", Action: model.HistoryActionUndo, OldValue: valuePtr(ldvalue.Bool(true)), Reverts: 1},
+	}
+
+	t.Run("replays the most recently undone change", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil).Times(2)
+		store.EXPECT().GetHistory(gomock.Any(), projectKey).Return(history, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil).Times(2)
+		store.EXPECT().SetOverrides(gomock.Any(), projectKey, model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true},
+		}).Return(3, nil)
+		observer.EXPECT().Handle(gomock.AssignableToTypeOf(model.SyncEvent{}))
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).DoAndReturn(echoHistory)
+
+		entries, err := model.RedoChange(ctx, projectKey)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, model.HistoryActionRedo, entries[0].Action)
+		assert.Equal(t, 1, entries[0].Reverts)
+		assert.Equal(t, model.HistorySourceServer, entries[0].Source)
+	})
+
+	t.Run("replays a scoped override's context matcher and a TTL override's expiry", func(t *testing.T) {
+		matcher, err := model.ParseContextMatcher(`org.tier in [enterprise, startup]`)
+		require.NoError(t, err)
+		expiresAt := time.Now().Add(2 * time.Hour).UTC()
+		history := []model.HistoryEntry{
+			{ID: 1, ChangeID: 1, FlagKey: "new-checkout", Action: model.HistoryActionOverride, NewValue: valuePtr(ldvalue.Bool(true)),
+				NewOptions: model.OverrideOptions{ContextMatcher: &matcher, ExpiresAt: expiresAt}},
+			{ID: 2, ChangeID: 2, FlagKey: "new-checkout", Action: model.HistoryActionUndo, OldValue: valuePtr(ldvalue.Bool(true)),
+				OldOptions: model.OverrideOptions{ContextMatcher: &matcher, ExpiresAt: expiresAt}, Reverts: 1},
+		}
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil).Times(2)
+		store.EXPECT().GetHistory(gomock.Any(), projectKey).Return(history, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil).Times(2)
+		store.EXPECT().SetOverrides(gomock.Any(), projectKey, model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true, ExpiresAt: expiresAt, ContextMatcher: &matcher},
+		}).Return(3, nil)
+		observer.EXPECT().Handle(gomock.AssignableToTypeOf(model.SyncEvent{}))
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).DoAndReturn(echoHistory)
+
+		entries, err := model.RedoChange(ctx, projectKey)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, model.OverrideOptions{ContextMatcher: &matcher, ExpiresAt: expiresAt}, entries[0].NewOptions)
+	})
+
+	t.Run("a new change can't be redone over", func(t *testing.T) {
+		changed := append(history, model.HistoryEntry{ID: 3, ChangeID: 3, FlagKey: "new-checkout", Action: model.HistoryActionOverride, NewValue: valuePtr(ldvalue.Bool(false))})
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetHistory(gomock.Any(), projectKey).Return(changed, nil)
+
+		_, err := model.RedoChange(ctx, projectKey)
+		assert.ErrorAs(t, err, &model.ErrNoChange{})
+	})
+}
+
+func TestHistorySourceMiddleware(t *testing.T) {
+	var source model.HistorySource
+	handler := model.HistorySourceMiddleware()(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		source = model.HistorySourceFromContext(request.Context())
+	}))
+
+	tests := map[string]struct {
+		header http.Header
+		want   model.HistorySource
+	}{
+		"cli": {http.Header{"User-Agent": {"launchdarkly-cli/v1.0.0"}}, model.HistorySourceCLI},
+		"ui":  {http.Header{"Referer": {"http://localhost:8765/ui/"}}, model.HistorySourceUI},
+		"api": {http.Header{"User-Agent": {"curl/8.0"}}, model.HistorySourceAPI},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:8765/dev/projects/proj/overrides/flag", nil)
+			request.Header = test.header
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+			assert.Equal(t, test.want, source)
+		})
+	}
+
+	assert.Equal(t, model.HistorySourceServer, model.HistorySourceFromContext(context.Background()))
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
 	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
 )
 
 // ImportData represents the JSON structure from the project endpoint
@@ -16,8 +21,16 @@ type ImportData struct {
 	Context              ldcontext.Context             `json:"context"`
 	SourceEnvironmentKey string                        `json:"sourceEnvironmentKey"`
 	FlagsState           FlagsState                    `json:"flagsState"`
-	Overrides            *FlagsState                   `json:"overrides,omitempty"`
+	Overrides            *OverridesState               `json:"overrides,omitempty"`
 	AvailableVariations  *map[string][]ImportVariation `json:"availableVariations,omitempty"`
+	// Flags is a shorthand for hand-written flag files; it is expanded into FlagsState and AvailableVariations.
+	Flags map[string]ImportFlag `json:"flags,omitempty"`
+}
+
+// ImportFlag is the shorthand form of a flag: the value it serves and the other values it can be overridden to.
+type ImportFlag struct {
+	Value      ldvalue.Value   `json:"value"`
+	Variations []ldvalue.Value `json:"variations,omitempty"`
 }
 
 // ImportVariation represents a variation in the import data format
@@ -46,33 +59,21 @@ func ImportProject(ctx context.Context, projectKey string, importData ImportData
 		return NewErrAlreadyExists("project", projectKey)
 	}
 
+	overrideOptions, err := importData.overrideOptions()
+	if err != nil {
+		return err
+	}
+
 	// Create project from import data
 	project := Project{
 		Key:                  projectKey,
 		SourceEnvironmentKey: importData.SourceEnvironmentKey,
 		Context:              importData.Context,
 		AllFlagsState:        importData.FlagsState,
-		AvailableVariations:  []FlagVariation{},
+		AvailableVariations:  importData.flagVariations(),
 		PayloadVersion:       1,
 	}
 
-	// Convert available variations if present
-	if importData.AvailableVariations != nil {
-		for flagKey, variations := range *importData.AvailableVariations {
-			for _, v := range variations {
-				project.AvailableVariations = append(project.AvailableVariations, FlagVariation{
-					FlagKey: flagKey,
-					Variation: Variation{
-						Id:          v.Id,
-						Name:        v.Name,
-						Description: v.Description,
-						Value:       v.Value,
-					},
-				})
-			}
-		}
-	}
-
 	// Insert project into database
 	err = store.InsertProject(ctx, project)
 	if err != nil {
@@ -81,6 +82,8 @@ func ImportProject(ctx context.Context, projectKey string, importData ImportData
 
 	// Import overrides if present
 	if importData.Overrides != nil {
+		var entries []HistoryEntry
+		defer func() { recordHistory(ctx, projectKey, entries...) }()
 		for flagKey, flagState := range *importData.Overrides {
 			// Use store directly instead of UpsertOverride to avoid observer notifications
 			override := Override{
@@ -89,40 +92,152 @@ func ImportProject(ctx context.Context, projectKey string, importData ImportData
 				Value:      flagState.Value,
 				Active:     true,
 				Version:    1,
+
+				ExpiresAt:      overrideOptions[flagKey].ExpiresAt,
+				ContextMatcher: overrideOptions[flagKey].ContextMatcher,
 			}
 			_, err = store.UpsertOverride(ctx, override)
 			if err != nil {
 				return errors.Wrapf(err, "unable to import override for flag %s", flagKey)
 			}
+			entries = append(entries, HistoryEntry{
+				FlagKey:    flagKey,
+				Action:     HistoryActionImport,
+				NewValue:   valuePtr(flagState.Value),
+				NewOptions: overrideOptions[flagKey],
+			})
 		}
 	}
 
 	return nil
 }
 
-// ImportProjectFromFile reads a JSON file and imports the project data.
-func ImportProjectFromFile(ctx context.Context, projectKey, filepath string) error {
-	// Read file
-	data, err := os.ReadFile(filepath)
+// overrideOptions parses the options of each imported override.
+func (importData ImportData) overrideOptions() (map[string]OverrideOptions, error) {
+	options := map[string]OverrideOptions{}
+	if importData.Overrides == nil {
+		return options, nil
+	}
+	for flagKey, state := range *importData.Overrides {
+		flagOptions, err := state.options()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid override for flag %s", flagKey)
+		}
+		options[flagKey] = flagOptions
+	}
+	return options, nil
+}
+
+// flagVariations converts the available variations, if present, to the project's format.
+func (importData ImportData) flagVariations() []FlagVariation {
+	flagVariations := []FlagVariation{}
+	if importData.AvailableVariations == nil {
+		return flagVariations
+	}
+	for flagKey, variations := range *importData.AvailableVariations {
+		for _, v := range variations {
+			flagVariations = append(flagVariations, FlagVariation{
+				FlagKey: flagKey,
+				Variation: Variation{
+					Id:          v.Id,
+					Name:        v.Name,
+					Description: v.Description,
+					Value:       v.Value,
+				},
+			})
+		}
+	}
+	return flagVariations
+}
+
+// NotifyProjectImported tells observers about a project that ImportProject created. ImportProject doesn't do this
+// itself because import-project runs it without a dev server to observe it.
+func NotifyProjectImported(ctx context.Context, project Project) error {
+	allFlagsWithOverrides, err := project.GetFlagStateWithOverridesForProject(ctx)
 	if err != nil {
-		return errors.Wrapf(err, "unable to read file %s", filepath)
+		return errors.Wrapf(err, "unable to get overrides for project, %s", project.Key)
 	}
+	GetObserversFromContext(ctx).Notify(ImportEvent{
+		ProjectKey:     project.Key,
+		AllFlagsState:  allFlagsWithOverrides,
+		PayloadVersion: project.PayloadVersion,
+	})
+	return nil
+}
 
-	// Parse JSON
-	var importData ImportData
-	err = json.Unmarshal(data, &importData)
+// ImportProjectFromFile reads a JSON or YAML file and imports the project data.
+func ImportProjectFromFile(ctx context.Context, projectKey, filepath string) error {
+	importData, err := ReadImportFile(filepath)
 	if err != nil {
-		return errors.Wrap(err, "unable to parse JSON")
+		return err
 	}
 
 	// Validate required fields
 	if importData.SourceEnvironmentKey == "" {
 		return errors.New("sourceEnvironmentKey is required in import data")
 	}
-	if importData.FlagsState == nil {
-		return errors.New("flagsState is required in import data")
-	}
 
 	// Import the project
 	return ImportProject(ctx, projectKey, importData)
 }
+
+// ReadImportFile parses a JSON or YAML (by .yaml/.yml extension) import file, expanding any shorthand flags.
+func ReadImportFile(filePath string) (ImportData, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return ImportData{}, errors.Wrapf(err, "unable to read file %s", filePath)
+	}
+
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		// ldvalue and ldcontext only know how to unmarshal JSON, so YAML is converted before decoding.
+		var raw interface{}
+		err = yaml.Unmarshal(data, &raw)
+		if err != nil {
+			return ImportData{}, errors.Wrap(err, "unable to parse YAML")
+		}
+		data, err = json.Marshal(raw)
+		if err != nil {
+			return ImportData{}, errors.Wrap(err, "unable to convert YAML to JSON")
+		}
+	}
+
+	var importData ImportData
+	err = json.Unmarshal(data, &importData)
+	if err != nil {
+		return ImportData{}, errors.Wrap(err, "unable to parse JSON")
+	}
+
+	importData.expandFlags()
+	if importData.FlagsState == nil {
+		return ImportData{}, errors.New("flagsState is required in import data unless flags is set")
+	}
+	return importData, nil
+}
+
+// expandFlags folds the shorthand flags into FlagsState and AvailableVariations.
+func (importData *ImportData) expandFlags() {
+	if importData.Flags == nil {
+		return
+	}
+	if importData.FlagsState == nil {
+		importData.FlagsState = make(FlagsState, len(importData.Flags))
+	}
+	if importData.AvailableVariations == nil {
+		importData.AvailableVariations = &map[string][]ImportVariation{}
+	}
+	availableVariations := *importData.AvailableVariations
+	for flagKey, flag := range importData.Flags {
+		importData.FlagsState[flagKey] = FlagState{Value: flag.Value, Version: 1}
+
+		values := flag.Variations
+		if !slices.ContainsFunc(values, flag.Value.Equal) {
+			values = append([]ldvalue.Value{flag.Value}, values...)
+		}
+		variations := make([]ImportVariation, 0, len(values))
+		for i, value := range values {
+			variations = append(variations, ImportVariation{Id: strconv.Itoa(i), Value: value})
+		}
+		availableVariations[flagKey] = variations
+	}
+}
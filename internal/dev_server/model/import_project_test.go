@@ -57,8 +57,8 @@ func TestImportProject(t *testing.T) {
 				},
 			},
 		},
-		Overrides: &model.FlagsState{
-			"flag-1": model.FlagState{Value: ldvalue.Bool(false), Version: 1},
+		Overrides: &model.OverridesState{
+			"flag-1": {FlagState: model.FlagState{Value: ldvalue.Bool(false), Version: 1}},
 		},
 	}
 
@@ -159,6 +159,7 @@ func TestImportProject(t *testing.T) {
 				return override, nil
 			},
 		)
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).Return(nil, nil)
 
 		err := model.ImportProject(ctx, projectKey, seedData)
 		require.NoError(t, err)
@@ -0,0 +1,223 @@
+package model
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+)
+
+// ApplyUpstreamFlagsData mirrors a configuration streamed from the project's source environment. Overrides keep
+// precedence, and connected SDKs are sent just the flags whose state changed. Segment changes that don't change the
+// project context's flag state still send a full sync, since they can change other contexts' evaluations.
+func ApplyUpstreamFlagsData(ctx context.Context, projectKey string, flagsData adapters.FlagsData) error {
+	store := StoreFromContext(ctx)
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return err
+	}
+
+	flagsState := EvaluateFlags(flagsData, project.Context)
+	var changed []string
+	for flagKey, state := range flagsState {
+		current, ok := project.AllFlagsState[flagKey]
+		if !ok || !current.Value.Equal(state.Value) || current.Version != state.Version || current.TrackEvents != state.TrackEvents {
+			changed = append(changed, flagKey)
+		}
+	}
+	fullSync := len(flagsState) != len(project.AllFlagsState)
+	for flagKey := range project.AllFlagsState {
+		if _, ok := flagsState[flagKey]; !ok {
+			fullSync = true
+		}
+	}
+	if !segmentVersionsEqual(project.FlagsData, flagsData) {
+		fullSync = true
+	}
+	if len(changed) == 0 && !fullSync {
+		return nil
+	}
+
+	project.FlagsData = flagsData
+	return project.applyFlagsState(ctx, flagsState, changed, fullSync)
+}
+
+func segmentVersionsEqual(a, b adapters.FlagsData) bool {
+	if len(a.Segments) != len(b.Segments) {
+		return false
+	}
+	for key, segment := range a.Segments {
+		other, ok := b.Segments[key]
+		if !ok || other.Version != segment.Version {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	liveSyncInterval = 5 * time.Second
+	// maxLiveSyncBackoff caps how long a project whose stream keeps failing to start waits between attempts.
+	maxLiveSyncBackoff = 5 * time.Minute
+)
+
+// LiveSync keeps one upstream SDK stream open per dev project, so that flag changes made in LaunchDarkly reach the
+// dev server as they happen instead of on the next sync. Projects added, removed or repointed at another source
+// environment are picked up on the next reconcile.
+type LiveSync struct {
+	// RetryBackoff is how long a project waits after its stream first fails to start. It doubles with each failure
+	// in a row, up to maxLiveSyncBackoff.
+	RetryBackoff time.Duration
+
+	mu       sync.Mutex
+	streams  map[string]liveStream
+	failures map[string]liveStreamFailure
+}
+
+type liveStream struct {
+	sourceEnvironmentKey string
+	cancel               context.CancelFunc
+}
+
+// liveStreamFailure is a project whose stream failed to start, and when to try it again.
+type liveStreamFailure struct {
+	sourceEnvironmentKey string
+	attempts             int
+	retryAt              time.Time
+}
+
+func NewLiveSync() *LiveSync {
+	return &LiveSync{
+		RetryBackoff: liveSyncInterval,
+		streams:      make(map[string]liveStream),
+		failures:     make(map[string]liveStreamFailure),
+	}
+}
+
+// Run reconciles the open streams with the stored projects until ctx is done.
+func (l *LiveSync) Run(ctx context.Context) {
+	ticker := time.NewTicker(liveSyncInterval)
+	defer ticker.Stop()
+	for {
+		l.Reconcile(ctx)
+		select {
+		case <-ctx.Done():
+			l.stopAll()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile opens streams for projects that don't have one and closes streams for projects that are gone or whose
+// source environment has changed. Offline projects have nothing to stream. Projects whose stream failed to start are
+// retried with backoff. Streams are started without holding the lock, since each can take seconds to connect.
+func (l *LiveSync) Reconcile(ctx context.Context) {
+	store := StoreFromContext(ctx)
+	projectKeys, err := store.GetDevProjectKeys(ctx)
+	if err != nil {
+		log.Printf("live sync: unable to list projects: %v", err)
+		return
+	}
+
+	wanted := make(map[string]string, len(projectKeys))
+	for _, projectKey := range projectKeys {
+		project, err := store.GetDevProject(ctx, projectKey)
+		if err != nil {
+			log.Printf("live sync: unable to get project %s: %v", projectKey, err)
+			continue
+		}
+		if project.IsOffline() {
+			continue
+		}
+		wanted[projectKey] = project.SourceEnvironmentKey
+	}
+
+	now := time.Now()
+	toStart := make(map[string]string)
+	l.mu.Lock()
+	for projectKey, stream := range l.streams {
+		if sourceEnvironmentKey, ok := wanted[projectKey]; !ok || sourceEnvironmentKey != stream.sourceEnvironmentKey {
+			stream.cancel()
+			delete(l.streams, projectKey)
+		}
+	}
+	for projectKey, failure := range l.failures {
+		if sourceEnvironmentKey, ok := wanted[projectKey]; !ok || sourceEnvironmentKey != failure.sourceEnvironmentKey {
+			delete(l.failures, projectKey)
+		}
+	}
+	for projectKey, sourceEnvironmentKey := range wanted {
+		if _, ok := l.streams[projectKey]; ok {
+			continue
+		}
+		if failure, ok := l.failures[projectKey]; ok && now.Before(failure.retryAt) {
+			continue
+		}
+		toStart[projectKey] = sourceEnvironmentKey
+	}
+	l.mu.Unlock()
+
+	for projectKey, sourceEnvironmentKey := range toStart {
+		cancel, err := startLiveStream(ctx, projectKey, sourceEnvironmentKey)
+		l.mu.Lock()
+		if err != nil {
+			failure := l.failures[projectKey]
+			failure.sourceEnvironmentKey = sourceEnvironmentKey
+			failure.attempts++
+			backoff := l.backoff(failure.attempts)
+			failure.retryAt = time.Now().Add(backoff)
+			l.failures[projectKey] = failure
+			log.Printf("live sync: unable to stream project %s, retrying in %s: %v", projectKey, backoff, err)
+		} else if _, ok := l.streams[projectKey]; ok {
+			// another reconcile started the project's stream in the meantime
+			cancel()
+		} else {
+			delete(l.failures, projectKey)
+			l.streams[projectKey] = liveStream{sourceEnvironmentKey: sourceEnvironmentKey, cancel: cancel}
+		}
+		l.mu.Unlock()
+	}
+}
+
+// backoff is how long to wait after the given number of failed attempts in a row.
+func (l *LiveSync) backoff(attempts int) time.Duration {
+	backoff := l.RetryBackoff
+	for i := 1; i < attempts && backoff < maxLiveSyncBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxLiveSyncBackoff)
+}
+
+func (l *LiveSync) stopAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for projectKey, stream := range l.streams {
+		stream.cancel()
+		delete(l.streams, projectKey)
+	}
+}
+
+func startLiveStream(ctx context.Context, projectKey, sourceEnvironmentKey string) (context.CancelFunc, error) {
+	sdkKey, err := adapters.GetApi(ctx).GetSdkKey(ctx, SourceProjectKey(projectKey), sourceEnvironmentKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get sdk key")
+	}
+	streamCtx, cancel := context.WithCancel(ctx)
+	err = adapters.GetSdk(ctx).StreamFlagsData(streamCtx, sdkKey, func(flagsData adapters.FlagsData) {
+		err := ApplyUpstreamFlagsData(streamCtx, projectKey, flagsData)
+		if err != nil {
+			log.Printf("live sync: unable to apply upstream changes to project %s: %v", projectKey, err)
+		}
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	log.Printf("live sync: streaming project [%s] from env [%s]", projectKey, sourceEnvironmentKey)
+	return cancel, nil
+}
@@ -0,0 +1,186 @@
+package model_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldbuilders"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+	adapters_mocks "github.com/launchdarkly/ldcli/internal/dev_server/adapters/mocks"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func TestApplyUpstreamFlagsData(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+	observers := model.NewObservers()
+	observer := mocks.NewMockObserver(mockController)
+	observers.RegisterObserver(observer)
+	ctx = model.SetObserversOnContext(ctx, observers)
+
+	projectKey := "proj"
+	synced := adapters_mocks.FlagsDataFromValues(map[string]ldvalue.Value{
+		"flag-1": ldvalue.Bool(true),
+		"flag-2": ldvalue.String("a"),
+	}, 1)
+	project := func() *model.Project {
+		return &model.Project{
+			Key:                  projectKey,
+			SourceEnvironmentKey: "env",
+			Context:              ldcontext.New("dev-environment"),
+			FlagsData:            synced,
+			AllFlagsState:        model.EvaluateFlags(synced, ldcontext.New("dev-environment")),
+			PayloadVersion:       1,
+		}
+	}
+
+	t.Run("does nothing when nothing changed", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project(), nil)
+
+		require.NoError(t, model.ApplyUpstreamFlagsData(ctx, projectKey, synced))
+	})
+
+	t.Run("sends changed flags with overrides taking precedence", func(t *testing.T) {
+		upstream := adapters_mocks.FlagsDataFromValues(map[string]ldvalue.Value{
+			"flag-1": ldvalue.Bool(false),
+			"flag-2": ldvalue.String("b"),
+		}, 2)
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project(), nil)
+		store.EXPECT().UpdateProject(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, project model.Project) (bool, error) {
+			assert.Equal(t, upstream, project.FlagsData)
+			return true, nil
+		})
+		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projectKey).Return(2, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(2)).Return(nil, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{{
+			ProjectKey: projectKey,
+			FlagKey:    "flag-2",
+			Value:      ldvalue.String("local"),
+			Active:     true,
+			Version:    1,
+		}}, nil)
+		observer.EXPECT().Handle(model.OverrideEvent{
+			FlagKey:        "flag-1",
+			ProjectKey:     projectKey,
+			FlagState:      model.FlagState{Value: ldvalue.Bool(false), Version: 2},
+			PayloadVersion: 2,
+		})
+		observer.EXPECT().Handle(model.OverrideEvent{
+			FlagKey:        "flag-2",
+			ProjectKey:     projectKey,
+			FlagState:      model.FlagState{Value: ldvalue.String("local"), Version: 3, TrackEvents: true},
+			PayloadVersion: 2,
+		})
+
+		require.NoError(t, model.ApplyUpstreamFlagsData(ctx, projectKey, upstream))
+	})
+
+	t.Run("sends a full sync when only segments changed", func(t *testing.T) {
+		upstream := adapters_mocks.FlagsDataFromValues(map[string]ldvalue.Value{
+			"flag-1": ldvalue.Bool(true),
+			"flag-2": ldvalue.String("a"),
+		}, 1)
+		upstream.Segments = map[string]ldmodel.Segment{
+			"beta-users": ldbuilders.NewSegmentBuilder("beta-users").Version(1).Build(),
+		}
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project(), nil)
+		store.EXPECT().UpdateProject(gomock.Any(), gomock.Any()).Return(true, nil)
+		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projectKey).Return(2, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil)
+		observer.EXPECT().Handle(gomock.AssignableToTypeOf(model.SyncEvent{}))
+
+		require.NoError(t, model.ApplyUpstreamFlagsData(ctx, projectKey, upstream))
+	})
+}
+
+func TestLiveSyncReconcile(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	ctx, api, sdk := adapters_mocks.WithMockApiAndSdk(ctx, mockController)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+	ctx = model.SetObserversOnContext(ctx, model.NewObservers())
+
+	liveSync := model.NewLiveSync()
+	online := &model.Project{Key: "online", SourceEnvironmentKey: "env"}
+	offline := &model.Project{Key: "offline"}
+
+	var streamCtx context.Context
+	store.EXPECT().GetDevProjectKeys(gomock.Any()).Return([]string{"online", "offline"}, nil)
+	store.EXPECT().GetDevProject(gomock.Any(), "online").Return(online, nil)
+	store.EXPECT().GetDevProject(gomock.Any(), "offline").Return(offline, nil)
+	api.EXPECT().GetSdkKey(gomock.Any(), "online", "env").Return("sdk-key", nil)
+	sdk.EXPECT().StreamFlagsData(gomock.Any(), "sdk-key", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, sdkKey string, onChange func(adapters.FlagsData)) error {
+			streamCtx = ctx
+			return nil
+		})
+
+	liveSync.Reconcile(ctx)
+	require.NotNil(t, streamCtx)
+	assert.NoError(t, streamCtx.Err())
+
+	t.Run("keeps the stream open while the project is unchanged", func(t *testing.T) {
+		store.EXPECT().GetDevProjectKeys(gomock.Any()).Return([]string{"online"}, nil)
+		store.EXPECT().GetDevProject(gomock.Any(), "online").Return(online, nil)
+
+		liveSync.Reconcile(ctx)
+		assert.NoError(t, streamCtx.Err())
+	})
+
+	t.Run("closes the stream once the project is removed", func(t *testing.T) {
+		store.EXPECT().GetDevProjectKeys(gomock.Any()).Return([]string{}, nil)
+
+		liveSync.Reconcile(ctx)
+		assert.Error(t, streamCtx.Err())
+	})
+}
+
+func TestLiveSyncReconcileBackoff(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	ctx, api, sdk := adapters_mocks.WithMockApiAndSdk(ctx, mockController)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+
+	liveSync := model.NewLiveSync()
+	liveSync.RetryBackoff = 50 * time.Millisecond
+	project := &model.Project{Key: "online", SourceEnvironmentKey: "env"}
+	store.EXPECT().GetDevProjectKeys(gomock.Any()).Return([]string{"online"}, nil).AnyTimes()
+	store.EXPECT().GetDevProject(gomock.Any(), "online").Return(project, nil).AnyTimes()
+	api.EXPECT().GetSdkKey(gomock.Any(), "online", "env").Return("sdk-key", nil).AnyTimes()
+
+	sdk.EXPECT().StreamFlagsData(gomock.Any(), "sdk-key", gomock.Any()).Return(errors.New("initialization timed out"))
+	liveSync.Reconcile(ctx)
+
+	t.Run("doesn't retry a failed stream until its backoff is over", func(t *testing.T) {
+		liveSync.Reconcile(ctx)
+	})
+
+	t.Run("retries once the backoff is over", func(t *testing.T) {
+		time.Sleep(60 * time.Millisecond)
+		var streamCtx context.Context
+		sdk.EXPECT().StreamFlagsData(gomock.Any(), "sdk-key", gomock.Any()).DoAndReturn(
+			func(ctx context.Context, sdkKey string, onChange func(adapters.FlagsData)) error {
+				streamCtx = ctx
+				return nil
+			})
+
+		liveSync.Reconcile(ctx)
+		require.NotNil(t, streamCtx)
+		assert.NoError(t, streamCtx.Err())
+	})
+}
@@ -0,0 +1,169 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "ldcli_dev_server"
+
+// Metrics collects the dev server's Prometheus metrics. It is also an Observer, counting the updates broadcast to
+// connected SDKs. A nil *Metrics records nothing, so that code can record metrics whether or not they are enabled.
+type Metrics struct {
+	registry     *prometheus.Registry
+	openStreams  *prometheus.GaugeVec
+	broadcasts   *prometheus.CounterVec
+	sdkEvents    *prometheus.CounterVec
+	syncDuration prometheus.Histogram
+	syncFailures prometheus.Counter
+}
+
+// DatabaseSizer is a database whose size is reported in the metrics.
+type DatabaseSizer interface {
+	// Size returns the size of the database in bytes.
+	Size(ctx context.Context) (int64, error)
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		openStreams: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "open_streams",
+			Help:      "SSE streams open to SDKs, by the endpoint that serves them.",
+		}, []string{"endpoint"}),
+		broadcasts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "broadcasts_total",
+			Help:      "Events broadcast to the dev server's observers, by kind.",
+		}, []string{"event"}),
+		sdkEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "sdk_events_received_total",
+			Help:      "Analytics events received from SDKs, by event kind.",
+		}, []string{"kind"}),
+		syncDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "sync_duration_seconds",
+			Help:      "How long syncing a project from its source environment took.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+		}),
+		syncFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "sync_failures_total",
+			Help:      "Syncs of a project from its source environment that failed.",
+		}),
+	}
+	m.registry.MustRegister(
+		m.openStreams,
+		m.broadcasts,
+		m.sdkEvents,
+		m.syncDuration,
+		m.syncFailures,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// AddDatabase reports the size of the database under name, read each time the metrics are scraped.
+func (m *Metrics) AddDatabase(name string, database DatabaseSizer) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   metricsNamespace,
+		Name:        "database_size_bytes",
+		Help:        "Size of the dev server's SQLite databases.",
+		ConstLabels: prometheus.Labels{"database": name},
+	}, func() float64 {
+		size, err := database.Size(context.Background())
+		if err != nil {
+			log.Printf("metrics: unable to get the size of the %s database: %v", name, err)
+			return 0
+		}
+		return float64(size)
+	}))
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) Handle(event interface{}) {
+	if m == nil {
+		return
+	}
+	var kind string
+	switch event.(type) {
+	case OverrideEvent:
+		kind = "override"
+	case SyncEvent:
+		kind = "sync"
+	case ImportEvent:
+		kind = "import"
+	case ProjectDeletedEvent:
+		kind = "project_deleted"
+	case json.RawMessage:
+		kind = "sdk_event"
+	default:
+		return
+	}
+	m.broadcasts.WithLabelValues(kind).Inc()
+}
+
+// StreamOpened counts a stream opened by the endpoint until the returned function is called.
+func (m *Metrics) StreamOpened(endpoint string) (closed func()) {
+	if m == nil {
+		return func() {}
+	}
+	gauge := m.openStreams.WithLabelValues(endpoint)
+	gauge.Inc()
+	return gauge.Dec
+}
+
+func (m *Metrics) SDKEventReceived(kind string) {
+	if m == nil {
+		return
+	}
+	m.sdkEvents.WithLabelValues(kind).Inc()
+}
+
+// SyncFinished records a sync from a source environment that took duration and failed with err if it isn't nil.
+func (m *Metrics) SyncFinished(duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.syncDuration.Observe(duration.Seconds())
+	if err != nil {
+		m.syncFailures.Inc()
+	}
+}
+
+const ctxKeyMetrics = ctxKey("model.Metrics")
+
+func WithMetrics(ctx context.Context, metrics *Metrics) context.Context {
+	return context.WithValue(ctx, ctxKeyMetrics, metrics)
+}
+
+// MetricsFromContext returns the metrics, or nil if metrics aren't enabled.
+func MetricsFromContext(ctx context.Context) *Metrics {
+	metrics, _ := ctx.Value(ctxKeyMetrics).(*Metrics)
+	return metrics
+}
+
+// MetricsMiddleware puts the metrics on the request context.
+func MetricsMiddleware(metrics *Metrics) mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			request = request.WithContext(WithMetrics(request.Context(), metrics))
+			handler.ServeHTTP(writer, request)
+		})
+	}
+}
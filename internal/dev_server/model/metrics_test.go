@@ -0,0 +1,88 @@
+package model_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+type fixedSizeDatabase int64
+
+func (d fixedSizeDatabase) Size(context.Context) (int64, error) {
+	return int64(d), nil
+}
+
+func TestMetrics(t *testing.T) {
+	scrape := func(t *testing.T, metrics *model.Metrics) string {
+		server := httptest.NewServer(metrics.Handler())
+		defer server.Close()
+		res, err := http.Get(server.URL)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	t.Run("counts broadcasts by kind", func(t *testing.T) {
+		metrics := model.NewMetrics()
+		metrics.Handle(model.OverrideEvent{})
+		metrics.Handle(model.OverrideEvent{})
+		metrics.Handle(model.SyncEvent{})
+		metrics.Handle(json.RawMessage(`{"kind":"custom"}`))
+
+		body := scrape(t, metrics)
+		assert.Contains(t, body, `ldcli_dev_server_broadcasts_total{event="override"} 2`)
+		assert.Contains(t, body, `ldcli_dev_server_broadcasts_total{event="sync"} 1`)
+		assert.Contains(t, body, `ldcli_dev_server_broadcasts_total{event="sdk_event"} 1`)
+	})
+
+	t.Run("counts streams while they're open", func(t *testing.T) {
+		metrics := model.NewMetrics()
+		closeFirst := metrics.StreamOpened("StreamClientFlags")
+		metrics.StreamOpened("StreamClientFlags")
+		closeFirst()
+
+		assert.Contains(t, scrape(t, metrics), `ldcli_dev_server_open_streams{endpoint="StreamClientFlags"} 1`)
+	})
+
+	t.Run("records syncs and their failures", func(t *testing.T) {
+		metrics := model.NewMetrics()
+		metrics.SyncFinished(time.Second, nil)
+		metrics.SyncFinished(time.Second, errors.New("unavailable"))
+
+		body := scrape(t, metrics)
+		assert.Contains(t, body, `ldcli_dev_server_sync_duration_seconds_count 2`)
+		assert.Contains(t, body, `ldcli_dev_server_sync_failures_total 1`)
+	})
+
+	t.Run("reports SDK events and database sizes", func(t *testing.T) {
+		metrics := model.NewMetrics()
+		metrics.SDKEventReceived("feature")
+		metrics.AddDatabase("store", fixedSizeDatabase(4096))
+
+		body := scrape(t, metrics)
+		assert.Contains(t, body, `ldcli_dev_server_sdk_events_received_total{kind="feature"} 1`)
+		assert.Contains(t, body, `ldcli_dev_server_database_size_bytes{database="store"} 4096`)
+	})
+
+	t.Run("disabled metrics record nothing", func(t *testing.T) {
+		metrics := model.MetricsFromContext(context.Background())
+		require.Nil(t, metrics)
+
+		metrics.Handle(model.OverrideEvent{})
+		metrics.StreamOpened("StreamClientFlags")()
+		metrics.SDKEventReceived("feature")
+		metrics.SyncFinished(time.Second, nil)
+	})
+}
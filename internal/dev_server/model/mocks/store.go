@@ -13,7 +13,9 @@ import (
 	context "context"
 	io "io"
 	reflect "reflect"
+	time "time"
 
+	ldvalue "github.com/launchdarkly/go-sdk-common/v3/ldvalue"
 	model "github.com/launchdarkly/ldcli/internal/dev_server/model"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,6 +44,21 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AppendHistory mocks base method.
+func (m *MockStore) AppendHistory(ctx context.Context, projectKey string, entries []model.HistoryEntry) ([]model.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendHistory", ctx, projectKey, entries)
+	ret0, _ := ret[0].([]model.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendHistory indicates an expected call of AppendHistory.
+func (mr *MockStoreMockRecorder) AppendHistory(ctx, projectKey, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendHistory", reflect.TypeOf((*MockStore)(nil).AppendHistory), ctx, projectKey, entries)
+}
+
 // CreateBackup mocks base method.
 func (m *MockStore) CreateBackup(ctx context.Context) (io.ReadCloser, int64, error) {
 	m.ctrl.T.Helper()
@@ -88,6 +105,66 @@ func (mr *MockStoreMockRecorder) DeleteDevProject(ctx, projectKey any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDevProject", reflect.TypeOf((*MockStore)(nil).DeleteDevProject), ctx, projectKey)
 }
 
+// DeleteDevProjects mocks base method.
+func (m *MockStore) DeleteDevProjects(ctx context.Context, projectKeys []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDevProjects", ctx, projectKeys)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDevProjects indicates an expected call of DeleteDevProjects.
+func (mr *MockStoreMockRecorder) DeleteDevProjects(ctx, projectKeys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDevProjects", reflect.TypeOf((*MockStore)(nil).DeleteDevProjects), ctx, projectKeys)
+}
+
+// DeleteScenario mocks base method.
+func (m *MockStore) DeleteScenario(ctx context.Context, projectKey, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScenario", ctx, projectKey, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteScenario indicates an expected call of DeleteScenario.
+func (mr *MockStoreMockRecorder) DeleteScenario(ctx, projectKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScenario", reflect.TypeOf((*MockStore)(nil).DeleteScenario), ctx, projectKey, name)
+}
+
+// DeleteSnapshot mocks base method.
+func (m *MockStore) DeleteSnapshot(ctx context.Context, projectKey, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSnapshot", ctx, projectKey, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSnapshot indicates an expected call of DeleteSnapshot.
+func (mr *MockStoreMockRecorder) DeleteSnapshot(ctx, projectKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSnapshot", reflect.TypeOf((*MockStore)(nil).DeleteSnapshot), ctx, projectKey, name)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockStore) DeleteWebhook(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockStoreMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockStore)(nil).DeleteWebhook), ctx, id)
+}
+
 // GetAvailableVariationsForProject mocks base method.
 func (m *MockStore) GetAvailableVariationsForProject(ctx context.Context, projectKey string) (map[string][]model.Variation, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +195,21 @@ func (mr *MockStoreMockRecorder) GetDevProject(ctx, projectKey any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevProject", reflect.TypeOf((*MockStore)(nil).GetDevProject), ctx, projectKey)
 }
 
+// GetDevProjectKeyForCredential mocks base method.
+func (m *MockStore) GetDevProjectKeyForCredential(ctx context.Context, credential string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevProjectKeyForCredential", ctx, credential)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevProjectKeyForCredential indicates an expected call of GetDevProjectKeyForCredential.
+func (mr *MockStoreMockRecorder) GetDevProjectKeyForCredential(ctx, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevProjectKeyForCredential", reflect.TypeOf((*MockStore)(nil).GetDevProjectKeyForCredential), ctx, credential)
+}
+
 // GetDevProjectKeys mocks base method.
 func (m *MockStore) GetDevProjectKeys(ctx context.Context) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +225,51 @@ func (mr *MockStoreMockRecorder) GetDevProjectKeys(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevProjectKeys", reflect.TypeOf((*MockStore)(nil).GetDevProjectKeys), ctx)
 }
 
+// GetDevProjectKeysWithPrefix mocks base method.
+func (m *MockStore) GetDevProjectKeysWithPrefix(ctx context.Context, prefix string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevProjectKeysWithPrefix", ctx, prefix)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevProjectKeysWithPrefix indicates an expected call of GetDevProjectKeysWithPrefix.
+func (mr *MockStoreMockRecorder) GetDevProjectKeysWithPrefix(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevProjectKeysWithPrefix", reflect.TypeOf((*MockStore)(nil).GetDevProjectKeysWithPrefix), ctx, prefix)
+}
+
+// GetExpiredOverrides mocks base method.
+func (m *MockStore) GetExpiredOverrides(ctx context.Context, now time.Time) (model.Overrides, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredOverrides", ctx, now)
+	ret0, _ := ret[0].(model.Overrides)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredOverrides indicates an expected call of GetExpiredOverrides.
+func (mr *MockStoreMockRecorder) GetExpiredOverrides(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredOverrides", reflect.TypeOf((*MockStore)(nil).GetExpiredOverrides), ctx, now)
+}
+
+// GetHistory mocks base method.
+func (m *MockStore) GetHistory(ctx context.Context, projectKey string) ([]model.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, projectKey)
+	ret0, _ := ret[0].([]model.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockStoreMockRecorder) GetHistory(ctx, projectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockStore)(nil).GetHistory), ctx, projectKey)
+}
+
 // GetOverridesForProject mocks base method.
 func (m *MockStore) GetOverridesForProject(ctx context.Context, projectKey string) (model.Overrides, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +285,81 @@ func (mr *MockStoreMockRecorder) GetOverridesForProject(ctx, projectKey any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverridesForProject", reflect.TypeOf((*MockStore)(nil).GetOverridesForProject), ctx, projectKey)
 }
 
+// GetScenario mocks base method.
+func (m *MockStore) GetScenario(ctx context.Context, projectKey, name string) (*model.Scenario, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScenario", ctx, projectKey, name)
+	ret0, _ := ret[0].(*model.Scenario)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScenario indicates an expected call of GetScenario.
+func (mr *MockStoreMockRecorder) GetScenario(ctx, projectKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScenario", reflect.TypeOf((*MockStore)(nil).GetScenario), ctx, projectKey, name)
+}
+
+// GetScenariosForProject mocks base method.
+func (m *MockStore) GetScenariosForProject(ctx context.Context, projectKey string) ([]model.Scenario, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScenariosForProject", ctx, projectKey)
+	ret0, _ := ret[0].([]model.Scenario)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScenariosForProject indicates an expected call of GetScenariosForProject.
+func (mr *MockStoreMockRecorder) GetScenariosForProject(ctx, projectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScenariosForProject", reflect.TypeOf((*MockStore)(nil).GetScenariosForProject), ctx, projectKey)
+}
+
+// GetSnapshot mocks base method.
+func (m *MockStore) GetSnapshot(ctx context.Context, projectKey, name string) (*model.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshot", ctx, projectKey, name)
+	ret0, _ := ret[0].(*model.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshot indicates an expected call of GetSnapshot.
+func (mr *MockStoreMockRecorder) GetSnapshot(ctx, projectKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshot", reflect.TypeOf((*MockStore)(nil).GetSnapshot), ctx, projectKey, name)
+}
+
+// GetSnapshotsForProject mocks base method.
+func (m *MockStore) GetSnapshotsForProject(ctx context.Context, projectKey string) ([]model.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshotsForProject", ctx, projectKey)
+	ret0, _ := ret[0].([]model.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshotsForProject indicates an expected call of GetSnapshotsForProject.
+func (mr *MockStoreMockRecorder) GetSnapshotsForProject(ctx, projectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshotsForProject", reflect.TypeOf((*MockStore)(nil).GetSnapshotsForProject), ctx, projectKey)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockStore) GetWebhooks(ctx context.Context, projectKey string) ([]model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx, projectKey)
+	ret0, _ := ret[0].([]model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockStoreMockRecorder) GetWebhooks(ctx, projectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockStore)(nil).GetWebhooks), ctx, projectKey)
+}
+
 // IncrementProjectPayloadVersion mocks base method.
 func (m *MockStore) IncrementProjectPayloadVersion(ctx context.Context, projectKey string) (int, error) {
 	m.ctrl.T.Helper()
@@ -177,6 +389,49 @@ func (mr *MockStoreMockRecorder) InsertProject(ctx, project any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProject", reflect.TypeOf((*MockStore)(nil).InsertProject), ctx, project)
 }
 
+// InsertSnapshot mocks base method.
+func (m *MockStore) InsertSnapshot(ctx context.Context, snapshot model.Snapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSnapshot", ctx, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertSnapshot indicates an expected call of InsertSnapshot.
+func (mr *MockStoreMockRecorder) InsertSnapshot(ctx, snapshot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSnapshot", reflect.TypeOf((*MockStore)(nil).InsertSnapshot), ctx, snapshot)
+}
+
+// InsertWebhook mocks base method.
+func (m *MockStore) InsertWebhook(ctx context.Context, webhook model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWebhook", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWebhook indicates an expected call of InsertWebhook.
+func (mr *MockStoreMockRecorder) InsertWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWebhook", reflect.TypeOf((*MockStore)(nil).InsertWebhook), ctx, webhook)
+}
+
+// ReplaceOverrides mocks base method.
+func (m *MockStore) ReplaceOverrides(ctx context.Context, projectKey string, values map[string]ldvalue.Value) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceOverrides", ctx, projectKey, values)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceOverrides indicates an expected call of ReplaceOverrides.
+func (mr *MockStoreMockRecorder) ReplaceOverrides(ctx, projectKey, values any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceOverrides", reflect.TypeOf((*MockStore)(nil).ReplaceOverrides), ctx, projectKey, values)
+}
+
 // RestoreBackup mocks base method.
 func (m *MockStore) RestoreBackup(ctx context.Context, stream io.Reader) (string, error) {
 	m.ctrl.T.Helper()
@@ -192,6 +447,21 @@ func (mr *MockStoreMockRecorder) RestoreBackup(ctx, stream any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBackup", reflect.TypeOf((*MockStore)(nil).RestoreBackup), ctx, stream)
 }
 
+// RestoreSnapshot mocks base method.
+func (m *MockStore) RestoreSnapshot(ctx context.Context, snapshot model.Snapshot) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSnapshot", ctx, snapshot)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreSnapshot indicates an expected call of RestoreSnapshot.
+func (mr *MockStoreMockRecorder) RestoreSnapshot(ctx, snapshot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSnapshot", reflect.TypeOf((*MockStore)(nil).RestoreSnapshot), ctx, snapshot)
+}
+
 // SetAvailableVariationsForProject mocks base method.
 func (m *MockStore) SetAvailableVariationsForProject(ctx context.Context, projectKey string, variations []model.FlagVariation) error {
 	m.ctrl.T.Helper()
@@ -206,6 +476,21 @@ func (mr *MockStoreMockRecorder) SetAvailableVariationsForProject(ctx, projectKe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvailableVariationsForProject", reflect.TypeOf((*MockStore)(nil).SetAvailableVariationsForProject), ctx, projectKey, variations)
 }
 
+// SetOverrides mocks base method.
+func (m *MockStore) SetOverrides(ctx context.Context, projectKey string, overrides model.Overrides) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOverrides", ctx, projectKey, overrides)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOverrides indicates an expected call of SetOverrides.
+func (mr *MockStoreMockRecorder) SetOverrides(ctx, projectKey, overrides any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOverrides", reflect.TypeOf((*MockStore)(nil).SetOverrides), ctx, projectKey, overrides)
+}
+
 // UpdateProject mocks base method.
 func (m *MockStore) UpdateProject(ctx context.Context, project model.Project) (bool, error) {
 	m.ctrl.T.Helper()
@@ -221,6 +506,21 @@ func (mr *MockStoreMockRecorder) UpdateProject(ctx, project any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockStore)(nil).UpdateProject), ctx, project)
 }
 
+// UpdateProjects mocks base method.
+func (m *MockStore) UpdateProjects(ctx context.Context, projects []model.Project) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProjects", ctx, projects)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProjects indicates an expected call of UpdateProjects.
+func (mr *MockStoreMockRecorder) UpdateProjects(ctx, projects any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjects", reflect.TypeOf((*MockStore)(nil).UpdateProjects), ctx, projects)
+}
+
 // UpsertOverride mocks base method.
 func (m *MockStore) UpsertOverride(ctx context.Context, override model.Override) (model.Override, error) {
 	m.ctrl.T.Helper()
@@ -235,3 +535,17 @@ func (mr *MockStoreMockRecorder) UpsertOverride(ctx, override any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOverride", reflect.TypeOf((*MockStore)(nil).UpsertOverride), ctx, override)
 }
+
+// UpsertScenario mocks base method.
+func (m *MockStore) UpsertScenario(ctx context.Context, scenario model.Scenario) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertScenario", ctx, scenario)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertScenario indicates an expected call of UpsertScenario.
+func (mr *MockStoreMockRecorder) UpsertScenario(ctx, scenario any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertScenario", reflect.TypeOf((*MockStore)(nil).UpsertScenario), ctx, scenario)
+}
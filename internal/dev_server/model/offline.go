@@ -0,0 +1,93 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+)
+
+type ErrOffline struct {
+	projectKey string
+}
+
+func (e ErrOffline) Error() string {
+	return fmt.Sprintf("project %s is in offline mode: it was seeded from a local flag file and has no source "+
+		"environment, so it can't be synced from LaunchDarkly and doesn't need an access token", e.projectKey)
+}
+
+func NewErrOffline(projectKey string) ErrOffline {
+	return ErrOffline{projectKey: projectKey}
+}
+
+// IsOffline reports whether the project was seeded from a flag file rather than a LaunchDarkly environment.
+func (project Project) IsOffline() bool {
+	return project.SourceEnvironmentKey == ""
+}
+
+// SeedProjectFromFile creates or replaces a project's flags from a JSON or YAML flag file without calling
+// LaunchDarkly. Overrides on an existing project are kept.
+func SeedProjectFromFile(ctx context.Context, projectKey, filePath string) (Project, error) {
+	importData, err := ReadImportFile(filePath)
+	if err != nil {
+		return Project{}, err
+	}
+	if !importData.Context.IsDefined() {
+		importData.Context = defaultContext()
+	}
+
+	store := StoreFromContext(ctx)
+	existing, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		if !errors.As(err, &ErrNotFound{}) {
+			return Project{}, errors.Wrap(err, "unable to check if project exists")
+		}
+		err = ImportProject(ctx, projectKey, importData)
+		if err != nil {
+			return Project{}, err
+		}
+		project, err := store.GetDevProject(ctx, projectKey)
+		if err != nil {
+			return Project{}, err
+		}
+		if err := NotifyProjectImported(ctx, *project); err != nil {
+			return Project{}, err
+		}
+		return *project, nil
+	}
+
+	project := *existing
+	project.SourceEnvironmentKey = importData.SourceEnvironmentKey
+	project.Context = importData.Context
+	project.AllFlagsState = importData.FlagsState
+	project.FlagsData = adapters.FlagsData{}
+	project.AvailableVariations = importData.flagVariations()
+	project.LastSyncTime = time.Now()
+
+	updated, err := store.UpdateProject(ctx, project)
+	if err != nil {
+		return Project{}, err
+	}
+	if !updated {
+		return Project{}, errors.New("Project not updated")
+	}
+	project.PayloadVersion, err = store.IncrementProjectPayloadVersion(ctx, projectKey)
+	if err != nil {
+		return Project{}, errors.Wrap(err, "unable to increment payload version")
+	}
+	recordHistory(ctx, projectKey, syncHistory(existing.AllFlagsState, project.AllFlagsState)...)
+
+	allFlagsWithOverrides, err := project.GetFlagStateWithOverridesForProject(ctx)
+	if err != nil {
+		return Project{}, errors.Wrapf(err, "unable to get overrides for project, %s", projectKey)
+	}
+	GetObserversFromContext(ctx).Notify(SyncEvent{
+		ProjectKey:     project.Key,
+		AllFlagsState:  allFlagsWithOverrides,
+		PayloadVersion: project.PayloadVersion,
+	})
+	return project, nil
+}
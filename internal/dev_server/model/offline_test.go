@@ -0,0 +1,138 @@
+package model_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+const offlineFlagFile = `
+flags:
+  new-checkout:
+    value: true
+    variations: [true, false]
+  banner:
+    value: welcome
+`
+
+func writeFlagFile(t *testing.T, name, contents string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func TestReadImportFile(t *testing.T) {
+	t.Run("expands shorthand flags from YAML", func(t *testing.T) {
+		importData, err := model.ReadImportFile(writeFlagFile(t, "flags.yaml", offlineFlagFile))
+		require.NoError(t, err)
+
+		assert.Equal(t, model.FlagsState{
+			"new-checkout": {Value: ldvalue.Bool(true), Version: 1},
+			"banner":       {Value: ldvalue.String("welcome"), Version: 1},
+		}, importData.FlagsState)
+		require.NotNil(t, importData.AvailableVariations)
+		assert.Equal(t, []model.ImportVariation{
+			{Id: "0", Value: ldvalue.Bool(true)},
+			{Id: "1", Value: ldvalue.Bool(false)},
+		}, (*importData.AvailableVariations)["new-checkout"])
+		assert.Equal(t, []model.ImportVariation{
+			{Id: "0", Value: ldvalue.String("welcome")},
+		}, (*importData.AvailableVariations)["banner"])
+	})
+
+	t.Run("returns error without flags or flagsState", func(t *testing.T) {
+		_, err := model.ReadImportFile(writeFlagFile(t, "flags.json", `{"sourceEnvironmentKey": "test"}`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "flagsState is required")
+	})
+}
+
+func TestSeedProjectFromFile(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+	observers := model.NewObservers()
+	ctx = model.SetObserversOnContext(ctx, observers)
+
+	projectKey := "offline-proj"
+	path := writeFlagFile(t, "flags.yaml", offlineFlagFile)
+
+	t.Run("imports a new project", func(t *testing.T) {
+		var inserted model.Project
+		gomock.InOrder(
+			store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(nil, model.NewErrNotFound("project", projectKey)),
+			store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(nil, model.NewErrNotFound("project", projectKey)),
+			store.EXPECT().InsertProject(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, project model.Project) error {
+				inserted = project
+				return nil
+			}),
+			store.EXPECT().GetDevProject(gomock.Any(), projectKey).DoAndReturn(func(ctx context.Context, projectKey string) (*model.Project, error) {
+				return &inserted, nil
+			}),
+			store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil),
+		)
+
+		project, err := model.SeedProjectFromFile(ctx, projectKey, path)
+		require.NoError(t, err)
+		assert.True(t, project.IsOffline())
+		assert.Equal(t, "dev-environment", project.Context.Key())
+		assert.Len(t, project.AllFlagsState, 2)
+		assert.Len(t, project.AvailableVariations, 3)
+	})
+
+	t.Run("replaces the flags of an existing project and notifies observers", func(t *testing.T) {
+		observer := mocks.NewMockObserver(mockController)
+		observers.RegisterObserver(observer)
+
+		existing := model.Project{
+			Key:            projectKey,
+			AllFlagsState:  model.FlagsState{"stale-flag": {Value: ldvalue.Bool(true), Version: 1}},
+			PayloadVersion: 1,
+		}
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(&existing, nil)
+		store.EXPECT().UpdateProject(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, project model.Project) (bool, error) {
+			assert.NotContains(t, project.AllFlagsState, "stale-flag")
+			assert.Len(t, project.AllFlagsState, 2)
+			return true, nil
+		})
+		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projectKey).Return(2, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(3)).Return(nil, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil)
+		observer.EXPECT().Handle(model.SyncEvent{
+			ProjectKey: projectKey,
+			AllFlagsState: model.FlagsState{
+				"new-checkout": {Value: ldvalue.Bool(true), Version: 1},
+				"banner":       {Value: ldvalue.String("welcome"), Version: 1},
+			},
+			PayloadVersion: 2,
+		})
+
+		project, err := model.SeedProjectFromFile(ctx, projectKey, path)
+		require.NoError(t, err)
+		assert.Equal(t, 2, project.PayloadVersion)
+	})
+}
+
+func TestOfflineProjectCannotSync(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+
+	store.EXPECT().GetDevProject(gomock.Any(), "offline-proj").Return(&model.Project{Key: "offline-proj"}, nil)
+
+	_, err := model.UpdateProject(ctx, "offline-proj", nil, nil)
+	require.Error(t, err)
+	assert.ErrorAs(t, err, &model.ErrOffline{})
+	assert.Contains(t, err.Error(), "offline mode")
+}
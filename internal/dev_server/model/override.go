@@ -2,29 +2,75 @@ package model
 
 import (
 	"context"
+	"log"
+	"slices"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	ldeval "github.com/launchdarkly/go-server-sdk-evaluation/v3"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 )
 
+// overrideRuleID identifies the flag rule that serves an override with a context matcher to server-side SDKs.
+const overrideRuleID = "dev-server-override"
+
 type Override struct {
 	ProjectKey string
 	FlagKey    string
 	Value      ldvalue.Value
 	Active     bool
 	Version    int
+	// ExpiresAt is when the override reaper deactivates the override. The zero value means it never expires.
+	ExpiresAt time.Time
+	// ContextMatcher limits the override to matching contexts. A nil matcher applies the override to every context.
+	ContextMatcher *ContextMatcher
+}
+
+// OverrideOptions are the optional settings of an override.
+type OverrideOptions struct {
+	ExpiresAt      time.Time
+	ContextMatcher *ContextMatcher
+}
+
+// OverrideState is an active override as shown on a project, with its expiry and context matcher if it has them.
+type OverrideState struct {
+	FlagState
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// ExpiresIn is the time left until the override expires, e.g. "1h59m30s".
+	ExpiresIn      string `json:"expiresIn,omitempty"`
+	ContextMatcher string `json:"contextMatcher,omitempty"`
+}
+
+type OverridesState map[string]OverrideState
+
+func (s OverrideState) options() (OverrideOptions, error) {
+	var options OverrideOptions
+	if s.ExpiresAt != nil {
+		options.ExpiresAt = *s.ExpiresAt
+	}
+	if s.ContextMatcher != "" {
+		matcher, err := ParseContextMatcher(s.ContextMatcher)
+		if err != nil {
+			return OverrideOptions{}, err
+		}
+		options.ContextMatcher = &matcher
+	}
+	return options, nil
 }
 
 // getFlagStateForFlagAndProject fetches state from the store so that it can later be used to apply an override and
 // construct an update. You want to call this before you write the override so that written overrides don't
 // less often don't cause updates.
-func getFlagStateForFlagAndProject(ctx context.Context, projectKey, flagKey string) (FlagState, error) {
+func getFlagStateForFlagAndProject(ctx context.Context, projectKey, flagKey string) (*Project, FlagState, error) {
 	store := StoreFromContext(ctx)
 
 	project, err := store.GetDevProject(ctx, projectKey)
 	if err != nil {
-		return FlagState{}, err
+		return nil, FlagState{}, err
 	}
 
 	var flagExists bool
@@ -35,16 +81,38 @@ func getFlagStateForFlagAndProject(ctx context.Context, projectKey, flagKey stri
 		}
 	}
 	if !flagExists {
-		return FlagState{}, NewErrNotFound("flag", flagKey)
+		return nil, FlagState{}, NewErrNotFound("flag", flagKey)
 	}
-	return project.AllFlagsState[flagKey], nil
+	return project, project.AllFlagsState[flagKey], nil
 }
 
 func UpsertOverride(ctx context.Context, projectKey, flagKey string, value ldvalue.Value) (Override, error) {
-	flagState, err := getFlagStateForFlagAndProject(ctx, projectKey, flagKey)
+	return UpsertOverrideWithOptions(ctx, projectKey, flagKey, value, OverrideOptions{})
+}
+
+// UpsertOverrideWithOptions overrides the flag with the given options, replacing any options the override had. An
+// override with an expiry is reverted by the override reaper once it expires, and one with a context matcher is only
+// served to matching contexts.
+func UpsertOverrideWithOptions(ctx context.Context, projectKey, flagKey string, value ldvalue.Value, options OverrideOptions) (Override, error) {
+	override, entry, err := upsertOverride(ctx, projectKey, flagKey, value, options)
 	if err != nil {
 		return Override{}, err
 	}
+	recordHistory(ctx, projectKey, entry)
+	return override, nil
+}
+
+// upsertOverride overrides the flag and notifies observers, returning the history entry of the change for the caller
+// to record.
+func upsertOverride(ctx context.Context, projectKey, flagKey string, value ldvalue.Value, options OverrideOptions) (Override, HistoryEntry, error) {
+	project, flagState, err := getFlagStateForFlagAndProject(ctx, projectKey, flagKey)
+	if err != nil {
+		return Override{}, HistoryEntry{}, err
+	}
+	oldValue, oldOptions, err := activeOverride(ctx, projectKey, flagKey)
+	if err != nil {
+		return Override{}, HistoryEntry{}, err
+	}
 
 	override := Override{
 		ProjectKey: projectKey,
@@ -52,42 +120,89 @@ func UpsertOverride(ctx context.Context, projectKey, flagKey string, value ldval
 		Value:      value,
 		Active:     true,
 		Version:    1,
+
+		ExpiresAt:      options.ExpiresAt,
+		ContextMatcher: options.ContextMatcher,
 	}
 
 	store := StoreFromContext(ctx)
 	override, err = store.UpsertOverride(ctx, override)
 	if err != nil {
-		return Override{}, err
+		return Override{}, HistoryEntry{}, err
 	}
 
 	newPayloadVersion, err := store.IncrementProjectPayloadVersion(ctx, projectKey)
 	if err != nil {
-		return Override{}, errors.Wrap(err, "unable to increment payload version")
+		return Override{}, HistoryEntry{}, errors.Wrap(err, "unable to increment payload version")
+	}
+	if override.ContextMatcher != nil {
+		// Scoped overrides are evaluated against the project's other overrides.
+		flagsState, err := project.applyOverrides(ctx, FlagsState{flagKey: flagState}, project.Context)
+		if err != nil {
+			return Override{}, HistoryEntry{}, err
+		}
+		flagState = flagsState[flagKey]
+	} else {
+		flagState = override.Apply(flagState)
 	}
 
 	GetObserversFromContext(ctx).Notify(OverrideEvent{
 		FlagKey:        flagKey,
 		ProjectKey:     projectKey,
-		FlagState:      override.Apply(flagState),
+		FlagState:      flagState,
 		PayloadVersion: newPayloadVersion,
 	})
-	return override, nil
+	return override, HistoryEntry{
+		FlagKey:    flagKey,
+		Action:     HistoryActionOverride,
+		OldValue:   oldValue,
+		NewValue:   valuePtr(value),
+		OldOptions: oldOptions,
+		NewOptions: options,
+	}, nil
+}
+
+// activeOverride returns the value and options of the flag's active override, or a nil value if it isn't overridden.
+func activeOverride(ctx context.Context, projectKey, flagKey string) (*ldvalue.Value, OverrideOptions, error) {
+	overrides, err := StoreFromContext(ctx).GetOverridesForProject(ctx, projectKey)
+	if err != nil {
+		return nil, OverrideOptions{}, errors.Wrapf(err, "unable to fetch overrides for project %s", projectKey)
+	}
+	if override, ok := overrides.GetFlag(flagKey); ok && override.Active {
+		return valuePtr(override.Value), override.options(), nil
+	}
+	return nil, OverrideOptions{}, nil
 }
 
 func DeleteOverride(ctx context.Context, projectKey, flagKey string) error {
-	flagState, err := getFlagStateForFlagAndProject(ctx, projectKey, flagKey)
+	entry, err := deactivateOverride(ctx, projectKey, flagKey)
 	if err != nil {
 		return err
 	}
+	recordHistory(ctx, projectKey, entry)
+	return nil
+}
+
+// deactivateOverride deactivates the flag's override and notifies observers, returning the history entry of the
+// change for the caller to record.
+func deactivateOverride(ctx context.Context, projectKey, flagKey string) (HistoryEntry, error) {
+	_, flagState, err := getFlagStateForFlagAndProject(ctx, projectKey, flagKey)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	oldValue, oldOptions, err := activeOverride(ctx, projectKey, flagKey)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
 	store := StoreFromContext(ctx)
 	version, err := store.DeactivateOverride(ctx, projectKey, flagKey)
 	if err != nil {
-		return err
+		return HistoryEntry{}, err
 	}
 
 	newPayloadVersion, err := store.IncrementProjectPayloadVersion(ctx, projectKey)
 	if err != nil {
-		return errors.Wrap(err, "unable to increment payload version")
+		return HistoryEntry{}, errors.Wrap(err, "unable to increment payload version")
 	}
 
 	override := Override{
@@ -103,9 +218,16 @@ func DeleteOverride(ctx context.Context, projectKey, flagKey string) error {
 		FlagState:      override.Apply(flagState),
 		PayloadVersion: newPayloadVersion,
 	})
-	return err
+	return HistoryEntry{
+		FlagKey:    flagKey,
+		Action:     HistoryActionDeactivate,
+		OldValue:   oldValue,
+		OldOptions: oldOptions,
+	}, nil
 }
 
+// DeleteOverrides deactivates the project's overrides, recording them as a single change so that they're undone
+// together.
 func DeleteOverrides(ctx context.Context, projectKey string) error {
 
 	store := StoreFromContext(ctx)
@@ -114,16 +236,27 @@ func DeleteOverrides(ctx context.Context, projectKey string) error {
 		return err
 	}
 
+	var entries []HistoryEntry
+	defer func() { recordHistory(ctx, projectKey, entries...) }()
 	for _, override := range overrides {
-		err := DeleteOverride(ctx, projectKey, override.FlagKey)
+		entry, err := deactivateOverride(ctx, projectKey, override.FlagKey)
 		if err != nil {
 			return err
 		}
+		entries = append(entries, entry)
 	}
 
 	return nil
 }
 
+func (o OverrideOptions) equal(other OverrideOptions) bool {
+	return o.ExpiresAt.Equal(other.ExpiresAt) && o.ContextMatcher.equal(other.ContextMatcher)
+}
+
+func (o Override) options() OverrideOptions {
+	return OverrideOptions{ExpiresAt: o.ExpiresAt, ContextMatcher: o.ContextMatcher}
+}
+
 func (o Override) Apply(state FlagState) FlagState {
 	flagVersion := state.Version + o.Version
 	flagValue := state.Value
@@ -137,6 +270,73 @@ func (o Override) Apply(state FlagState) FlagState {
 	}
 }
 
+// ApplyForContext applies the override to the flag state evaluated for ldCtx. An override with a context matcher is
+// evaluated from withOverrides, the flag configuration served to server-side SDKs, so that client-side SDKs see the
+// same value: prerequisites and individual targets take precedence over it, and contexts that it doesn't match keep
+// their evaluated value. Every context gets the override's version so that changes to it reach them.
+func (o Override) ApplyForContext(state FlagState, ldCtx ldcontext.Context, withOverrides adapters.FlagsData) FlagState {
+	if !o.Active || o.ContextMatcher == nil {
+		return o.Apply(state)
+	}
+	flag, ok := withOverrides.Flags[o.FlagKey]
+	if !ok {
+		if !o.ContextMatcher.Matches(ldCtx) {
+			o.Active = false
+		}
+		return o.Apply(state)
+	}
+	result := ldeval.NewEvaluator(flagsDataProvider(withOverrides)).Evaluate(&flag, ldCtx, nil)
+	return FlagState{
+		Value:       result.Detail.Value,
+		Version:     state.Version + o.Version,
+		TrackEvents: result.Detail.Reason.GetRuleID() == overrideRuleID,
+	}
+}
+
+// ApplyToFlag forces an active override on as the flag's only outcome by serving its value as the fallthrough
+// variation, adding the value as a new variation if it isn't one of the flag's own. An override with a context matcher
+// is instead added as the flag's first rule, so prerequisites and individual targets still take precedence over it.
+func (o Override) ApplyToFlag(flag ldmodel.FeatureFlag) ldmodel.FeatureFlag {
+	flag.Version += o.Version
+	if !o.Active {
+		return flag
+	}
+	variation := slices.IndexFunc(flag.Variations, o.Value.Equal)
+	if variation == -1 {
+		flag.Variations = append(slices.Clone(flag.Variations), o.Value)
+		variation = len(flag.Variations) - 1
+	}
+	if o.ContextMatcher != nil {
+		if !flag.On {
+			// Serve the off variation to everyone else, as the flag would have while off.
+			flag.On = true
+			flag.Prerequisites = nil
+			flag.Targets = nil
+			flag.ContextTargets = nil
+			flag.Rules = nil
+			flag.Fallthrough = ldmodel.VariationOrRollout{Variation: flag.OffVariation}
+		}
+		rule := ldmodel.FlagRule{
+			ID:                 overrideRuleID,
+			Clauses:            []ldmodel.Clause{o.ContextMatcher.clause()},
+			VariationOrRollout: ldmodel.VariationOrRollout{Variation: ldvalue.NewOptionalInt(variation)},
+			TrackEvents:        true,
+		}
+		flag.Rules = append([]ldmodel.FlagRule{rule}, flag.Rules...)
+		ldmodel.PreprocessFlag(&flag)
+		return flag
+	}
+	flag.On = true
+	flag.Prerequisites = nil
+	flag.Targets = nil
+	flag.ContextTargets = nil
+	flag.Rules = nil
+	flag.Fallthrough = ldmodel.VariationOrRollout{Variation: ldvalue.NewOptionalInt(variation)}
+	flag.TrackEvents = true
+	flag.TrackEventsFallthrough = true
+	return flag
+}
+
 type Overrides []Override
 
 func (o Overrides) GetFlag(key string) (Override, bool) {
@@ -147,3 +347,39 @@ func (o Overrides) GetFlag(key string) (Override, bool) {
 	}
 	return Override{}, false
 }
+
+const overrideReaperInterval = time.Second
+
+// ReapExpiredOverrides deactivates every active override that expired by now, notifying connected SDKs of the
+// reverted values. Overrides that can't be removed are logged and retried on the next call.
+func ReapExpiredOverrides(ctx context.Context, now time.Time) error {
+	overrides, err := StoreFromContext(ctx).GetExpiredOverrides(ctx, now)
+	if err != nil {
+		return errors.Wrap(err, "unable to fetch expired overrides")
+	}
+	for _, override := range overrides {
+		err = DeleteOverride(ctx, override.ProjectKey, override.FlagKey)
+		if err != nil {
+			log.Printf("unable to remove expired override for flag %s in project %s: %v", override.FlagKey, override.ProjectKey, err)
+			continue
+		}
+		log.Printf("override for flag %s in project %s expired", override.FlagKey, override.ProjectKey)
+	}
+	return nil
+}
+
+// RunOverrideReaper removes expired overrides until ctx is done.
+func RunOverrideReaper(ctx context.Context) {
+	ticker := time.NewTicker(overrideReaperInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := ReapExpiredOverrides(ctx, now); err != nil {
+				log.Printf("override reaper: %v", err)
+			}
+		}
+	}
+}
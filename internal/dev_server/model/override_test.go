@@ -4,11 +4,17 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldbuilders"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -63,6 +69,7 @@ func TestUpsertOverride(t *testing.T) {
 
 	t.Run("store fails to upsert, returns error", func(t *testing.T) {
 		store.EXPECT().GetDevProject(gomock.Any(), projKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projKey).Return(model.Overrides{}, nil)
 		store.EXPECT().UpsertOverride(gomock.Any(), gomock.Any()).Return(model.Override{}, errors.New("testy test"))
 
 		_, err := model.UpsertOverride(ctx, projKey, flagKey, ldValue)
@@ -72,8 +79,10 @@ func TestUpsertOverride(t *testing.T) {
 
 	t.Run("override is applied, observers are notified", func(t *testing.T) {
 		store.EXPECT().GetDevProject(gomock.Any(), projKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projKey).Return(model.Overrides{}, nil)
 		store.EXPECT().UpsertOverride(gomock.Any(), override).Return(override, nil)
 		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projKey).Return(1, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projKey, gomock.Any()).Return(nil, nil)
 		observer.
 			EXPECT().
 			Handle(model.OverrideEvent{
@@ -121,6 +130,7 @@ func TestDeleteOverride(t *testing.T) {
 
 	t.Run("Returns error if store errors on delete", func(t *testing.T) {
 		store.EXPECT().GetDevProject(gomock.Any(), projKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projKey).Return(model.Overrides{}, nil)
 		store.EXPECT().DeactivateOverride(gomock.Any(), projKey, flagKey).Return(0, errors.New("store error on deactive override"))
 
 		err := model.DeleteOverride(ctx, projKey, flagKey)
@@ -129,8 +139,10 @@ func TestDeleteOverride(t *testing.T) {
 
 	t.Run("override is applied, observers are notified", func(t *testing.T) {
 		store.EXPECT().GetDevProject(gomock.Any(), projKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projKey).Return(model.Overrides{}, nil)
 		store.EXPECT().DeactivateOverride(gomock.Any(), projKey, flagKey).Return(2, nil)
 		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projKey).Return(1, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projKey, gomock.Any()).Return(nil, nil)
 		observer.
 			EXPECT().
 			Handle(model.OverrideEvent{
@@ -184,7 +196,7 @@ func TestDeleteOverrides(t *testing.T) {
 		overrides := model.Overrides{
 			{ProjectKey: projKey, FlagKey: flagKey},
 		}
-		store.EXPECT().GetOverridesForProject(gomock.Any(), projKey).Return(overrides, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projKey).Return(overrides, nil).Times(2)
 		store.EXPECT().GetDevProject(gomock.Any(), projKey).Return(project, nil)
 		store.EXPECT().DeactivateOverride(gomock.Any(), projKey, flagKey).Return(0, errors.New("delete error"))
 
@@ -197,7 +209,7 @@ func TestDeleteOverrides(t *testing.T) {
 			{ProjectKey: projKey, FlagKey: flagKey},
 			{ProjectKey: projKey, FlagKey: "flag2"},
 		}
-		store.EXPECT().GetOverridesForProject(gomock.Any(), projKey).Return(overrides, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projKey).Return(overrides, nil).Times(3)
 
 		// Expectations for first override
 		store.EXPECT().GetDevProject(gomock.Any(), projKey).Return(project, nil)
@@ -211,6 +223,9 @@ func TestDeleteOverrides(t *testing.T) {
 		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projKey).Return(2, nil)
 		observer.EXPECT().Handle(gomock.Any())
 
+		// Both deactivations are recorded as one change
+		store.EXPECT().AppendHistory(gomock.Any(), projKey, gomock.Len(2)).Return(nil, nil)
+
 		err := model.DeleteOverrides(ctx, projKey)
 		assert.Nil(t, err)
 	})
@@ -249,3 +264,116 @@ func TestOverrideApply(t *testing.T) {
 		assert.Equal(t, 2, state.Version)
 	})
 }
+
+func TestOverrideApplyToFlag(t *testing.T) {
+	flag := ldbuilders.NewFlagBuilder("flg").
+		Version(2).
+		On(false).
+		Variations(ldvalue.String("a"), ldvalue.String("b")).
+		AddTarget(1, "targeted").
+		OffVariation(0).
+		FallthroughVariation(0).
+		Build()
+
+	t.Run("active override serves an existing variation to everyone", func(t *testing.T) {
+		applied := model.Override{Value: ldvalue.String("b"), Active: true, Version: 3}.ApplyToFlag(flag)
+		assert.True(t, applied.On)
+		assert.Empty(t, applied.Targets)
+		assert.Len(t, applied.Variations, 2)
+		assert.Equal(t, 1, applied.Fallthrough.Variation.IntValue())
+		assert.Equal(t, 5, applied.Version)
+	})
+
+	t.Run("active override with a new value adds a variation", func(t *testing.T) {
+		applied := model.Override{Value: ldvalue.String("c"), Active: true, Version: 1}.ApplyToFlag(flag)
+		assert.Equal(t, []ldvalue.Value{ldvalue.String("a"), ldvalue.String("b"), ldvalue.String("c")}, applied.Variations)
+		assert.Equal(t, 2, applied.Fallthrough.Variation.IntValue())
+		assert.Len(t, flag.Variations, 2, "the original flag is not modified")
+	})
+
+	t.Run("inactive override only bumps the version", func(t *testing.T) {
+		applied := model.Override{Value: ldvalue.Null(), Active: false, Version: 2}.ApplyToFlag(flag)
+		assert.False(t, applied.On)
+		assert.Len(t, applied.Targets, 1)
+		assert.Equal(t, 4, applied.Version)
+	})
+
+	t.Run("scoped override only serves its value to matching contexts", func(t *testing.T) {
+		matcher, err := model.ParseContextMatcher(`user.key == "alice"`)
+		require.NoError(t, err)
+		applied := model.Override{Value: ldvalue.String("b"), Active: true, Version: 1, ContextMatcher: &matcher}.ApplyToFlag(flag)
+		data := adapters.FlagsData{Flags: map[string]ldmodel.FeatureFlag{"flg": applied}}
+
+		assert.Equal(t, ldvalue.String("b"), model.EvaluateFlags(data, ldcontext.New("alice"))["flg"].Value)
+		assert.Equal(t, ldvalue.String("a"), model.EvaluateFlags(data, ldcontext.New("bob"))["flg"].Value,
+			"other contexts get the off variation of the flag that was off")
+		assert.Equal(t, ldvalue.String("a"), model.EvaluateFlags(data, ldcontext.New("targeted"))["flg"].Value)
+	})
+
+	t.Run("scoped override on a flag that is on keeps its targets ahead of the override", func(t *testing.T) {
+		onFlag := flag
+		onFlag.On = true
+		matcher, err := model.ParseContextMatcher("user.key in [alice, targeted]")
+		require.NoError(t, err)
+		applied := model.Override{Value: ldvalue.String("c"), Active: true, Version: 1, ContextMatcher: &matcher}.ApplyToFlag(onFlag)
+		data := adapters.FlagsData{Flags: map[string]ldmodel.FeatureFlag{"flg": applied}}
+
+		assert.Equal(t, ldvalue.String("c"), model.EvaluateFlags(data, ldcontext.New("alice"))["flg"].Value)
+		assert.Equal(t, ldvalue.String("b"), model.EvaluateFlags(data, ldcontext.New("targeted"))["flg"].Value)
+		assert.Equal(t, ldvalue.String("a"), model.EvaluateFlags(data, ldcontext.New("bob"))["flg"].Value)
+	})
+}
+
+func TestReapExpiredOverrides(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mocks.NewMockStore(mockController)
+	ctx := context.Background()
+	projKey := "proj"
+	now := time.Now()
+
+	project := &model.Project{
+		Key: projKey,
+		AllFlagsState: model.FlagsState{
+			"expired":   model.FlagState{Value: ldvalue.Bool(false), Version: 1},
+			"expiring":  model.FlagState{Value: ldvalue.Bool(false), Version: 1},
+			"permanent": model.FlagState{Value: ldvalue.Bool(false), Version: 1},
+		},
+	}
+
+	ctx = model.ContextWithStore(ctx, store)
+
+	observers := model.NewObservers()
+	observer := mocks.NewMockObserver(mockController)
+
+	observers.RegisterObserver(observer)
+	ctx = model.SetObserversOnContext(ctx, observers)
+
+	store.EXPECT().GetExpiredOverrides(gomock.Any(), now).Return(model.Overrides{
+		{ProjectKey: projKey, FlagKey: "expired", Value: ldvalue.Bool(true), Active: true, Version: 1, ExpiresAt: now.Add(-time.Second)},
+	}, nil)
+	store.EXPECT().GetOverridesForProject(gomock.Any(), projKey).Return(model.Overrides{
+		{ProjectKey: projKey, FlagKey: "expired", Value: ldvalue.Bool(true), Active: true, Version: 1, ExpiresAt: now.Add(-time.Second)},
+		{ProjectKey: projKey, FlagKey: "expiring", Value: ldvalue.Bool(true), Active: true, Version: 1, ExpiresAt: now.Add(time.Hour)},
+		{ProjectKey: projKey, FlagKey: "permanent", Value: ldvalue.Bool(true), Active: true, Version: 1},
+	}, nil)
+	store.EXPECT().GetDevProject(gomock.Any(), projKey).Return(project, nil)
+	store.EXPECT().DeactivateOverride(gomock.Any(), projKey, "expired").Return(2, nil)
+	store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projKey).Return(2, nil)
+	store.EXPECT().AppendHistory(gomock.Any(), projKey, gomock.Len(1)).Return(nil, nil)
+	observer.
+		EXPECT().
+		Handle(model.OverrideEvent{
+			FlagKey:    "expired",
+			ProjectKey: projKey,
+			FlagState: model.FlagState{
+				Value:   ldvalue.Bool(false),
+				Version: 3,
+			},
+			PayloadVersion: 2,
+		})
+
+	err := model.ReapExpiredOverrides(ctx, now)
+	assert.NoError(t, err)
+}
@@ -19,8 +19,12 @@ type Project struct {
 	Context              ldcontext.Context
 	LastSyncTime         time.Time
 	AllFlagsState        FlagsState
+	FlagsData            adapters.FlagsData
 	AvailableVariations  []FlagVariation
 	PayloadVersion       int
+	// Credentials are the source environment's, recorded when the project syncs so that SDKs configured with them
+	// are served this project.
+	Credentials adapters.EnvironmentCredentials
 }
 
 // CreateProject creates a project and adds it to the database.
@@ -32,7 +36,7 @@ func CreateProject(ctx context.Context, projectKey, sourceEnvironmentKey string,
 	}
 
 	if ldCtx == nil {
-		project.Context = ldcontext.NewBuilder("user").Key("dev-environment").Build()
+		project.Context = defaultContext()
 	} else {
 		project.Context = *ldCtx
 	}
@@ -48,12 +52,26 @@ func CreateProject(ctx context.Context, projectKey, sourceEnvironmentKey string,
 	return project, nil
 }
 
-func (project *Project) refreshExternalState(ctx context.Context) error {
-	flagsState, err := project.fetchFlagState(ctx)
+// defaultContext is the context a project's flags are evaluated for when none is given.
+func defaultContext() ldcontext.Context {
+	return ldcontext.NewBuilder("user").Key("dev-environment").Build()
+}
+
+func (project *Project) refreshExternalState(ctx context.Context) (err error) {
+	if project.IsOffline() {
+		return NewErrOffline(project.Key)
+	}
+	start := time.Now()
+	defer func() {
+		MetricsFromContext(ctx).SyncFinished(time.Since(start), err)
+	}()
+	credentials, flagsData, err := project.fetchFlagsData(ctx)
 	if err != nil {
 		return err
 	}
-	project.AllFlagsState = flagsState
+	project.Credentials = credentials
+	project.FlagsData = flagsData
+	project.AllFlagsState = EvaluateFlags(flagsData, project.Context)
 	project.LastSyncTime = time.Now()
 
 	if StreamStartupFromContext(ctx) {
@@ -95,52 +113,82 @@ func UpdateProject(ctx context.Context, projectKey string, context *ldcontext.Co
 		project.Context = *context
 	}
 
-	if sourceEnvironmentKey != nil {
+	if sourceEnvironmentKey != nil && *sourceEnvironmentKey != project.SourceEnvironmentKey {
+		if isEnvironmentProjectKey(projectKey) {
+			return Project{}, errors.Errorf("the source environment of %s can't be changed", projectKey)
+		}
+		environmentKey := EnvironmentProjectKey(projectKey, *sourceEnvironmentKey)
+		_, err := store.GetDevProject(ctx, environmentKey)
+		if err == nil {
+			return Project{}, errors.Errorf("%s is already an environment of project %s, remove it before making it the source environment", *sourceEnvironmentKey, projectKey)
+		}
+		if !errors.As(err, &ErrNotFound{}) {
+			return Project{}, err
+		}
 		project.SourceEnvironmentKey = *sourceEnvironmentKey
 	}
 
+	before := []FlagsState{project.AllFlagsState}
 	err = project.refreshExternalState(ctx)
 	if err != nil {
 		return Project{}, err
 	}
 
-	updated, err := store.UpdateProject(ctx, *project)
-	if err != nil {
-		return Project{}, err
-	}
-	if !updated {
-		return Project{}, errors.New("Project not updated")
+	// The project's environments are evaluated for its context, so they're refreshed and updated along with it.
+	projects := []Project{*project}
+	if !isEnvironmentProjectKey(projectKey) {
+		environments, err := GetProjectEnvironments(ctx, projectKey)
+		if err != nil {
+			return Project{}, err
+		}
+		for _, environment := range environments {
+			before = append(before, environment.AllFlagsState)
+			environment.Context = project.Context
+			if err := environment.refreshExternalState(ctx); err != nil {
+				return Project{}, errors.Wrapf(err, "unable to refresh environment %s", environment.Key)
+			}
+			projects = append(projects, environment)
+		}
 	}
 
-	newPayloadVersion, err := store.IncrementProjectPayloadVersion(ctx, projectKey)
+	payloadVersions, err := store.UpdateProjects(ctx, projects)
 	if err != nil {
-		return Project{}, errors.Wrap(err, "unable to increment payload version")
+		return Project{}, err
 	}
-	project.PayloadVersion = newPayloadVersion
+	for i := range projects {
+		projects[i].PayloadVersion = payloadVersions[i]
+		recordHistory(ctx, projects[i].Key, syncHistory(before[i], projects[i].AllFlagsState)...)
 
-	allFlagsWithOverrides, err := project.GetFlagStateWithOverridesForProject(ctx)
-	if err != nil {
-		return Project{}, errors.Wrapf(err, "unable to get overrides for project, %s", projectKey)
-	}
+		allFlagsWithOverrides, err := projects[i].GetFlagStateWithOverridesForProject(ctx)
+		if err != nil {
+			return Project{}, errors.Wrapf(err, "unable to get overrides for project, %s", projects[i].Key)
+		}
 
-	GetObserversFromContext(ctx).Notify(SyncEvent{
-		ProjectKey:     project.Key,
-		AllFlagsState:  allFlagsWithOverrides,
-		PayloadVersion: project.PayloadVersion,
-	})
-	return *project, nil
+		GetObserversFromContext(ctx).Notify(SyncEvent{
+			ProjectKey:     projects[i].Key,
+			AllFlagsState:  allFlagsWithOverrides,
+			PayloadVersion: projects[i].PayloadVersion,
+		})
+	}
+	return projects[0], nil
 }
 
 func (project Project) GetFlagStateWithOverridesForProject(ctx context.Context) (FlagsState, error) {
+	return project.applyOverrides(ctx, project.AllFlagsState, project.Context)
+}
+
+// applyOverrides applies the project's overrides to flag state evaluated for ldCtx.
+func (project Project) applyOverrides(ctx context.Context, flagsState FlagsState, ldCtx ldcontext.Context) (FlagsState, error) {
 	store := StoreFromContext(ctx)
 	overrides, err := store.GetOverridesForProject(ctx, project.Key)
 	if err != nil {
 		return FlagsState{}, errors.Wrapf(err, "unable to fetch overrides for project %s", project.Key)
 	}
-	withOverrides := make(FlagsState, len(project.AllFlagsState))
-	for flagKey, flagState := range project.AllFlagsState {
+	flagsData := project.flagsDataWithOverrides(overrides)
+	withOverrides := make(FlagsState, len(flagsState))
+	for flagKey, flagState := range flagsState {
 		if override, ok := overrides.GetFlag(flagKey); ok {
-			flagState = override.Apply(flagState)
+			flagState = override.ApplyForContext(flagState, ldCtx, flagsData)
 		}
 		withOverrides[flagKey] = flagState
 	}
@@ -148,7 +196,7 @@ func (project Project) GetFlagStateWithOverridesForProject(ctx context.Context)
 }
 
 func (project Project) fetchAvailableVariations(ctx context.Context) ([]FlagVariation, error) {
-	flags, err := adapters.GetApi(ctx).GetAllFlags(ctx, project.Key)
+	flags, err := adapters.GetApi(ctx).GetAllFlags(ctx, SourceProjectKey(project.Key))
 	if err != nil {
 		return nil, err
 	}
@@ -179,20 +227,17 @@ func variationsFromFlags(flags []ldapi.FeatureFlag) []FlagVariation {
 	return allVariations
 }
 
-func (project Project) fetchFlagState(ctx context.Context) (FlagsState, error) {
+func (project Project) fetchFlagsData(ctx context.Context) (adapters.EnvironmentCredentials, adapters.FlagsData, error) {
 	apiAdapter := adapters.GetApi(ctx)
-	sdkKey, err := apiAdapter.GetSdkKey(ctx, project.Key, project.SourceEnvironmentKey)
-	flagsState := make(FlagsState)
+	credentials, err := apiAdapter.GetEnvironmentCredentials(ctx, SourceProjectKey(project.Key), project.SourceEnvironmentKey)
 	if err != nil {
-		return flagsState, err
+		return adapters.EnvironmentCredentials{}, adapters.FlagsData{}, err
 	}
 
 	sdkAdapter := adapters.GetSdk(ctx)
-	sdkFlags, err := sdkAdapter.GetAllFlagsState(ctx, project.Context, sdkKey)
+	flagsData, err := sdkAdapter.GetFlagsData(ctx, credentials.SdkKey)
 	if err != nil {
-		return flagsState, err
+		return adapters.EnvironmentCredentials{}, adapters.FlagsData{}, err
 	}
-
-	flagsState = FromAllFlags(sdkFlags)
-	return flagsState, nil
+	return credentials, flagsData, nil
 }
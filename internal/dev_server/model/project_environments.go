@@ -0,0 +1,108 @@
+package model
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A dev project can hold flag state for more source environments than the one it was added with. Each additional
+// environment is kept as a dev project of its own, keyed project:env, so that it has its own overrides, payload
+// version and credentials, and SDKs and the API can address it by that key.
+const environmentKeySeparator = ":"
+
+// EnvironmentProjectKey is the key of the dev project that holds the project's flag state for the environment.
+func EnvironmentProjectKey(projectKey, environmentKey string) string {
+	return projectKey + environmentKeySeparator + environmentKey
+}
+
+// SourceProjectKey is the key of the LaunchDarkly project that a dev project, or one of its environments, syncs from.
+func SourceProjectKey(projectKey string) string {
+	sourceProjectKey, _, _ := strings.Cut(projectKey, environmentKeySeparator)
+	return sourceProjectKey
+}
+
+func isEnvironmentProjectKey(projectKey string) bool {
+	return strings.Contains(projectKey, environmentKeySeparator)
+}
+
+// AddProjectEnvironment syncs another source environment into the project, evaluated for the project's context.
+func AddProjectEnvironment(ctx context.Context, projectKey, environmentKey string) (Project, error) {
+	if isEnvironmentProjectKey(projectKey) {
+		return Project{}, errors.Errorf("%s is already an environment of project %s", projectKey, SourceProjectKey(projectKey))
+	}
+	project, err := StoreFromContext(ctx).GetDevProject(ctx, projectKey)
+	if err != nil {
+		return Project{}, err
+	}
+	if project.SourceEnvironmentKey == environmentKey {
+		return Project{}, NewErrAlreadyExists("environment", EnvironmentProjectKey(projectKey, environmentKey))
+	}
+	return CreateProject(ctx, EnvironmentProjectKey(projectKey, environmentKey), environmentKey, &project.Context)
+}
+
+// GetProjectEnvironments returns the project's additional environments, sorted by key.
+func GetProjectEnvironments(ctx context.Context, projectKey string) ([]Project, error) {
+	store := StoreFromContext(ctx)
+	keys, err := store.GetDevProjectKeysWithPrefix(ctx, projectKey+environmentKeySeparator)
+	if err != nil {
+		return nil, err
+	}
+	var environments []Project
+	for _, key := range keys {
+		environment, err := store.GetDevProject(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		environments = append(environments, *environment)
+	}
+	return environments, nil
+}
+
+// DeleteProject deletes the project along with its additional environments, in a single transaction.
+func DeleteProject(ctx context.Context, projectKey string) (bool, error) {
+	store := StoreFromContext(ctx)
+	projectKeys := []string{projectKey}
+	if !isEnvironmentProjectKey(projectKey) {
+		environmentKeys, err := store.GetDevProjectKeysWithPrefix(ctx, projectKey+environmentKeySeparator)
+		if err != nil {
+			return false, err
+		}
+		projectKeys = append(projectKeys, environmentKeys...)
+	}
+	deleted, err := store.DeleteDevProjects(ctx, projectKeys)
+	if err != nil {
+		return false, err
+	}
+	for _, key := range deleted {
+		GetObserversFromContext(ctx).Notify(ProjectDeletedEvent{ProjectKey: key})
+	}
+	return slices.Contains(deleted, projectKey), nil
+}
+
+// ResolveEnvironmentProjectKey maps a project:env key to the dev project that holds that environment, which is the
+// project itself when env is the environment it was added with. Other keys are returned as they are.
+func ResolveEnvironmentProjectKey(ctx context.Context, key string) (string, error) {
+	projectKey, environmentKey, ok := strings.Cut(key, environmentKeySeparator)
+	if !ok {
+		return key, nil
+	}
+	store := StoreFromContext(ctx)
+	_, err := store.GetDevProject(ctx, key)
+	if err == nil || !errors.As(err, &ErrNotFound{}) {
+		return key, err
+	}
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		if errors.As(err, &ErrNotFound{}) {
+			return key, nil
+		}
+		return key, err
+	}
+	if project.SourceEnvironmentKey == environmentKey {
+		return projectKey, nil
+	}
+	return key, nil
+}
@@ -0,0 +1,104 @@
+package model_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+	adapters_mocks "github.com/launchdarkly/ldcli/internal/dev_server/adapters/mocks"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func TestAddProjectEnvironment(t *testing.T) {
+	mockController := gomock.NewController(t)
+	ctx, api, sdk := adapters_mocks.WithMockApiAndSdk(context.Background(), mockController)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+	ldCtx := ldcontext.New(t.Name())
+	project := model.Project{Key: "proj", SourceEnvironmentKey: "production", Context: ldCtx}
+
+	t.Run("syncs the environment as project:env for the project's context", func(t *testing.T) {
+		flagsData := adapters_mocks.FlagsDataFromValues(map[string]ldvalue.Value{"flag": ldvalue.Bool(true)}, 1)
+		store.EXPECT().GetDevProject(gomock.Any(), "proj").Return(&project, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), "proj", "staging").Return(adapters.EnvironmentCredentials{SdkKey: "staging-sdk-key"}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), "staging-sdk-key").Return(flagsData, nil)
+		api.EXPECT().GetAllFlags(gomock.Any(), "proj").Return(nil, nil)
+		store.EXPECT().InsertProject(gomock.Any(), gomock.Any()).Return(nil)
+
+		environment, err := model.AddProjectEnvironment(ctx, "proj", "staging")
+		require.NoError(t, err)
+		assert.Equal(t, "proj:staging", environment.Key)
+		assert.Equal(t, "staging", environment.SourceEnvironmentKey)
+		assert.Equal(t, ldCtx, environment.Context)
+		assert.Equal(t, "staging-sdk-key", environment.Credentials.SdkKey)
+	})
+
+	t.Run("rejects the environment the project was added with", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), "proj").Return(&project, nil)
+
+		_, err := model.AddProjectEnvironment(ctx, "proj", "production")
+		assert.ErrorAs(t, err, &model.ErrAlreadyExists{})
+	})
+
+	t.Run("rejects environments of environments", func(t *testing.T) {
+		_, err := model.AddProjectEnvironment(ctx, "proj:staging", "test")
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteProject(t *testing.T) {
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx := model.ContextWithStore(context.Background(), store)
+	observers := model.NewObservers()
+	ctx = model.SetObserversOnContext(ctx, observers)
+	observer := mocks.NewMockObserver(mockController)
+	observers.RegisterObserver(observer)
+
+	store.EXPECT().GetDevProjectKeysWithPrefix(gomock.Any(), "proj:").Return([]string{"proj:staging"}, nil)
+	gomock.InOrder(
+		store.EXPECT().DeleteDevProjects(gomock.Any(), []string{"proj", "proj:staging"}).Return([]string{"proj", "proj:staging"}, nil),
+		observer.EXPECT().Handle(model.ProjectDeletedEvent{ProjectKey: "proj"}),
+		observer.EXPECT().Handle(model.ProjectDeletedEvent{ProjectKey: "proj:staging"}),
+	)
+
+	deleted, err := model.DeleteProject(ctx, "proj")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+}
+
+func TestResolveEnvironmentProjectKey(t *testing.T) {
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx := model.ContextWithStore(context.Background(), store)
+
+	t.Run("passes on keys that aren't project:env", func(t *testing.T) {
+		key, err := model.ResolveEnvironmentProjectKey(ctx, "proj")
+		require.NoError(t, err)
+		assert.Equal(t, "proj", key)
+	})
+
+	t.Run("keeps added environments", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), "proj:staging").Return(&model.Project{Key: "proj:staging"}, nil)
+
+		key, err := model.ResolveEnvironmentProjectKey(ctx, "proj:staging")
+		require.NoError(t, err)
+		assert.Equal(t, "proj:staging", key)
+	})
+
+	t.Run("maps the environment the project was added with to the project", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), "proj:production").Return(nil, model.NewErrNotFound("project", "proj:production"))
+		store.EXPECT().GetDevProject(gomock.Any(), "proj").Return(&model.Project{Key: "proj", SourceEnvironmentKey: "production"}, nil)
+
+		key, err := model.ResolveEnvironmentProjectKey(ctx, "proj:production")
+		require.NoError(t, err)
+		assert.Equal(t, "proj", key)
+	})
+}
@@ -13,7 +13,7 @@ import (
 	ldapi "github.com/launchdarkly/api-client-go/v14"
 	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
-	"github.com/launchdarkly/go-server-sdk/v7/interfaces/flagstate"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	adapters_mocks "github.com/launchdarkly/ldcli/internal/dev_server/adapters/mocks"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
@@ -29,9 +29,8 @@ func TestCreateProject(t *testing.T) {
 	sourceEnvKey := "env"
 	sdkKey := "thing"
 
-	allFlagsState := flagstate.NewAllFlagsBuilder().
-		AddFlag("boolFlag", flagstate.FlagState{Value: ldvalue.Bool(true)}).
-		Build()
+	flagsData := adapters_mocks.FlagsDataFromValues(map[string]ldvalue.Value{"boolFlag": ldvalue.Bool(true)}, 1)
+	allFlagsState := model.FlagsState{"boolFlag": {Value: ldvalue.Bool(true), Version: 1}}
 
 	trueVariationId, falseVariationId := "true", "false"
 	allFlags := []ldapi.FeatureFlag{{
@@ -51,15 +50,15 @@ func TestCreateProject(t *testing.T) {
 	}}
 
 	t.Run("Returns error if it cant fetch flag state", func(t *testing.T) {
-		api.EXPECT().GetSdkKey(gomock.Any(), projKey, sourceEnvKey).Return("", errors.New("fetch flag state fails"))
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projKey, sourceEnvKey).Return(adapters.EnvironmentCredentials{}, errors.New("fetch flag state fails"))
 		_, err := model.CreateProject(ctx, projKey, sourceEnvKey, nil)
 		assert.NotNil(t, err)
 		assert.Equal(t, "fetch flag state fails", err.Error())
 	})
 
 	t.Run("Returns error if it can't fetch flags", func(t *testing.T) {
-		api.EXPECT().GetSdkKey(gomock.Any(), projKey, sourceEnvKey).Return(sdkKey, nil)
-		sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), sdkKey).Return(allFlagsState, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projKey, sourceEnvKey).Return(adapters.EnvironmentCredentials{SdkKey: sdkKey}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), sdkKey).Return(flagsData, nil)
 		api.EXPECT().GetAllFlags(gomock.Any(), projKey).Return(nil, errors.New("fetch flags failed"))
 		_, err := model.CreateProject(ctx, projKey, sourceEnvKey, nil)
 		assert.NotNil(t, err)
@@ -67,8 +66,8 @@ func TestCreateProject(t *testing.T) {
 	})
 
 	t.Run("Returns error if it fails to insert the project", func(t *testing.T) {
-		api.EXPECT().GetSdkKey(gomock.Any(), projKey, sourceEnvKey).Return(sdkKey, nil)
-		sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), sdkKey).Return(allFlagsState, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projKey, sourceEnvKey).Return(adapters.EnvironmentCredentials{SdkKey: sdkKey}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), sdkKey).Return(flagsData, nil)
 		api.EXPECT().GetAllFlags(gomock.Any(), projKey).Return(allFlags, nil)
 		store.EXPECT().InsertProject(gomock.Any(), gomock.Any()).Return(errors.New("insert fails"))
 
@@ -78,8 +77,9 @@ func TestCreateProject(t *testing.T) {
 	})
 
 	t.Run("Successfully creates project", func(t *testing.T) {
-		api.EXPECT().GetSdkKey(gomock.Any(), projKey, sourceEnvKey).Return(sdkKey, nil)
-		sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), sdkKey).Return(allFlagsState, nil)
+		credentials := adapters.EnvironmentCredentials{SdkKey: sdkKey, MobileKey: "mob-key", ClientSideID: "client-side-id"}
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projKey, sourceEnvKey).Return(credentials, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), sdkKey).Return(flagsData, nil)
 		api.EXPECT().GetAllFlags(gomock.Any(), projKey).Return(allFlags, nil)
 		store.EXPECT().InsertProject(gomock.Any(), gomock.Any()).Return(nil)
 
@@ -90,13 +90,14 @@ func TestCreateProject(t *testing.T) {
 			Key:                  projKey,
 			SourceEnvironmentKey: sourceEnvKey,
 			Context:              ldcontext.NewBuilder("user").Key("dev-environment").Build(),
-			AllFlagsState:        model.FromAllFlags(allFlagsState),
+			AllFlagsState:        allFlagsState,
 		}
 
 		assert.Equal(t, expectedProj.Key, p.Key)
 		assert.Equal(t, expectedProj.SourceEnvironmentKey, p.SourceEnvironmentKey)
 		assert.Equal(t, expectedProj.Context, p.Context)
 		assert.Equal(t, expectedProj.AllFlagsState, p.AllFlagsState)
+		assert.Equal(t, credentials, p.Credentials)
 		//TODO add assertion on AvailableVariations
 	})
 }
@@ -121,9 +122,8 @@ func TestUpdateProject(t *testing.T) {
 		Context:              ldcontext.New(t.Name()),
 	}
 
-	allFlagsState := flagstate.NewAllFlagsBuilder().
-		AddFlag("stringFlag", flagstate.FlagState{Value: ldvalue.String("cool")}).
-		Build()
+	flagsData := adapters_mocks.FlagsDataFromValues(map[string]ldvalue.Value{"stringFlag": ldvalue.String("cool")}, 1)
+	allFlagsState := model.FlagsState{"stringFlag": {Value: ldvalue.String("cool"), Version: 1}}
 
 	allFlags := []ldapi.FeatureFlag{{
 		Name: "string flag",
@@ -146,7 +146,7 @@ func TestUpdateProject(t *testing.T) {
 
 	t.Run("returns error if the fetch flag state fails", func(t *testing.T) {
 		store.EXPECT().GetDevProject(gomock.Any(), proj.Key).Return(&proj, nil)
-		api.EXPECT().GetSdkKey(gomock.Any(), proj.Key, proj.SourceEnvironmentKey).Return("", errors.New("FetchFlagState fails"))
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), proj.Key, proj.SourceEnvironmentKey).Return(adapters.EnvironmentCredentials{}, errors.New("FetchFlagState fails"))
 
 		_, err := model.UpdateProject(ctx, proj.Key, &ldCtx, nil)
 		assert.NotNil(t, err)
@@ -155,10 +155,12 @@ func TestUpdateProject(t *testing.T) {
 
 	t.Run("Returns error if UpdateProject fails", func(t *testing.T) {
 		store.EXPECT().GetDevProject(gomock.Any(), proj.Key).Return(&proj, nil)
-		api.EXPECT().GetSdkKey(gomock.Any(), proj.Key, newSrcEnv).Return("sdkKey", nil)
-		sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), "sdkKey").Return(allFlagsState, nil)
+		store.EXPECT().GetDevProject(gomock.Any(), "projKey:newEnv").Return(nil, model.NewErrNotFound("project", "projKey:newEnv"))
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), proj.Key, newSrcEnv).Return(adapters.EnvironmentCredentials{SdkKey: "sdkKey"}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), "sdkKey").Return(flagsData, nil)
 		api.EXPECT().GetAllFlags(gomock.Any(), proj.Key).Return(allFlags, nil)
-		store.EXPECT().UpdateProject(gomock.Any(), gomock.Any()).Return(false, errors.New("UpdateProject fails"))
+		store.EXPECT().GetDevProjectKeysWithPrefix(gomock.Any(), "projKey:").Return(nil, nil)
+		store.EXPECT().UpdateProjects(gomock.Any(), gomock.Any()).Return(nil, errors.New("UpdateProject fails"))
 
 		_, err := model.UpdateProject(ctx, proj.Key, nil, &newSrcEnv)
 		assert.NotNil(t, err)
@@ -167,29 +169,38 @@ func TestUpdateProject(t *testing.T) {
 
 	t.Run("Returns error if project was not actually updated", func(t *testing.T) {
 		store.EXPECT().GetDevProject(gomock.Any(), proj.Key).Return(&proj, nil)
-		api.EXPECT().GetSdkKey(gomock.Any(), proj.Key, proj.SourceEnvironmentKey).Return("sdkKey", nil)
-		sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), "sdkKey").Return(allFlagsState, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), proj.Key, proj.SourceEnvironmentKey).Return(adapters.EnvironmentCredentials{SdkKey: "sdkKey"}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), "sdkKey").Return(flagsData, nil)
 		api.EXPECT().GetAllFlags(gomock.Any(), proj.Key).Return(allFlags, nil)
-		store.EXPECT().UpdateProject(gomock.Any(), gomock.Any()).Return(false, nil)
+		store.EXPECT().GetDevProjectKeysWithPrefix(gomock.Any(), "projKey:").Return(nil, nil)
+		store.EXPECT().UpdateProjects(gomock.Any(), gomock.Any()).Return(nil, model.NewErrNotFound("project", proj.Key))
 
 		_, err := model.UpdateProject(ctx, proj.Key, nil, nil)
-		assert.NotNil(t, err)
-		assert.Equal(t, "Project not updated", err.Error())
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+	})
+
+	t.Run("Rejects a source environment that is already one of the project's environments", func(t *testing.T) {
+		existingEnv := "existingEnv"
+		store.EXPECT().GetDevProject(gomock.Any(), proj.Key).Return(&proj, nil)
+		store.EXPECT().GetDevProject(gomock.Any(), "projKey:existingEnv").Return(&model.Project{Key: "projKey:existingEnv"}, nil)
+
+		_, err := model.UpdateProject(ctx, proj.Key, nil, &existingEnv)
+		assert.ErrorContains(t, err, "already an environment of project projKey")
 	})
 
 	t.Run("Return successfully", func(t *testing.T) {
 		store.EXPECT().GetDevProject(gomock.Any(), proj.Key).Return(&proj, nil)
-		api.EXPECT().GetSdkKey(gomock.Any(), proj.Key, proj.SourceEnvironmentKey).Return("sdkKey", nil)
-		sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), "sdkKey").Return(allFlagsState, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), proj.Key, proj.SourceEnvironmentKey).Return(adapters.EnvironmentCredentials{SdkKey: "sdkKey"}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), "sdkKey").Return(flagsData, nil)
 		api.EXPECT().GetAllFlags(gomock.Any(), proj.Key).Return(allFlags, nil)
-		store.EXPECT().UpdateProject(gomock.Any(), gomock.Any()).Return(true, nil)
-		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), proj.Key).Return(2, nil)
+		store.EXPECT().GetDevProjectKeysWithPrefix(gomock.Any(), "projKey:").Return(nil, nil)
+		store.EXPECT().UpdateProjects(gomock.Any(), gomock.Any()).Return([]int{2}, nil)
 		store.EXPECT().GetOverridesForProject(gomock.Any(), proj.Key).Return(model.Overrides{}, nil)
 		observer.
 			EXPECT().
 			Handle(model.SyncEvent{
 				ProjectKey:     proj.Key,
-				AllFlagsState:  model.FromAllFlags(allFlagsState),
+				AllFlagsState:  allFlagsState,
 				PayloadVersion: 2,
 			})
 
@@ -199,6 +210,36 @@ func TestUpdateProject(t *testing.T) {
 		expectedProj.PayloadVersion = 2
 		assert.Equal(t, expectedProj, project)
 	})
+
+	t.Run("Updates the project's environments with it, in one transaction", func(t *testing.T) {
+		environment := model.Project{Key: "projKey:staging", SourceEnvironmentKey: "staging", Context: proj.Context, AllFlagsState: allFlagsState}
+		newCtx := ldcontext.New("new-context")
+		store.EXPECT().GetDevProject(gomock.Any(), proj.Key).Return(&proj, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), proj.Key, proj.SourceEnvironmentKey).Return(adapters.EnvironmentCredentials{SdkKey: "sdkKey"}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), "sdkKey").Return(flagsData, nil)
+		api.EXPECT().GetAllFlags(gomock.Any(), proj.Key).Return(allFlags, nil)
+		store.EXPECT().GetDevProjectKeysWithPrefix(gomock.Any(), "projKey:").Return([]string{environment.Key}, nil)
+		store.EXPECT().GetDevProject(gomock.Any(), environment.Key).Return(&environment, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), proj.Key, "staging").Return(adapters.EnvironmentCredentials{SdkKey: "stagingSdkKey"}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), "stagingSdkKey").Return(flagsData, nil)
+		api.EXPECT().GetAllFlags(gomock.Any(), proj.Key).Return(allFlags, nil)
+		store.EXPECT().UpdateProjects(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, projects []model.Project) ([]int, error) {
+			require.Len(t, projects, 2)
+			assert.Equal(t, proj.Key, projects[0].Key)
+			assert.Equal(t, environment.Key, projects[1].Key)
+			assert.Equal(t, newCtx, projects[0].Context)
+			assert.Equal(t, newCtx, projects[1].Context)
+			return []int{2, 5}, nil
+		})
+		store.EXPECT().GetOverridesForProject(gomock.Any(), proj.Key).Return(model.Overrides{}, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), environment.Key).Return(model.Overrides{}, nil)
+		observer.EXPECT().Handle(model.SyncEvent{ProjectKey: proj.Key, AllFlagsState: allFlagsState, PayloadVersion: 2})
+		observer.EXPECT().Handle(model.SyncEvent{ProjectKey: environment.Key, AllFlagsState: allFlagsState, PayloadVersion: 5})
+
+		project, err := model.UpdateProject(ctx, proj.Key, &newCtx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, newCtx, project.Context)
+	})
 }
 
 func TestGetFlagStateWithOverridesForProject(t *testing.T) {
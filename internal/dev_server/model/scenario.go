@@ -0,0 +1,142 @@
+package model
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+)
+
+// Scenario is a named set of overrides for a project that can be applied in a single update.
+type Scenario struct {
+	ProjectKey string
+	Name       string
+	Overrides  map[string]ldvalue.Value
+}
+
+// SaveScenario stores the overrides under the scenario name, replacing any scenario already saved with that name.
+// When overrides is nil, the project's active overrides are saved instead.
+func SaveScenario(ctx context.Context, projectKey, name string, overrides map[string]ldvalue.Value) (Scenario, error) {
+	store := StoreFromContext(ctx)
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return Scenario{}, err
+	}
+
+	if overrides == nil {
+		active, err := store.GetOverridesForProject(ctx, projectKey)
+		if err != nil {
+			return Scenario{}, errors.Wrapf(err, "unable to fetch overrides for project %s", projectKey)
+		}
+		overrides = make(map[string]ldvalue.Value, len(active))
+		for _, override := range active {
+			if override.Active {
+				overrides[override.FlagKey] = override.Value
+			}
+		}
+	}
+	for flagKey := range overrides {
+		if _, ok := project.AllFlagsState[flagKey]; !ok {
+			return Scenario{}, NewErrNotFound("flag", flagKey)
+		}
+	}
+
+	scenario := Scenario{
+		ProjectKey: projectKey,
+		Name:       name,
+		Overrides:  overrides,
+	}
+	err = store.UpsertScenario(ctx, scenario)
+	if err != nil {
+		return Scenario{}, errors.Wrapf(err, "unable to save scenario %s", name)
+	}
+	return scenario, nil
+}
+
+// ApplyScenario replaces the project's overrides with the scenario's. The overrides are swapped in one store
+// transaction with a single payload version bump, and connected SDKs get one update so they never see a mix of the
+// old and new overrides. Flags that have left the project since the scenario was saved are skipped.
+func ApplyScenario(ctx context.Context, projectKey, name string) (Scenario, error) {
+	store := StoreFromContext(ctx)
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return Scenario{}, err
+	}
+	scenario, err := store.GetScenario(ctx, projectKey, name)
+	if err != nil {
+		return Scenario{}, err
+	}
+
+	overrides := make(map[string]ldvalue.Value, len(scenario.Overrides))
+	for flagKey, value := range scenario.Overrides {
+		if _, ok := project.AllFlagsState[flagKey]; ok {
+			overrides[flagKey] = value
+		}
+	}
+	active, err := store.GetOverridesForProject(ctx, projectKey)
+	if err != nil {
+		return Scenario{}, errors.Wrapf(err, "unable to fetch overrides for project %s", projectKey)
+	}
+	project.PayloadVersion, err = store.ReplaceOverrides(ctx, projectKey, overrides)
+	if err != nil {
+		return Scenario{}, errors.Wrapf(err, "unable to apply scenario %s", name)
+	}
+	recordHistory(WithHistorySource(ctx, HistorySourceScenario), projectKey, replaceOverridesHistory(active, overrides)...)
+
+	allFlagsWithOverrides, err := project.GetFlagStateWithOverridesForProject(ctx)
+	if err != nil {
+		return Scenario{}, errors.Wrapf(err, "unable to get overrides for project, %s", projectKey)
+	}
+	GetObserversFromContext(ctx).Notify(SyncEvent{
+		ProjectKey:     projectKey,
+		AllFlagsState:  allFlagsWithOverrides,
+		PayloadVersion: project.PayloadVersion,
+	})
+	return *scenario, nil
+}
+
+// replaceOverridesHistory describes the override changes made by replacing the active overrides with values.
+func replaceOverridesHistory(active Overrides, values map[string]ldvalue.Value) []HistoryEntry {
+	replacements := make(Overrides, 0, len(values))
+	for flagKey, value := range values {
+		replacements = append(replacements, Override{FlagKey: flagKey, Value: value, Active: true})
+	}
+	return restoreOverridesHistory(active, replacements)
+}
+
+// restoreOverridesHistory describes the override changes made by replacing the active overrides with the active ones
+// of replacements, along with their expiries and context matchers.
+func restoreOverridesHistory(active, replacements Overrides) []HistoryEntry {
+	var entries []HistoryEntry
+	for _, override := range active {
+		if replacement, ok := replacements.GetFlag(override.FlagKey); override.Active && (!ok || !replacement.Active) {
+			entries = append(entries, HistoryEntry{
+				FlagKey:    override.FlagKey,
+				Action:     HistoryActionDeactivate,
+				OldValue:   valuePtr(override.Value),
+				OldOptions: override.options(),
+			})
+		}
+	}
+	for _, replacement := range replacements {
+		if !replacement.Active {
+			continue
+		}
+		entry := HistoryEntry{
+			FlagKey:    replacement.FlagKey,
+			Action:     HistoryActionOverride,
+			NewValue:   valuePtr(replacement.Value),
+			NewOptions: replacement.options(),
+		}
+		if override, ok := active.GetFlag(replacement.FlagKey); ok && override.Active {
+			if override.Value.Equal(replacement.Value) && override.options().equal(replacement.options()) {
+				continue
+			}
+			entry.OldValue = valuePtr(override.Value)
+			entry.OldOptions = override.options()
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
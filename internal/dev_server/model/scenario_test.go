@@ -0,0 +1,133 @@
+package model_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func TestSaveScenario(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+
+	projectKey := "proj"
+	project := &model.Project{
+		Key: projectKey,
+		AllFlagsState: model.FlagsState{
+			"new-checkout": {Value: ldvalue.Bool(false), Version: 1},
+			"banner":       {Value: ldvalue.String("welcome"), Version: 1},
+		},
+	}
+
+	t.Run("saves the given overrides", func(t *testing.T) {
+		overrides := map[string]ldvalue.Value{"new-checkout": ldvalue.Bool(true)}
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().UpsertScenario(gomock.Any(), model.Scenario{
+			ProjectKey: projectKey,
+			Name:       "checkout-on",
+			Overrides:  overrides,
+		}).Return(nil)
+
+		scenario, err := model.SaveScenario(ctx, projectKey, "checkout-on", overrides)
+		require.NoError(t, err)
+		assert.Equal(t, "checkout-on", scenario.Name)
+	})
+
+	t.Run("saves the active overrides when none are given", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true, Version: 1},
+			{ProjectKey: projectKey, FlagKey: "banner", Value: ldvalue.String("sale"), Active: false, Version: 2},
+		}, nil)
+		store.EXPECT().UpsertScenario(gomock.Any(), gomock.Any()).Return(nil)
+
+		scenario, err := model.SaveScenario(ctx, projectKey, "current", nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]ldvalue.Value{"new-checkout": ldvalue.Bool(true)}, scenario.Overrides)
+	})
+
+	t.Run("returns ErrNotFound for flags not in the project", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+
+		_, err := model.SaveScenario(ctx, projectKey, "bad", map[string]ldvalue.Value{"missing": ldvalue.Bool(true)})
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+	})
+}
+
+func TestApplyScenario(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+	observers := model.NewObservers()
+	observer := mocks.NewMockObserver(mockController)
+	observers.RegisterObserver(observer)
+	ctx = model.SetObserversOnContext(ctx, observers)
+
+	projectKey := "proj"
+	project := &model.Project{
+		Key: projectKey,
+		AllFlagsState: model.FlagsState{
+			"new-checkout": {Value: ldvalue.Bool(false), Version: 1},
+			"banner":       {Value: ldvalue.String("welcome"), Version: 1},
+		},
+		PayloadVersion: 3,
+	}
+
+	t.Run("replaces the overrides and sends a single update", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetScenario(gomock.Any(), projectKey, "checkout-on").Return(&model.Scenario{
+			ProjectKey: projectKey,
+			Name:       "checkout-on",
+			Overrides: map[string]ldvalue.Value{
+				"new-checkout": ldvalue.Bool(true),
+				"retired-flag": ldvalue.Bool(true),
+			},
+		}, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "banner", Value: ldvalue.String("sale"), Active: true, Version: 1},
+		}, nil)
+		store.EXPECT().ReplaceOverrides(gomock.Any(), projectKey, map[string]ldvalue.Value{
+			"new-checkout": ldvalue.Bool(true),
+		}).Return(4, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(2)).DoAndReturn(
+			func(ctx context.Context, projectKey string, entries []model.HistoryEntry) ([]model.HistoryEntry, error) {
+				for _, entry := range entries {
+					assert.Equal(t, model.HistorySourceScenario, entry.Source)
+				}
+				return entries, nil
+			})
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true, Version: 1},
+			{ProjectKey: projectKey, FlagKey: "banner", Value: ldvalue.String("sale"), Active: false, Version: 2},
+		}, nil)
+		observer.EXPECT().Handle(model.SyncEvent{
+			ProjectKey: projectKey,
+			AllFlagsState: model.FlagsState{
+				"new-checkout": {Value: ldvalue.Bool(true), Version: 2, TrackEvents: true},
+				"banner":       {Value: ldvalue.String("welcome"), Version: 3},
+			},
+			PayloadVersion: 4,
+		})
+
+		_, err := model.ApplyScenario(ctx, projectKey, "checkout-on")
+		require.NoError(t, err)
+	})
+
+	t.Run("returns ErrNotFound for unknown scenarios", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetScenario(gomock.Any(), projectKey, "missing").Return(nil, model.NewErrNotFound("scenario", "missing"))
+
+		_, err := model.ApplyScenario(ctx, projectKey, "missing")
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+	})
+}
@@ -0,0 +1,271 @@
+package model
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+)
+
+// Snapshot is a named copy of a single project's state that can be compared with other snapshots or the live state,
+// and restored without touching the other projects.
+type Snapshot struct {
+	ProjectKey          string
+	Name                string
+	CreatedAt           time.Time
+	Context             ldcontext.Context
+	AllFlagsState       FlagsState
+	FlagsData           adapters.FlagsData
+	AvailableVariations []FlagVariation
+	Overrides           Overrides
+}
+
+// SnapshotField is the part of a project's state that a SnapshotDifference is about.
+type SnapshotField string
+
+const (
+	SnapshotFieldContext    SnapshotField = "context"
+	SnapshotFieldValue      SnapshotField = "value"
+	SnapshotFieldOverride   SnapshotField = "override"
+	SnapshotFieldVariations SnapshotField = "variations"
+)
+
+// SnapshotDifference is a difference between two snapshots of a project. From and To are missing when the flag, its
+// override or its variations are missing from that side. Differences in the project's context have no flag key.
+type SnapshotDifference struct {
+	FlagKey string         `json:"flagKey,omitempty"`
+	Field   SnapshotField  `json:"field"`
+	From    *ldvalue.Value `json:"from,omitempty"`
+	To      *ldvalue.Value `json:"to,omitempty"`
+}
+
+// SnapshotDiff lists the differences between two snapshots, or between a snapshot and the project's live state. It has
+// the shape of a list of resources so that the CLI can show it as a table.
+type SnapshotDiff struct {
+	From       string               `json:"from"`
+	To         string               `json:"to"`
+	Items      []SnapshotDifference `json:"items"`
+	TotalCount int                  `json:"totalCount"`
+}
+
+// LiveSnapshotName is what a diff calls the project's live state.
+const LiveSnapshotName = "live"
+
+// CreateSnapshot saves the project's current flag state, overrides, context and available variations under name.
+func CreateSnapshot(ctx context.Context, projectKey, name string) (Snapshot, error) {
+	snapshot, err := captureSnapshot(ctx, projectKey)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshot.Name = name
+	snapshot.CreatedAt = time.Now()
+	err = StoreFromContext(ctx).InsertSnapshot(ctx, snapshot)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+func captureSnapshot(ctx context.Context, projectKey string) (Snapshot, error) {
+	store := StoreFromContext(ctx)
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	overrides, err := store.GetOverridesForProject(ctx, projectKey)
+	if err != nil {
+		return Snapshot{}, errors.Wrapf(err, "unable to fetch overrides for project %s", projectKey)
+	}
+	variations, err := store.GetAvailableVariationsForProject(ctx, projectKey)
+	if err != nil {
+		return Snapshot{}, errors.Wrapf(err, "unable to fetch available variations for project %s", projectKey)
+	}
+	return Snapshot{
+		ProjectKey:          projectKey,
+		Context:             project.Context,
+		AllFlagsState:       project.AllFlagsState,
+		FlagsData:           project.FlagsData,
+		AvailableVariations: flattenVariations(variations),
+		Overrides:           overrides,
+	}, nil
+}
+
+// DiffSnapshot compares the named snapshot with the against snapshot, or with the project's live state when against
+// is empty.
+func DiffSnapshot(ctx context.Context, projectKey, name, against string) (SnapshotDiff, error) {
+	store := StoreFromContext(ctx)
+	from, err := store.GetSnapshot(ctx, projectKey, name)
+	if err != nil {
+		return SnapshotDiff{}, err
+	}
+	var to Snapshot
+	if against == "" {
+		to, err = captureSnapshot(ctx, projectKey)
+		if err != nil {
+			return SnapshotDiff{}, err
+		}
+		to.Name = LiveSnapshotName
+	} else {
+		snapshot, err := store.GetSnapshot(ctx, projectKey, against)
+		if err != nil {
+			return SnapshotDiff{}, err
+		}
+		to = *snapshot
+	}
+
+	differences := DiffSnapshots(*from, to)
+	return SnapshotDiff{
+		From:       from.Name,
+		To:         to.Name,
+		Items:      differences,
+		TotalCount: len(differences),
+	}, nil
+}
+
+// DiffSnapshots returns the differences between two snapshots: the context first, then each flag's value, active
+// override and variations, sorted by flag key.
+func DiffSnapshots(from, to Snapshot) []SnapshotDifference {
+	differences := make([]SnapshotDifference, 0)
+	fromContext, toContext := contextValue(from.Context), contextValue(to.Context)
+	if !fromContext.Equal(toContext) {
+		differences = append(differences, SnapshotDifference{
+			Field: SnapshotFieldContext,
+			From:  &fromContext,
+			To:    &toContext,
+		})
+	}
+
+	fromOverrides, toOverrides := overrideValues(from.Overrides), overrideValues(to.Overrides)
+	fromVariations, toVariations := variationValues(from.AvailableVariations), variationValues(to.AvailableVariations)
+	flagKeys := make(map[string]struct{})
+	for _, values := range []map[string]ldvalue.Value{fromOverrides, toOverrides, fromVariations, toVariations} {
+		for flagKey := range values {
+			flagKeys[flagKey] = struct{}{}
+		}
+	}
+	for _, flagsState := range []FlagsState{from.AllFlagsState, to.AllFlagsState} {
+		for flagKey := range flagsState {
+			flagKeys[flagKey] = struct{}{}
+		}
+	}
+	sortedKeys := make([]string, 0, len(flagKeys))
+	for flagKey := range flagKeys {
+		sortedKeys = append(sortedKeys, flagKey)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, flagKey := range sortedKeys {
+		var fromValue, toValue *ldvalue.Value
+		if state, ok := from.AllFlagsState[flagKey]; ok {
+			fromValue = valuePtr(state.Value)
+		}
+		if state, ok := to.AllFlagsState[flagKey]; ok {
+			toValue = valuePtr(state.Value)
+		}
+		differences = appendDifference(differences, flagKey, SnapshotFieldValue, fromValue, toValue)
+		differences = appendDifference(differences, flagKey, SnapshotFieldOverride, lookup(fromOverrides, flagKey), lookup(toOverrides, flagKey))
+		differences = appendDifference(differences, flagKey, SnapshotFieldVariations, lookup(fromVariations, flagKey), lookup(toVariations, flagKey))
+	}
+	return differences
+}
+
+func appendDifference(differences []SnapshotDifference, flagKey string, field SnapshotField, from, to *ldvalue.Value) []SnapshotDifference {
+	if from == nil && to == nil || from != nil && to != nil && from.Equal(*to) {
+		return differences
+	}
+	return append(differences, SnapshotDifference{FlagKey: flagKey, Field: field, From: from, To: to})
+}
+
+func lookup(values map[string]ldvalue.Value, flagKey string) *ldvalue.Value {
+	if value, ok := values[flagKey]; ok {
+		return &value
+	}
+	return nil
+}
+
+func contextValue(ldCtx ldcontext.Context) ldvalue.Value {
+	return ldvalue.Parse([]byte(ldCtx.JSONString()))
+}
+
+// overrideValues describes each active override by its value, or as an object that also holds its expiry and context
+// matcher when it has them.
+func overrideValues(overrides Overrides) map[string]ldvalue.Value {
+	values := make(map[string]ldvalue.Value)
+	for _, override := range overrides {
+		if !override.Active {
+			continue
+		}
+		if override.ExpiresAt.IsZero() && override.ContextMatcher == nil {
+			values[override.FlagKey] = override.Value
+			continue
+		}
+		builder := ldvalue.ObjectBuild().Set("value", override.Value)
+		if !override.ExpiresAt.IsZero() {
+			builder.SetString("expiresAt", override.ExpiresAt.UTC().Format(time.RFC3339))
+		}
+		if override.ContextMatcher != nil {
+			builder.SetString("contextMatcher", override.ContextMatcher.String())
+		}
+		values[override.FlagKey] = builder.Build()
+	}
+	return values
+}
+
+// variationValues describes each flag's available variations by their values.
+func variationValues(variations []FlagVariation) map[string]ldvalue.Value {
+	byFlagKey := make(map[string][]ldvalue.Value)
+	for _, variation := range variations {
+		byFlagKey[variation.FlagKey] = append(byFlagKey[variation.FlagKey], variation.Value)
+	}
+	values := make(map[string]ldvalue.Value, len(byFlagKey))
+	for flagKey, flagValues := range byFlagKey {
+		values[flagKey] = ldvalue.ArrayOf(flagValues...)
+	}
+	return values
+}
+
+// RestoreSnapshot puts the project back in the state the named snapshot captured. The project's context, flag state,
+// available variations and overrides are replaced in one store transaction, and connected SDKs get a single update.
+// The project's other snapshots and the other projects are left as they are.
+func RestoreSnapshot(ctx context.Context, projectKey, name string) (Snapshot, error) {
+	store := StoreFromContext(ctx)
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshot, err := store.GetSnapshot(ctx, projectKey, name)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	active, err := store.GetOverridesForProject(ctx, projectKey)
+	if err != nil {
+		return Snapshot{}, errors.Wrapf(err, "unable to fetch overrides for project %s", projectKey)
+	}
+
+	payloadVersion, err := store.RestoreSnapshot(ctx, *snapshot)
+	if err != nil {
+		return Snapshot{}, errors.Wrapf(err, "unable to restore snapshot %s", name)
+	}
+	recordHistory(ctx, projectKey, syncHistory(project.AllFlagsState, snapshot.AllFlagsState)...)
+	recordHistory(ctx, projectKey, restoreOverridesHistory(active, snapshot.Overrides)...)
+
+	project.Context = snapshot.Context
+	project.AllFlagsState = snapshot.AllFlagsState
+	project.FlagsData = snapshot.FlagsData
+	project.PayloadVersion = payloadVersion
+	allFlagsWithOverrides, err := project.GetFlagStateWithOverridesForProject(ctx)
+	if err != nil {
+		return Snapshot{}, errors.Wrapf(err, "unable to get overrides for project, %s", projectKey)
+	}
+	GetObserversFromContext(ctx).Notify(SyncEvent{
+		ProjectKey:     projectKey,
+		AllFlagsState:  allFlagsWithOverrides,
+		PayloadVersion: payloadVersion,
+	})
+	return *snapshot, nil
+}
@@ -0,0 +1,288 @@
+package model_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func TestCreateSnapshot(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+
+	projectKey := "proj"
+	project := &model.Project{
+		Key:           projectKey,
+		Context:       ldcontext.New("dev"),
+		AllFlagsState: model.FlagsState{"new-checkout": {Value: ldvalue.Bool(false), Version: 1}},
+	}
+	overrides := model.Overrides{{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true}}
+
+	t.Run("captures the project's state under the name", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(overrides, nil)
+		store.EXPECT().GetAvailableVariationsForProject(gomock.Any(), projectKey).Return(map[string][]model.Variation{
+			"new-checkout": {{Id: "1", Value: ldvalue.Bool(true)}},
+		}, nil)
+		store.EXPECT().InsertSnapshot(gomock.Any(), gomock.Any()).Return(nil)
+
+		snapshot, err := model.CreateSnapshot(ctx, projectKey, "base")
+		require.NoError(t, err)
+		assert.Equal(t, "base", snapshot.Name)
+		assert.False(t, snapshot.CreatedAt.IsZero())
+		assert.Equal(t, project.Context, snapshot.Context)
+		assert.Equal(t, project.AllFlagsState, snapshot.AllFlagsState)
+		assert.Equal(t, overrides, snapshot.Overrides)
+		assert.Equal(t, []model.FlagVariation{
+			{FlagKey: "new-checkout", Variation: model.Variation{Id: "1", Value: ldvalue.Bool(true)}},
+		}, snapshot.AvailableVariations)
+	})
+
+	t.Run("returns ErrAlreadyExists when the name is taken", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(overrides, nil)
+		store.EXPECT().GetAvailableVariationsForProject(gomock.Any(), projectKey).Return(nil, nil)
+		store.EXPECT().InsertSnapshot(gomock.Any(), gomock.Any()).Return(model.NewErrAlreadyExists("snapshot", "base"))
+
+		_, err := model.CreateSnapshot(ctx, projectKey, "base")
+		assert.ErrorAs(t, err, &model.ErrAlreadyExists{})
+	})
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	matcher, err := model.ParseContextMatcher(`user.key == "alice"`)
+	require.NoError(t, err)
+	from := model.Snapshot{
+		Context: ldcontext.New("dev"),
+		AllFlagsState: model.FlagsState{
+			"banner":       {Value: ldvalue.String("welcome"), Version: 1},
+			"new-checkout": {Value: ldvalue.Bool(false), Version: 1},
+			"retired":      {Value: ldvalue.Bool(true), Version: 1},
+		},
+		AvailableVariations: []model.FlagVariation{
+			{FlagKey: "banner", Variation: model.Variation{Id: "1", Value: ldvalue.String("welcome")}},
+		},
+		Overrides: model.Overrides{
+			{FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true},
+			{FlagKey: "banner", Value: ldvalue.String("sale"), Active: false},
+		},
+	}
+
+	t.Run("identical snapshots have no differences", func(t *testing.T) {
+		assert.Empty(t, model.DiffSnapshots(from, from))
+	})
+
+	t.Run("lists the context first, then each flag's changes by key", func(t *testing.T) {
+		to := model.Snapshot{
+			Context: ldcontext.New("ci"),
+			AllFlagsState: model.FlagsState{
+				"banner":       {Value: ldvalue.String("sale"), Version: 2},
+				"new-checkout": {Value: ldvalue.Bool(false), Version: 2},
+			},
+			AvailableVariations: []model.FlagVariation{
+				{FlagKey: "banner", Variation: model.Variation{Id: "1", Value: ldvalue.String("welcome")}},
+				{FlagKey: "banner", Variation: model.Variation{Id: "2", Value: ldvalue.String("sale")}},
+			},
+			Overrides: model.Overrides{
+				{FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true, ContextMatcher: &matcher},
+			},
+		}
+
+		differences := model.DiffSnapshots(from, to)
+
+		require.Len(t, differences, 5)
+		assert.Equal(t, model.SnapshotDifference{
+			Field: model.SnapshotFieldContext,
+			From:  valuePtr(ldvalue.ObjectBuild().SetString("kind", "user").SetString("key", "dev").Build()),
+			To:    valuePtr(ldvalue.ObjectBuild().SetString("kind", "user").SetString("key", "ci").Build()),
+		}, differences[0])
+		assert.Equal(t, model.SnapshotDifference{
+			FlagKey: "banner",
+			Field:   model.SnapshotFieldValue,
+			From:    valuePtr(ldvalue.String("welcome")),
+			To:      valuePtr(ldvalue.String("sale")),
+		}, differences[1])
+		assert.Equal(t, model.SnapshotDifference{
+			FlagKey: "banner",
+			Field:   model.SnapshotFieldVariations,
+			From:    valuePtr(ldvalue.ArrayOf(ldvalue.String("welcome"))),
+			To:      valuePtr(ldvalue.ArrayOf(ldvalue.String("welcome"), ldvalue.String("sale"))),
+		}, differences[2])
+		assert.Equal(t, model.SnapshotDifference{
+			FlagKey: "new-checkout",
+			Field:   model.SnapshotFieldOverride,
+			From:    valuePtr(ldvalue.Bool(true)),
+			To: valuePtr(ldvalue.ObjectBuild().
+				Set("value", ldvalue.Bool(true)).
+				SetString("contextMatcher", matcher.String()).
+				Build()),
+		}, differences[3])
+		assert.Equal(t, model.SnapshotDifference{
+			FlagKey: "retired",
+			Field:   model.SnapshotFieldValue,
+			From:    valuePtr(ldvalue.Bool(true)),
+		}, differences[4])
+	})
+}
+
+func TestDiffSnapshot(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+
+	projectKey := "proj"
+	base := &model.Snapshot{
+		ProjectKey:    projectKey,
+		Name:          "base",
+		Context:       ldcontext.New("dev"),
+		AllFlagsState: model.FlagsState{"new-checkout": {Value: ldvalue.Bool(false), Version: 1}},
+	}
+
+	t.Run("compares with the live state by default", func(t *testing.T) {
+		store.EXPECT().GetSnapshot(gomock.Any(), projectKey, "base").Return(base, nil)
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(&model.Project{
+			Key:           projectKey,
+			Context:       base.Context,
+			AllFlagsState: base.AllFlagsState,
+		}, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true},
+		}, nil)
+		store.EXPECT().GetAvailableVariationsForProject(gomock.Any(), projectKey).Return(nil, nil)
+
+		diff, err := model.DiffSnapshot(ctx, projectKey, "base", "")
+		require.NoError(t, err)
+		assert.Equal(t, "base", diff.From)
+		assert.Equal(t, model.LiveSnapshotName, diff.To)
+		assert.Equal(t, 1, diff.TotalCount)
+		assert.Equal(t, []model.SnapshotDifference{
+			{FlagKey: "new-checkout", Field: model.SnapshotFieldOverride, To: valuePtr(ldvalue.Bool(true))},
+		}, diff.Items)
+	})
+
+	t.Run("compares with another snapshot", func(t *testing.T) {
+		store.EXPECT().GetSnapshot(gomock.Any(), projectKey, "base").Return(base, nil)
+		store.EXPECT().GetSnapshot(gomock.Any(), projectKey, "base").Return(base, nil)
+
+		diff, err := model.DiffSnapshot(ctx, projectKey, "base", "base")
+		require.NoError(t, err)
+		assert.Equal(t, "base", diff.To)
+		assert.Empty(t, diff.Items)
+	})
+
+	t.Run("returns ErrNotFound for unknown snapshots", func(t *testing.T) {
+		store.EXPECT().GetSnapshot(gomock.Any(), projectKey, "base").Return(base, nil)
+		store.EXPECT().GetSnapshot(gomock.Any(), projectKey, "missing").Return(nil, model.NewErrNotFound("snapshot", "missing"))
+
+		_, err := model.DiffSnapshot(ctx, projectKey, "base", "missing")
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+	})
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+	observers := model.NewObservers()
+	observer := mocks.NewMockObserver(mockController)
+	observers.RegisterObserver(observer)
+	ctx = model.SetObserversOnContext(ctx, observers)
+
+	projectKey := "proj"
+	project := &model.Project{
+		Key:            projectKey,
+		Context:        ldcontext.New("ci"),
+		AllFlagsState:  model.FlagsState{"new-checkout": {Value: ldvalue.Bool(true), Version: 2}},
+		PayloadVersion: 3,
+	}
+	snapshot := &model.Snapshot{
+		ProjectKey:    projectKey,
+		Name:          "base",
+		Context:       ldcontext.New("dev"),
+		AllFlagsState: model.FlagsState{"new-checkout": {Value: ldvalue.Bool(false), Version: 1}},
+		Overrides: model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true},
+		},
+	}
+
+	t.Run("restores the snapshot, records the changes and sends a single update", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetSnapshot(gomock.Any(), projectKey, "base").Return(snapshot, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil)
+		store.EXPECT().RestoreSnapshot(gomock.Any(), *snapshot).Return(4, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).DoAndReturn(
+			func(ctx context.Context, projectKey string, entries []model.HistoryEntry) ([]model.HistoryEntry, error) {
+				assert.Equal(t, model.HistoryActionSync, entries[0].Action)
+				return entries, nil
+			})
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).DoAndReturn(
+			func(ctx context.Context, projectKey string, entries []model.HistoryEntry) ([]model.HistoryEntry, error) {
+				assert.Equal(t, model.HistoryActionOverride, entries[0].Action)
+				return entries, nil
+			})
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true, Version: 1},
+		}, nil)
+		observer.EXPECT().Handle(model.SyncEvent{
+			ProjectKey:     projectKey,
+			AllFlagsState:  model.FlagsState{"new-checkout": {Value: ldvalue.Bool(true), Version: 2, TrackEvents: true}},
+			PayloadVersion: 4,
+		})
+
+		restored, err := model.RestoreSnapshot(ctx, projectKey, "base")
+		require.NoError(t, err)
+		assert.Equal(t, "base", restored.Name)
+	})
+
+	t.Run("records the restored overrides' expiry and context matcher", func(t *testing.T) {
+		matcher, err := model.ParseContextMatcher(`user.key == "alice"`)
+		require.NoError(t, err)
+		expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		scoped := *snapshot
+		scoped.AllFlagsState = project.AllFlagsState
+		scoped.Overrides = model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true, ExpiresAt: expiresAt, ContextMatcher: &matcher},
+		}
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetSnapshot(gomock.Any(), projectKey, "scoped").Return(&scoped, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{
+			{ProjectKey: projectKey, FlagKey: "new-checkout", Value: ldvalue.Bool(true), Active: true, Version: 1},
+		}, nil)
+		store.EXPECT().RestoreSnapshot(gomock.Any(), scoped).Return(4, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).DoAndReturn(
+			func(ctx context.Context, projectKey string, entries []model.HistoryEntry) ([]model.HistoryEntry, error) {
+				assert.Equal(t, model.HistoryActionOverride, entries[0].Action)
+				assert.Equal(t, ldvalue.Bool(true), *entries[0].OldValue)
+				assert.Equal(t, model.OverrideOptions{}, entries[0].OldOptions)
+				assert.Equal(t, ldvalue.Bool(true), *entries[0].NewValue)
+				assert.Equal(t, model.OverrideOptions{ExpiresAt: expiresAt, ContextMatcher: &matcher}, entries[0].NewOptions)
+				return entries, nil
+			})
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(scoped.Overrides, nil)
+		observer.EXPECT().Handle(gomock.Any())
+
+		_, err = model.RestoreSnapshot(ctx, projectKey, "scoped")
+		require.NoError(t, err)
+	})
+
+	t.Run("returns ErrNotFound for unknown snapshots", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project, nil)
+		store.EXPECT().GetSnapshot(gomock.Any(), projectKey, "missing").Return(nil, model.NewErrNotFound("snapshot", "missing"))
+
+		_, err := model.RestoreSnapshot(ctx, projectKey, "missing")
+		assert.ErrorAs(t, err, &model.ErrNotFound{})
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
 )
 
 type ctxKey string
@@ -19,14 +22,27 @@ type Store interface {
 	// ErrNotFound is returned if there isn't an override for the flag.
 	DeactivateOverride(ctx context.Context, projectKey, flagKey string) (int, error)
 	GetDevProjectKeys(ctx context.Context) ([]string, error)
+	// GetDevProjectKeysWithPrefix returns the keys of the projects that start with prefix, sorted.
+	GetDevProjectKeysWithPrefix(ctx context.Context, prefix string) ([]string, error)
 	// GetDevProject fetches the project based on the projectKey. If it doesn't exist, ErrNotFound is returned
 	GetDevProject(ctx context.Context, projectKey string) (*Project, error)
+	// GetDevProjectKeyForCredential returns the key of the project whose source environment has the SDK key, mobile
+	// key or client-side ID, preferring the most recently synced one. If there is none, ErrNotFound is returned
+	GetDevProjectKeyForCredential(ctx context.Context, credential string) (string, error)
 	UpdateProject(ctx context.Context, project Project) (bool, error)
+	// UpdateProjects updates the projects and increments each one's payload version in a single transaction,
+	// returning the new payload versions in the order of the projects. If any of them doesn't exist, ErrNotFound is
+	// returned and none are updated.
+	UpdateProjects(ctx context.Context, projects []Project) ([]int, error)
 	DeleteDevProject(ctx context.Context, projectKey string) (bool, error)
+	// DeleteDevProjects deletes the projects in a single transaction, returning the keys of those that existed.
+	DeleteDevProjects(ctx context.Context, projectKeys []string) ([]string, error)
 	// InsertProject inserts the project. If it already exists, ErrAlreadyExists is returned
 	InsertProject(ctx context.Context, project Project) error
 	UpsertOverride(ctx context.Context, override Override) (Override, error)
 	GetOverridesForProject(ctx context.Context, projectKey string) (Overrides, error)
+	// GetExpiredOverrides returns the active overrides of every project that expired by now.
+	GetExpiredOverrides(ctx context.Context, now time.Time) (Overrides, error)
 	GetAvailableVariationsForProject(ctx context.Context, projectKey string) (map[string][]Variation, error)
 	// SetAvailableVariationsForProject replaces all stored variations for the
 	// project (used by the background fill in streaming-startup mode).
@@ -34,6 +50,40 @@ type Store interface {
 	// IncrementProjectPayloadVersion atomically increments the payload version for the project and returns the new version.
 	IncrementProjectPayloadVersion(ctx context.Context, projectKey string) (int, error)
 
+	UpsertScenario(ctx context.Context, scenario Scenario) error
+	GetScenariosForProject(ctx context.Context, projectKey string) ([]Scenario, error)
+	// GetScenario fetches the named scenario for the project. If it doesn't exist, ErrNotFound is returned
+	GetScenario(ctx context.Context, projectKey, name string) (*Scenario, error)
+	DeleteScenario(ctx context.Context, projectKey, name string) (bool, error)
+	// ReplaceOverrides makes the given values the project's only active overrides and increments the payload version
+	// in a single transaction, returning the new payload version.
+	ReplaceOverrides(ctx context.Context, projectKey string, values map[string]ldvalue.Value) (int, error)
+	// SetOverrides writes the overrides with their options, deactivating the inactive ones, and increments the payload
+	// version in a single transaction, returning the new payload version.
+	SetOverrides(ctx context.Context, projectKey string, overrides Overrides) (int, error)
+
+	// AppendHistory appends the entries to the project's history under a new change ID, returning them with their IDs.
+	AppendHistory(ctx context.Context, projectKey string, entries []HistoryEntry) ([]HistoryEntry, error)
+	// GetHistory returns the project's history, oldest first.
+	GetHistory(ctx context.Context, projectKey string) ([]HistoryEntry, error)
+
+	// InsertSnapshot stores the snapshot. If the project already has a snapshot with its name, ErrAlreadyExists is
+	// returned
+	InsertSnapshot(ctx context.Context, snapshot Snapshot) error
+	// GetSnapshotsForProject returns the project's snapshots, oldest first.
+	GetSnapshotsForProject(ctx context.Context, projectKey string) ([]Snapshot, error)
+	// GetSnapshot fetches the named snapshot of the project. If it doesn't exist, ErrNotFound is returned
+	GetSnapshot(ctx context.Context, projectKey, name string) (*Snapshot, error)
+	DeleteSnapshot(ctx context.Context, projectKey, name string) (bool, error)
+	// RestoreSnapshot replaces the project's context, flag state, available variations and overrides with the
+	// snapshot's and increments the payload version in a single transaction, returning the new payload version.
+	RestoreSnapshot(ctx context.Context, snapshot Snapshot) (int, error)
+
+	InsertWebhook(ctx context.Context, webhook Webhook) error
+	// GetWebhooks returns the project's webhooks, or every webhook if projectKey is empty, oldest first.
+	GetWebhooks(ctx context.Context, projectKey string) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) (bool, error)
+
 	CreateBackup(ctx context.Context) (io.ReadCloser, int64, error)
 	RestoreBackup(ctx context.Context, stream io.Reader) (string, error)
 }
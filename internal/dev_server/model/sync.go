@@ -19,12 +19,19 @@ type InitialProjectSettings struct {
 	Context    *ldcontext.Context   `json:"context,omitempty"`
 	Overrides  map[string]FlagValue `json:"overrides,omitempty"`
 	SyncOnce   bool
+	// File seeds the project from a local flag file instead of a LaunchDarkly environment.
+	File string
+	// WatchFile is a flag file that is applied to the project at startup, and again whenever it is saved.
+	WatchFile string
 }
 
 func CreateOrSyncProject(ctx context.Context, settings InitialProjectSettings) error {
 	if !settings.Enabled {
 		return nil
 	}
+	if settings.File != "" {
+		return seedInitialProject(ctx, settings)
+	}
 
 	log.Printf("Initial project [%s] with env [%s]", settings.ProjectKey, settings.EnvKey)
 	var project Project
@@ -63,3 +70,33 @@ func CreateOrSyncProject(ctx context.Context, settings InitialProjectSettings) e
 	log.Printf("Successfully synced Initial project [%s]", project.Key)
 	return nil
 }
+
+// seedInitialProject is the offline counterpart of CreateOrSyncProject: the project comes from a flag file and
+// LaunchDarkly is never called.
+func seedInitialProject(ctx context.Context, settings InitialProjectSettings) error {
+	log.Printf("Initial project [%s] in offline mode from file [%s]", settings.ProjectKey, settings.File)
+	if settings.SyncOnce {
+		_, err := StoreFromContext(ctx).GetDevProject(ctx, settings.ProjectKey)
+		if err == nil {
+			log.Printf("Project [%s] exists, but --sync-once flag is set, skipping reseed", settings.ProjectKey)
+			return nil
+		}
+		if !errors.As(err, &ErrNotFound{}) {
+			return err
+		}
+	}
+
+	project, err := SeedProjectFromFile(ctx, settings.ProjectKey, settings.File)
+	if err != nil {
+		return err
+	}
+	for flagKey, val := range settings.Overrides {
+		_, err := UpsertOverride(ctx, settings.ProjectKey, flagKey, val)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Successfully seeded Initial project [%s]", project.Key)
+	return nil
+}
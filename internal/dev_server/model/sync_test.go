@@ -11,7 +11,7 @@ import (
 	ldapi "github.com/launchdarkly/api-client-go/v14"
 	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
-	"github.com/launchdarkly/go-server-sdk/v7/interfaces/flagstate"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	adapters_mocks "github.com/launchdarkly/ldcli/internal/dev_server/adapters/mocks"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
@@ -30,9 +30,7 @@ func TestInitialSync(t *testing.T) {
 	sourceEnvKey := "env"
 	sdkKey := "thing"
 
-	allFlagsState := flagstate.NewAllFlagsBuilder().
-		AddFlag("boolFlag", flagstate.FlagState{Value: ldvalue.Bool(true)}).
-		Build()
+	flagsData := adapters_mocks.FlagsDataFromValues(map[string]ldvalue.Value{"boolFlag": ldvalue.Bool(true)}, 1)
 
 	trueVariationId, falseVariationId := "true", "false"
 	allFlags := []ldapi.FeatureFlag{{
@@ -64,7 +62,7 @@ func TestInitialSync(t *testing.T) {
 	})
 
 	t.Run("Returns error if it cant fetch flag state", func(t *testing.T) {
-		api.EXPECT().GetSdkKey(gomock.Any(), projKey, sourceEnvKey).Return("", errors.New("fetch flag state fails"))
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projKey, sourceEnvKey).Return(adapters.EnvironmentCredentials{}, errors.New("fetch flag state fails"))
 		input := model.InitialProjectSettings{
 			Enabled:    true,
 			ProjectKey: projKey,
@@ -78,8 +76,8 @@ func TestInitialSync(t *testing.T) {
 	})
 
 	t.Run("Returns error if it can't fetch flags", func(t *testing.T) {
-		api.EXPECT().GetSdkKey(gomock.Any(), projKey, sourceEnvKey).Return(sdkKey, nil)
-		sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), sdkKey).Return(allFlagsState, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projKey, sourceEnvKey).Return(adapters.EnvironmentCredentials{SdkKey: sdkKey}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), sdkKey).Return(flagsData, nil)
 		api.EXPECT().GetAllFlags(gomock.Any(), projKey).Return(nil, errors.New("fetch flags failed"))
 		input := model.InitialProjectSettings{
 			Enabled:    true,
@@ -94,8 +92,8 @@ func TestInitialSync(t *testing.T) {
 	})
 
 	t.Run("Returns error if it fails to insert the project", func(t *testing.T) {
-		api.EXPECT().GetSdkKey(gomock.Any(), projKey, sourceEnvKey).Return(sdkKey, nil)
-		sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), sdkKey).Return(allFlagsState, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projKey, sourceEnvKey).Return(adapters.EnvironmentCredentials{SdkKey: sdkKey}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), sdkKey).Return(flagsData, nil)
 		api.EXPECT().GetAllFlags(gomock.Any(), projKey).Return(allFlags, nil)
 		store.EXPECT().InsertProject(gomock.Any(), gomock.Any()).Return(errors.New("insert fails"))
 
@@ -112,8 +110,8 @@ func TestInitialSync(t *testing.T) {
 	})
 
 	t.Run("Successfully creates project", func(t *testing.T) {
-		api.EXPECT().GetSdkKey(gomock.Any(), projKey, sourceEnvKey).Return(sdkKey, nil)
-		sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), sdkKey).Return(allFlagsState, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projKey, sourceEnvKey).Return(adapters.EnvironmentCredentials{SdkKey: sdkKey}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), sdkKey).Return(flagsData, nil)
 		api.EXPECT().GetAllFlags(gomock.Any(), projKey).Return(allFlags, nil)
 		store.EXPECT().InsertProject(gomock.Any(), gomock.Any()).Return(nil)
 
@@ -149,13 +147,15 @@ func TestInitialSync(t *testing.T) {
 			},
 		}
 
-		api.EXPECT().GetSdkKey(gomock.Any(), projKey, sourceEnvKey).Return(sdkKey, nil)
-		sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), sdkKey).Return(allFlagsState, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projKey, sourceEnvKey).Return(adapters.EnvironmentCredentials{SdkKey: sdkKey}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), sdkKey).Return(flagsData, nil)
 		api.EXPECT().GetAllFlags(gomock.Any(), projKey).Return(allFlags, nil)
 		store.EXPECT().InsertProject(gomock.Any(), gomock.Any()).Return(nil)
 		store.EXPECT().GetDevProject(gomock.Any(), projKey).Return(&proj, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projKey).Return(model.Overrides{}, nil)
 		store.EXPECT().UpsertOverride(gomock.Any(), override).Return(override, nil)
 		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projKey).Return(1, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projKey, gomock.Len(1)).Return(nil, nil)
 
 		input := model.InitialProjectSettings{
 			Enabled:    true,
@@ -172,8 +172,8 @@ func TestInitialSync(t *testing.T) {
 	})
 
 	t.Run("If SyncOnce is set and the project already exists, return early", func(t *testing.T) {
-		api.EXPECT().GetSdkKey(gomock.Any(), projKey, sourceEnvKey).Return(sdkKey, nil)
-		sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), sdkKey).Return(allFlagsState, nil)
+		api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projKey, sourceEnvKey).Return(adapters.EnvironmentCredentials{SdkKey: sdkKey}, nil)
+		sdk.EXPECT().GetFlagsData(gomock.Any(), sdkKey).Return(flagsData, nil)
 		api.EXPECT().GetAllFlags(gomock.Any(), projKey).Return(allFlags, nil)
 		store.EXPECT().InsertProject(gomock.Any(), gomock.Any()).Return(model.NewErrAlreadyExists("project", projKey))
 
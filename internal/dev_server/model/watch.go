@@ -0,0 +1,205 @@
+package model
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+)
+
+// ApplyFlagFile brings a project in line with an edited flag file. Only flags whose value changed get a new version,
+// and connected SDKs are sent just those flags; adding or removing flags sends a full sync instead. Overrides listed
+// in the file are applied when they differ from the active ones.
+func ApplyFlagFile(ctx context.Context, projectKey, filePath string) error {
+	importData, err := ReadImportFile(filePath)
+	if err != nil {
+		return err
+	}
+	overrideOptions, err := importData.overrideOptions()
+	if err != nil {
+		return err
+	}
+	store := StoreFromContext(ctx)
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return err
+	}
+
+	flagsState := make(FlagsState, len(importData.FlagsState))
+	var changed []string
+	for flagKey, state := range importData.FlagsState {
+		current, ok := project.AllFlagsState[flagKey]
+		switch {
+		case !ok:
+			changed = append(changed, flagKey)
+		case current.Value.Equal(state.Value) && current.TrackEvents == state.TrackEvents:
+			state = current
+		default:
+			state.Version = current.Version + 1
+			changed = append(changed, flagKey)
+		}
+		flagsState[flagKey] = state
+	}
+	fullSync := len(flagsState) != len(project.AllFlagsState)
+	for flagKey := range project.AllFlagsState {
+		if _, ok := flagsState[flagKey]; !ok {
+			fullSync = true
+		}
+	}
+
+	if len(changed) == 0 && !fullSync {
+		err = store.SetAvailableVariationsForProject(ctx, projectKey, importData.flagVariations())
+		if err != nil {
+			return errors.Wrap(err, "unable to update available variations")
+		}
+	} else {
+		project.FlagsData = flagsDataForFile(project.FlagsData, flagsState, changed)
+		project.AvailableVariations = importData.flagVariations()
+		err = project.applyFlagsState(ctx, flagsState, changed, fullSync)
+		if err != nil {
+			return err
+		}
+	}
+
+	if importData.Overrides == nil {
+		return nil
+	}
+	overrides, err := store.GetOverridesForProject(ctx, projectKey)
+	if err != nil {
+		return errors.Wrapf(err, "unable to fetch overrides for project %s", projectKey)
+	}
+	for flagKey, state := range *importData.Overrides {
+		options := overrideOptions[flagKey]
+		if override, ok := overrides.GetFlag(flagKey); ok && override.Active && override.Value.Equal(state.Value) &&
+			override.ExpiresAt.Equal(options.ExpiresAt) && override.ContextMatcher.equal(options.ContextMatcher) {
+			continue
+		}
+		_, err = UpsertOverrideWithOptions(ctx, projectKey, flagKey, state.Value, options)
+		if err != nil {
+			return errors.Wrapf(err, "unable to apply override for flag %s", flagKey)
+		}
+	}
+	return nil
+}
+
+// flagsDataForFile keeps the synced configuration of the flags that the file left unchanged, so that they're still
+// evaluated per context, and serves the file's value to every context for the flags it changed or added. Projects
+// without synced configuration are left without it.
+func flagsDataForFile(flagsData adapters.FlagsData, flagsState FlagsState, changed []string) adapters.FlagsData {
+	if flagsData.Flags == nil {
+		return flagsData
+	}
+	fromFile := FlagsDataFromFlagsState(flagsState)
+	flags := make(map[string]ldmodel.FeatureFlag, len(flagsState))
+	for flagKey := range flagsState {
+		if flag, ok := flagsData.Flags[flagKey]; ok {
+			flags[flagKey] = flag
+		} else {
+			flags[flagKey] = fromFile.Flags[flagKey]
+		}
+	}
+	for _, flagKey := range changed {
+		flags[flagKey] = fromFile.Flags[flagKey]
+	}
+	return adapters.FlagsData{Flags: flags, Segments: flagsData.Segments}
+}
+
+// applyFlagsState stores the project with its new flag state, bumps the payload version and notifies observers of the
+// changed flags, or of every flag when fullSync is set.
+func (project *Project) applyFlagsState(ctx context.Context, flagsState FlagsState, changed []string, fullSync bool) error {
+	store := StoreFromContext(ctx)
+	before := project.AllFlagsState
+	project.AllFlagsState = flagsState
+	project.LastSyncTime = time.Now()
+	updated, err := store.UpdateProject(ctx, *project)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New("Project not updated")
+	}
+	project.PayloadVersion, err = store.IncrementProjectPayloadVersion(ctx, project.Key)
+	if err != nil {
+		return errors.Wrap(err, "unable to increment payload version")
+	}
+	recordHistory(ctx, project.Key, syncHistory(before, flagsState)...)
+
+	allFlagsWithOverrides, err := project.GetFlagStateWithOverridesForProject(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get overrides for project, %s", project.Key)
+	}
+	observers := GetObserversFromContext(ctx)
+	if fullSync {
+		observers.Notify(SyncEvent{
+			ProjectKey:     project.Key,
+			AllFlagsState:  allFlagsWithOverrides,
+			PayloadVersion: project.PayloadVersion,
+		})
+		return nil
+	}
+	for _, flagKey := range changed {
+		observers.Notify(OverrideEvent{
+			FlagKey:        flagKey,
+			ProjectKey:     project.Key,
+			FlagState:      allFlagsWithOverrides[flagKey],
+			PayloadVersion: project.PayloadVersion,
+		})
+	}
+	return nil
+}
+
+// WatchFlagFile applies every change to the flag file to the project until ctx is done. The file's directory is
+// watched rather than the file itself so that editors which save by replacing the file are picked up.
+func WatchFlagFile(ctx context.Context, projectKey, filePath string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "unable to create file watcher")
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		_ = watcher.Close()
+		return errors.Wrapf(err, "unable to resolve %s", filePath)
+	}
+	err = watcher.Add(filepath.Dir(absPath))
+	if err != nil {
+		_ = watcher.Close()
+		return errors.Wrapf(err, "unable to watch %s", filePath)
+	}
+
+	go func() {
+		defer func() {
+			_ = watcher.Close()
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != absPath || !event.Has(fsnotify.Write|fsnotify.Create) {
+					continue
+				}
+				// Partial writes can fail to parse; the next write event will apply the finished file.
+				if err := ApplyFlagFile(ctx, projectKey, absPath); err != nil {
+					log.Printf("watch-file: unable to apply %s to project %s: %v", filePath, projectKey, err)
+					continue
+				}
+				log.Printf("watch-file: applied %s to project %s", filePath, projectKey)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("watch-file: error watching %s: %v", filePath, err)
+			}
+		}
+	}()
+	return nil
+}
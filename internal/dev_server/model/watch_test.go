@@ -0,0 +1,176 @@
+package model_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func TestApplyFlagFile(t *testing.T) {
+	ctx := context.Background()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+	observers := model.NewObservers()
+	observer := mocks.NewMockObserver(mockController)
+	observers.RegisterObserver(observer)
+	ctx = model.SetObserversOnContext(ctx, observers)
+
+	projectKey := "offline-proj"
+	project := func() *model.Project {
+		return &model.Project{
+			Key: projectKey,
+			AllFlagsState: model.FlagsState{
+				"new-checkout": {Value: ldvalue.Bool(false), Version: 3},
+				"banner":       {Value: ldvalue.String("welcome"), Version: 2},
+			},
+			PayloadVersion: 4,
+		}
+	}
+	path := writeFlagFile(t, "flags.yaml", offlineFlagFile)
+
+	t.Run("sends only the flags whose value changed", func(t *testing.T) {
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(project(), nil)
+		store.EXPECT().UpdateProject(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, project model.Project) (bool, error) {
+			assert.Equal(t, model.FlagsState{
+				"new-checkout": {Value: ldvalue.Bool(true), Version: 4},
+				"banner":       {Value: ldvalue.String("welcome"), Version: 2},
+			}, project.AllFlagsState)
+			return true, nil
+		})
+		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projectKey).Return(5, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).Return(nil, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil)
+		observer.EXPECT().Handle(model.OverrideEvent{
+			FlagKey:        "new-checkout",
+			ProjectKey:     projectKey,
+			FlagState:      model.FlagState{Value: ldvalue.Bool(true), Version: 4},
+			PayloadVersion: 5,
+		})
+
+		require.NoError(t, model.ApplyFlagFile(ctx, projectKey, path))
+	})
+
+	t.Run("keeps the synced configuration of the flags the file didn't change", func(t *testing.T) {
+		synced := project()
+		bannerFlag := ldmodel.FeatureFlag{Key: "banner", On: true, Version: 2, Variations: []ldvalue.Value{ldvalue.String("welcome")}}
+		synced.FlagsData = adapters.FlagsData{
+			Flags: map[string]ldmodel.FeatureFlag{
+				"new-checkout": {Key: "new-checkout", On: true, Version: 3, Variations: []ldvalue.Value{ldvalue.Bool(false)}},
+				"banner":       bannerFlag,
+			},
+			Segments: map[string]ldmodel.Segment{"beta": {Key: "beta"}},
+		}
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(synced, nil)
+		store.EXPECT().UpdateProject(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, project model.Project) (bool, error) {
+			assert.Equal(t, bannerFlag, project.FlagsData.Flags["banner"])
+			assert.Equal(t, []ldvalue.Value{ldvalue.Bool(true)}, project.FlagsData.Flags["new-checkout"].Variations)
+			assert.Contains(t, project.FlagsData.Segments, "beta")
+			return true, nil
+		})
+		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projectKey).Return(5, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).Return(nil, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil)
+		observer.EXPECT().Handle(gomock.AssignableToTypeOf(model.OverrideEvent{}))
+
+		require.NoError(t, model.ApplyFlagFile(ctx, projectKey, path))
+	})
+
+	t.Run("sends a full sync when flags are added or removed", func(t *testing.T) {
+		withExtraFlag := project()
+		withExtraFlag.AllFlagsState["new-checkout"] = model.FlagState{Value: ldvalue.Bool(true), Version: 3}
+		withExtraFlag.AllFlagsState["retired-flag"] = model.FlagState{Value: ldvalue.Bool(true), Version: 1}
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(withExtraFlag, nil)
+		store.EXPECT().UpdateProject(gomock.Any(), gomock.Any()).Return(true, nil)
+		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projectKey).Return(5, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).Return(nil, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil)
+		observer.EXPECT().Handle(model.SyncEvent{
+			ProjectKey: projectKey,
+			AllFlagsState: model.FlagsState{
+				"new-checkout": {Value: ldvalue.Bool(true), Version: 3},
+				"banner":       {Value: ldvalue.String("welcome"), Version: 2},
+			},
+			PayloadVersion: 5,
+		})
+
+		require.NoError(t, model.ApplyFlagFile(ctx, projectKey, path))
+	})
+
+	t.Run("only refreshes variations when no value changed", func(t *testing.T) {
+		unchanged := project()
+		unchanged.AllFlagsState["new-checkout"] = model.FlagState{Value: ldvalue.Bool(true), Version: 3}
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(unchanged, nil)
+		store.EXPECT().SetAvailableVariationsForProject(gomock.Any(), projectKey, gomock.Len(3)).Return(nil)
+
+		require.NoError(t, model.ApplyFlagFile(ctx, projectKey, path))
+	})
+
+	t.Run("applies overrides from the file that differ from the active ones", func(t *testing.T) {
+		withOverrides := writeFlagFile(t, "overrides.json", `{
+			"flagsState": {"banner": {"value": "welcome", "version": 2}},
+			"overrides": {"banner": {"value": "sale"}}
+		}`)
+		stored := &model.Project{
+			Key:            projectKey,
+			AllFlagsState:  model.FlagsState{"banner": {Value: ldvalue.String("welcome"), Version: 2}},
+			PayloadVersion: 4,
+		}
+		store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(stored, nil).Times(2)
+		store.EXPECT().SetAvailableVariationsForProject(gomock.Any(), projectKey, gomock.Any()).Return(nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil).Times(2)
+		store.EXPECT().UpsertOverride(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, override model.Override) (model.Override, error) {
+			assert.Equal(t, ldvalue.String("sale"), override.Value)
+			return override, nil
+		})
+		store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projectKey).Return(5, nil)
+		store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Len(1)).Return(nil, nil)
+		observer.EXPECT().Handle(gomock.Any())
+
+		require.NoError(t, model.ApplyFlagFile(ctx, projectKey, withOverrides))
+	})
+}
+
+func TestWatchFlagFile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx = model.ContextWithStore(ctx, store)
+	ctx = model.SetObserversOnContext(ctx, model.NewObservers())
+
+	projectKey := "offline-proj"
+	path := writeFlagFile(t, "flags.yaml", offlineFlagFile)
+	require.NoError(t, model.WatchFlagFile(ctx, projectKey, path))
+
+	applied := make(chan struct{}, 10)
+	store.EXPECT().GetDevProject(gomock.Any(), projectKey).Return(&model.Project{Key: projectKey}, nil).AnyTimes()
+	store.EXPECT().UpdateProject(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, project model.Project) (bool, error) {
+		if _, ok := project.AllFlagsState["banner"]; ok {
+			applied <- struct{}{}
+		}
+		return true, nil
+	}).AnyTimes()
+	store.EXPECT().IncrementProjectPayloadVersion(gomock.Any(), projectKey).Return(2, nil).AnyTimes()
+	store.EXPECT().GetOverridesForProject(gomock.Any(), projectKey).Return(model.Overrides{}, nil).AnyTimes()
+	store.EXPECT().AppendHistory(gomock.Any(), projectKey, gomock.Any()).Return(nil, nil).AnyTimes()
+
+	require.NoError(t, os.WriteFile(path, []byte(offlineFlagFile), 0o600))
+
+	select {
+	case <-applied:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the flag file change to be applied")
+	}
+}
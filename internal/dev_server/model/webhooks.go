@@ -0,0 +1,346 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Webhook is a URL that the dev server posts a project's state changes to.
+type Webhook struct {
+	ID         string
+	ProjectKey string
+	URL        string
+	// Secret is the key that deliveries are signed with.
+	Secret    string
+	CreatedAt time.Time
+}
+
+// NewWebhook validates rawURL and returns a webhook for the project with a new ID. A secret is generated if none is
+// given.
+func NewWebhook(projectKey, rawURL, secret string) (Webhook, error) {
+	if projectKey == "" {
+		return Webhook{}, errors.New("project key is required")
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return Webhook{}, errors.Errorf("webhook URL %q must be an absolute http or https URL", rawURL)
+	}
+	if secret == "" {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return Webhook{}, errors.Wrap(err, "unable to generate webhook secret")
+		}
+		secret = hex.EncodeToString(key)
+	}
+	return Webhook{
+		ID:         uuid.New().String(),
+		ProjectKey: projectKey,
+		URL:        rawURL,
+		Secret:     secret,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// WebhookEventKind is the kind of state change a webhook delivery is about.
+type WebhookEventKind string
+
+const (
+	WebhookEventOverride       WebhookEventKind = "override"
+	WebhookEventSync           WebhookEventKind = "sync"
+	WebhookEventImport         WebhookEventKind = "import"
+	WebhookEventProjectDeleted WebhookEventKind = "project-deleted"
+)
+
+// WebhookPayload is the body of a webhook delivery. Override events have the flag's key and state; sync and import
+// events have the state of all of the project's flags.
+type WebhookPayload struct {
+	ID             string           `json:"id"`
+	Kind           WebhookEventKind `json:"kind"`
+	ProjectKey     string           `json:"projectKey"`
+	PayloadVersion int              `json:"payloadVersion,omitempty"`
+	FlagKey        string           `json:"flagKey,omitempty"`
+	FlagState      *FlagState       `json:"flagState,omitempty"`
+	FlagsState     FlagsState       `json:"flagsState,omitempty"`
+	Timestamp      time.Time        `json:"timestamp"`
+}
+
+const (
+	// WebhookSignatureHeader has the hex HMAC-SHA256 of the delivery's body, keyed with the webhook's secret and
+	// prefixed with "sha256=".
+	WebhookSignatureHeader = "X-LDCLI-Signature"
+	WebhookEventHeader     = "X-LDCLI-Event"
+	WebhookDeliveryHeader  = "X-LDCLI-Delivery"
+
+	// maxQueuedWebhookEvents is how many events can wait to be queued for their webhooks, and how many deliveries
+	// can wait for each webhook.
+	maxQueuedWebhookEvents        = 1000
+	maxWebhookDeliveryAttempts    = 3
+	webhookDeliveryRequestTimeout = 10 * time.Second
+)
+
+// SignWebhookPayload returns the signature header value of a delivery with the body.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookDispatcher is an Observer that posts the state changes of projects to their webhooks. Events are queued as
+// they are observed, and Run hands each one to its project's webhooks. Every webhook has a queue and a worker of its
+// own, so that it sees a project's changes in the order they were made without a slow webhook holding up the others.
+// Deliveries that fail are retried with backoff; a webhook that falls too far behind has its new events dropped until
+// it catches up. A deleted project's webhooks are removed once they've been sent its deletion.
+type WebhookDispatcher struct {
+	httpClient *http.Client
+	// RetryBackoff is the wait before the first retry of a delivery, doubled for each retry after it.
+	RetryBackoff time.Duration
+
+	events  chan WebhookPayload
+	mu      sync.Mutex
+	dropped int
+
+	// queues are the queues of the webhooks that have been sent events, by webhook ID. Only Run uses them.
+	queues map[string]*webhookQueue
+}
+
+func NewWebhookDispatcher() *WebhookDispatcher {
+	return &WebhookDispatcher{
+		httpClient:   &http.Client{Timeout: webhookDeliveryRequestTimeout},
+		RetryBackoff: time.Second,
+		events:       make(chan WebhookPayload, maxQueuedWebhookEvents),
+		queues:       make(map[string]*webhookQueue),
+	}
+}
+
+func (d *WebhookDispatcher) Handle(event interface{}) {
+	payload, ok := webhookPayloadFor(event)
+	if !ok {
+		return
+	}
+	select {
+	case d.events <- payload:
+	default:
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.dropped == 0 {
+			log.Printf("webhooks: too many events are waiting, dropping events until they're queued")
+		}
+		d.dropped++
+	}
+}
+
+func webhookPayloadFor(event interface{}) (WebhookPayload, bool) {
+	payload := WebhookPayload{ID: uuid.New().String(), Timestamp: time.Now()}
+	switch event := event.(type) {
+	case OverrideEvent:
+		payload.Kind = WebhookEventOverride
+		payload.ProjectKey = event.ProjectKey
+		payload.PayloadVersion = event.PayloadVersion
+		payload.FlagKey = event.FlagKey
+		flagState := event.FlagState
+		payload.FlagState = &flagState
+	case SyncEvent:
+		payload.Kind = WebhookEventSync
+		payload.ProjectKey = event.ProjectKey
+		payload.PayloadVersion = event.PayloadVersion
+		payload.FlagsState = event.AllFlagsState
+	case ImportEvent:
+		payload.Kind = WebhookEventImport
+		payload.ProjectKey = event.ProjectKey
+		payload.PayloadVersion = event.PayloadVersion
+		payload.FlagsState = event.AllFlagsState
+	case ProjectDeletedEvent:
+		payload.Kind = WebhookEventProjectDeleted
+		payload.ProjectKey = event.ProjectKey
+	default:
+		return WebhookPayload{}, false
+	}
+	return payload, true
+}
+
+// Run queues events for their webhooks until ctx is done.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case payload := <-d.events:
+			d.mu.Lock()
+			if d.dropped > 0 {
+				log.Printf("webhooks: dropped %d events while too many were waiting", d.dropped)
+				d.dropped = 0
+			}
+			d.mu.Unlock()
+			d.dispatch(ctx, payload)
+		}
+	}
+}
+
+// dispatch queues the payload for each of its project's webhooks, starting workers for the webhooks that don't have
+// one yet and stopping those of the project's webhooks that were removed.
+func (d *WebhookDispatcher) dispatch(ctx context.Context, payload WebhookPayload) {
+	store := StoreFromContext(ctx)
+	webhooks, err := store.GetWebhooks(ctx, payload.ProjectKey)
+	if err != nil {
+		log.Printf("webhooks: unable to get webhooks for project %s: %v", payload.ProjectKey, err)
+		return
+	}
+	registered := make(map[string]bool, len(webhooks))
+	for _, webhook := range webhooks {
+		registered[webhook.ID] = true
+	}
+	for id, queue := range d.queues {
+		if queue.webhook.ProjectKey == payload.ProjectKey && !registered[id] {
+			d.stop(id)
+		}
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("webhooks: unable to marshal %s event for project %s: %v", payload.Kind, payload.ProjectKey, err)
+		return
+	}
+	for _, webhook := range webhooks {
+		queue, ok := d.queues[webhook.ID]
+		if !ok {
+			queue = &webhookQueue{webhook: webhook, deliveries: make(chan webhookDelivery, maxQueuedWebhookEvents)}
+			d.queues[webhook.ID] = queue
+			go d.deliverQueued(ctx, queue)
+		}
+		queue.push(webhookDelivery{payload: payload, body: body})
+	}
+
+	if payload.Kind == WebhookEventProjectDeleted {
+		for _, webhook := range webhooks {
+			if _, err := store.DeleteWebhook(ctx, webhook.ID); err != nil {
+				log.Printf("webhooks: unable to remove webhook %s of deleted project %s: %v", webhook.ID, payload.ProjectKey, err)
+			}
+			d.stop(webhook.ID)
+		}
+	}
+}
+
+// stop closes the webhook's queue, so that its worker exits once it has delivered what was queued.
+func (d *WebhookDispatcher) stop(id string) {
+	close(d.queues[id].deliveries)
+	delete(d.queues, id)
+}
+
+// deliverQueued delivers the webhook's queued payloads in order until its queue is closed or ctx is done.
+func (d *WebhookDispatcher) deliverQueued(ctx context.Context, queue *webhookQueue) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery, ok := <-queue.deliveries:
+			if !ok {
+				return
+			}
+			if dropped := queue.takeDropped(); dropped > 0 {
+				log.Printf("webhooks: dropped %d events for %s while it was behind", dropped, queue.webhook.URL)
+			}
+			err := d.deliver(ctx, queue.webhook, delivery.payload, delivery.body)
+			if err != nil {
+				log.Printf("webhooks: unable to deliver %s event for project %s to %s: %v",
+					delivery.payload.Kind, delivery.payload.ProjectKey, queue.webhook.URL, err)
+			}
+		}
+	}
+}
+
+type webhookDelivery struct {
+	payload WebhookPayload
+	body    []byte
+}
+
+// webhookQueue holds the deliveries waiting for a webhook.
+type webhookQueue struct {
+	webhook    Webhook
+	deliveries chan webhookDelivery
+
+	mu      sync.Mutex
+	dropped int
+}
+
+func (q *webhookQueue) push(delivery webhookDelivery) {
+	select {
+	case q.deliveries <- delivery:
+	default:
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if q.dropped == 0 {
+			log.Printf("webhooks: %s is %d events behind, dropping its events until it catches up", q.webhook.URL, maxQueuedWebhookEvents)
+		}
+		q.dropped++
+	}
+}
+
+func (q *webhookQueue) takeDropped() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	dropped := q.dropped
+	q.dropped = 0
+	return dropped
+}
+
+// errDeliveryRejected is returned for deliveries that the webhook refused and that would be refused again if retried.
+var errDeliveryRejected = errors.New("delivery was rejected")
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, webhook Webhook, payload WebhookPayload, body []byte) error {
+	signature := SignWebhookPayload(webhook.Secret, body)
+	backoff := d.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := d.post(ctx, webhook, payload, signature, body)
+		if err == nil || errors.Is(err, errDeliveryRejected) || attempt == maxWebhookDeliveryAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (d *WebhookDispatcher) post(ctx context.Context, webhook Webhook, payload WebhookPayload, signature string, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(errDeliveryRejected, err.Error())
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookSignatureHeader, signature)
+	request.Header.Set(WebhookEventHeader, string(payload.Kind))
+	// The delivery ID stays the same across retries so that receivers can drop duplicates.
+	request.Header.Set(WebhookDeliveryHeader, payload.ID)
+
+	response, err := d.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+	switch {
+	case response.StatusCode < 300:
+		return nil
+	case response.StatusCode >= 500, response.StatusCode == http.StatusTooManyRequests, response.StatusCode == http.StatusRequestTimeout:
+		return errors.Errorf("webhook responded %s", response.Status)
+	default:
+		return errors.Wrapf(errDeliveryRejected, "webhook responded %s", response.Status)
+	}
+}
@@ -0,0 +1,205 @@
+package model_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+type receivedDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookReceiver answers deliveries with the given statuses in turn and 204 once they run out.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	received []receivedDelivery
+}
+
+func (r *webhookReceiver) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, receivedDelivery{header: request.Header, body: body})
+	status := http.StatusNoContent
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	writer.WriteHeader(status)
+}
+
+// deliveries returns the deliveries received so far.
+func (r *webhookReceiver) deliveries() []receivedDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedDelivery(nil), r.received...)
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	ctx := model.ContextWithStore(context.Background(), store)
+
+	newWebhook := func(t *testing.T, handler http.Handler) model.Webhook {
+		server := httptest.NewServer(handler)
+		t.Cleanup(server.Close)
+		webhook, err := model.NewWebhook("proj", server.URL, "secret")
+		require.NoError(t, err)
+		return webhook
+	}
+	newReceiver := func(t *testing.T, statuses ...int) (model.Webhook, *webhookReceiver) {
+		receiver := &webhookReceiver{statuses: statuses}
+		return newWebhook(t, receiver), receiver
+	}
+	runDispatcher := func(t *testing.T) *model.WebhookDispatcher {
+		dispatcher := model.NewWebhookDispatcher()
+		dispatcher.RetryBackoff = time.Millisecond
+		ctx, cancel := context.WithCancel(ctx)
+		t.Cleanup(cancel)
+		go dispatcher.Run(ctx)
+		return dispatcher
+	}
+	receives := func(t *testing.T, receiver *webhookReceiver, count int) {
+		require.Eventually(t, func() bool {
+			return len(receiver.deliveries()) == count
+		}, time.Second, time.Millisecond)
+	}
+	overrideEvent := model.OverrideEvent{
+		FlagKey:        "flag",
+		ProjectKey:     "proj",
+		FlagState:      model.FlagState{Value: ldvalue.Bool(true), Version: 2},
+		PayloadVersion: 3,
+	}
+
+	t.Run("posts signed events to the project's webhooks", func(t *testing.T) {
+		webhook, receiver := newReceiver(t)
+		store.EXPECT().GetWebhooks(gomock.Any(), "proj").Return([]model.Webhook{webhook}, nil)
+
+		runDispatcher(t).Handle(overrideEvent)
+
+		receives(t, receiver, 1)
+		delivery := receiver.deliveries()[0]
+		assert.Equal(t, "override", delivery.header.Get(model.WebhookEventHeader))
+		assert.Equal(t, model.SignWebhookPayload("secret", delivery.body), delivery.header.Get(model.WebhookSignatureHeader))
+		assert.NotEmpty(t, delivery.header.Get(model.WebhookDeliveryHeader))
+
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(delivery.body, &payload))
+		assert.Equal(t, "proj", payload["projectKey"])
+		assert.Equal(t, "flag", payload["flagKey"])
+		assert.Equal(t, float64(3), payload["payloadVersion"])
+		assert.Equal(t, map[string]interface{}{"value": true, "version": float64(2), "trackEvents": false}, payload["flagState"])
+	})
+
+	t.Run("retries deliveries that fail with the same delivery ID", func(t *testing.T) {
+		webhook, receiver := newReceiver(t, http.StatusServiceUnavailable, http.StatusInternalServerError)
+		store.EXPECT().GetWebhooks(gomock.Any(), "proj").Return([]model.Webhook{webhook}, nil)
+
+		runDispatcher(t).Handle(overrideEvent)
+
+		receives(t, receiver, 3)
+		deliveries := receiver.deliveries()
+		assert.NotEmpty(t, deliveries[0].header.Get(model.WebhookDeliveryHeader))
+		for _, delivery := range deliveries {
+			assert.Equal(t, deliveries[0].header.Get(model.WebhookDeliveryHeader), delivery.header.Get(model.WebhookDeliveryHeader))
+		}
+	})
+
+	t.Run("doesn't retry deliveries that the webhook rejects", func(t *testing.T) {
+		webhook, receiver := newReceiver(t, http.StatusBadRequest)
+		store.EXPECT().GetWebhooks(gomock.Any(), "proj").Return([]model.Webhook{webhook}, nil).Times(2)
+
+		dispatcher := runDispatcher(t)
+		dispatcher.Handle(overrideEvent)
+		dispatcher.Handle(overrideEvent)
+
+		receives(t, receiver, 2)
+	})
+
+	t.Run("delivers each webhook's events in order without waiting for the other webhooks", func(t *testing.T) {
+		release := make(chan struct{})
+		slow := &webhookReceiver{}
+		slowWebhook := newWebhook(t, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			<-release
+			slow.ServeHTTP(writer, request)
+		}))
+		t.Cleanup(func() { close(release) })
+		fastWebhook, fast := newReceiver(t)
+		store.EXPECT().GetWebhooks(gomock.Any(), "proj").Return([]model.Webhook{slowWebhook, fastWebhook}, nil).Times(3)
+
+		dispatcher := runDispatcher(t)
+		for version := 1; version <= 3; version++ {
+			event := overrideEvent
+			event.PayloadVersion = version
+			dispatcher.Handle(event)
+		}
+
+		receives(t, fast, 3)
+		for i, delivery := range fast.deliveries() {
+			var payload model.WebhookPayload
+			require.NoError(t, json.Unmarshal(delivery.body, &payload))
+			assert.Equal(t, i+1, payload.PayloadVersion)
+		}
+		assert.Empty(t, slow.deliveries())
+	})
+
+	t.Run("removes a deleted project's webhooks once they've been sent its deletion", func(t *testing.T) {
+		webhook, receiver := newReceiver(t)
+		store.EXPECT().GetWebhooks(gomock.Any(), "proj").Return([]model.Webhook{webhook}, nil)
+		deleted := make(chan struct{})
+		store.EXPECT().DeleteWebhook(gomock.Any(), webhook.ID).DoAndReturn(func(ctx context.Context, id string) (bool, error) {
+			close(deleted)
+			return true, nil
+		})
+
+		runDispatcher(t).Handle(model.ProjectDeletedEvent{ProjectKey: "proj"})
+
+		receives(t, receiver, 1)
+		assert.Equal(t, "project-deleted", receiver.deliveries()[0].header.Get(model.WebhookEventHeader))
+		require.Eventually(t, func() bool {
+			select {
+			case <-deleted:
+				return true
+			default:
+				return false
+			}
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("ignores events that aren't state changes", func(t *testing.T) {
+		dispatcher := model.NewWebhookDispatcher()
+		dispatcher.Handle(json.RawMessage(`{"kind":"custom"}`))
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		dispatcher.Run(ctx)
+	})
+}
+
+func TestNewWebhook(t *testing.T) {
+	webhook, err := model.NewWebhook("proj", "https://example.com/hook", "")
+	require.NoError(t, err)
+	assert.NotEmpty(t, webhook.ID)
+	assert.Len(t, webhook.Secret, 64)
+
+	_, err = model.NewWebhook("proj", "example.com/hook", "")
+	assert.Error(t, err)
+
+	_, err = model.NewWebhook("", "https://example.com/hook", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/pkg/errors"
+)
+
+// GetContextFromRequest reads the evaluation context a client-side or mobile SDK sends: the base64url-encoded
+// {context} path segment on GET requests, or the request body on REPORT requests. It returns nil if the request
+// doesn't carry a context.
+func GetContextFromRequest(r *http.Request) (*ldcontext.Context, error) {
+	var contextJson []byte
+	if encoded, ok := mux.Vars(r)["context"]; ok {
+		decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to decode context")
+		}
+		contextJson = decoded
+	} else if r.Method == "REPORT" {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read context")
+		}
+		contextJson = body
+	}
+	if len(contextJson) == 0 {
+		return nil, nil
+	}
+	var ldCtx ldcontext.Context
+	if err := json.Unmarshal(contextJson, &ldCtx); err != nil {
+		return nil, errors.Wrap(err, "unable to parse context")
+	}
+	return &ldCtx, nil
+}
@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+const connectionIDContextKey = ctxKey("connectionID")
+
+// TrackConnections registers the SDKs that the handler serves in the connection registry: streams while their request
+// is open, and polling SDKs with each poll. Requests for projects that don't exist aren't registered.
+func TrackConnections(kind model.ConnectionKind) mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			connections := model.ConnectionsFromContext(request.Context())
+			if connections == nil || request.Method == http.MethodOptions {
+				handler.ServeHTTP(writer, request)
+				return
+			}
+			connection, ok := connectionFromRequest(request)
+			if !ok {
+				handler.ServeHTTP(writer, request)
+				return
+			}
+			switch kind {
+			case model.StreamingConnection:
+				id, closed := connections.StreamOpened(connection)
+				defer closed()
+				request = request.WithContext(context.WithValue(request.Context(), connectionIDContextKey, id))
+			case model.PollingConnection:
+				connections.Polled(connection)
+			}
+			handler.ServeHTTP(writer, request)
+		})
+	}
+}
+
+func connectionFromRequest(request *http.Request) (model.Connection, bool) {
+	ctx := request.Context()
+	projectKey := GetProjectKeyFromContext(ctx)
+	project, err := model.StoreFromContext(ctx).GetDevProject(ctx, projectKey)
+	if err != nil {
+		if !errors.As(err, &model.ErrNotFound{}) {
+			log.Printf("unable to get project %s to register an SDK connection: %v", projectKey, err)
+		}
+		return model.Connection{}, false
+	}
+	endpoint := request.URL.Path
+	if route := mux.CurrentRoute(request); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			endpoint = template
+		}
+	}
+	// Browser SDKs can't set User-Agent, so they send theirs in a header of their own.
+	userAgent := request.Header.Get("X-LaunchDarkly-User-Agent")
+	if userAgent == "" {
+		userAgent = request.Header.Get("User-Agent")
+	}
+	payloadVersion := project.PayloadVersion
+	return model.Connection{
+		ProjectKey:         projectKey,
+		Endpoint:           endpoint,
+		UserAgent:          userAgent,
+		Wrapper:            request.Header.Get("X-LaunchDarkly-Wrapper"),
+		RemoteAddr:         request.RemoteAddr,
+		LastPayloadVersion: &payloadVersion,
+	}, true
+}
+
+// streamHeartbeatSent records a heartbeat of the stream in the connection registry, if the stream is registered.
+func streamHeartbeatSent(ctx context.Context) {
+	id, ok := ctx.Value(connectionIDContextKey).(string)
+	if !ok {
+		return
+	}
+	model.ConnectionsFromContext(ctx).Heartbeat(id)
+}
+
+// streamPayloadSent records the payload version of flags written to the stream in the connection registry, if the
+// stream is registered.
+func streamPayloadSent(ctx context.Context, payloadVersion int) {
+	id, ok := ctx.Value(connectionIDContextKey).(string)
+	if !ok {
+		return
+	}
+	model.ConnectionsFromContext(ctx).PayloadSent(id, payloadVersion)
+}
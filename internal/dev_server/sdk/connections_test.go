@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func TestTrackConnections(t *testing.T) {
+	store := mocks.NewMockStore(gomock.NewController(t))
+	store.EXPECT().GetDevProjectKeyForCredential(gomock.Any(), gomock.Any()).
+		Return("", model.NewErrNotFound("project", "for credential")).AnyTimes()
+	store.EXPECT().GetDevProject(gomock.Any(), "proj").Return(&model.Project{Key: "proj", PayloadVersion: 4}, nil).AnyTimes()
+	store.EXPECT().GetDevProject(gomock.Any(), "missing").Return(nil, model.NewErrNotFound("project", "missing")).AnyTimes()
+
+	connections := model.NewConnections()
+	var listedWhileStreaming []model.Connection
+	router := mux.NewRouter()
+	router.Use(model.StoreMiddleware(store))
+	router.Use(model.ConnectionsMiddleware(connections))
+	router.Use(SetProjectKeyWhenPresent(credentialFromAuthorizationHeader))
+	router.Handle("/stream/{context}", TrackConnections(model.StreamingConnection)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listedWhileStreaming = connections.List("")
+	})))
+	var versionWhileStreaming int
+	router.Handle("/updates", TrackConnections(model.StreamingConnection)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		updateChan, errChan := OpenStream(ctx, w, Message{Event: TYPE_PUT, Data: []byte(`{}`)}.ToPayload())
+		defer close(updateChan)
+		require.NoError(t, SendMessage(updateChan, 5, TYPE_PUT, map[string]any{}))
+		require.Eventually(t, func() bool {
+			listed := connections.List("proj")
+			if len(listed) != 1 || *listed[0].LastPayloadVersion != 5 {
+				return false
+			}
+			versionWhileStreaming = *listed[0].LastPayloadVersion
+			return true
+		}, time.Second, time.Millisecond)
+		cancel()
+		<-errChan
+	})))
+	router.Handle("/poll", TrackConnections(model.PollingConnection)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+	request := func(path, projectKey string) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", projectKey)
+		req.Header.Set("User-Agent", "GoClient/7.0.0")
+		req.Header.Set("X-LaunchDarkly-Wrapper", "OpenFeature/1.0")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	t.Run("registers streams while they're open", func(t *testing.T) {
+		request("/stream/eyJrZXkiOiJhIn0", "proj")
+
+		require.Len(t, listedWhileStreaming, 1)
+		connection := listedWhileStreaming[0]
+		assert.Equal(t, "proj", connection.ProjectKey)
+		assert.Equal(t, "/stream/{context}", connection.Endpoint)
+		assert.Equal(t, model.StreamingConnection, connection.Kind)
+		assert.Equal(t, "GoClient/7.0.0", connection.UserAgent)
+		assert.Equal(t, "OpenFeature/1.0", connection.Wrapper)
+		assert.NotEmpty(t, connection.RemoteAddr)
+		require.NotNil(t, connection.LastPayloadVersion)
+		assert.Equal(t, 4, *connection.LastPayloadVersion)
+		assert.Empty(t, connections.List(""))
+	})
+
+	t.Run("records the payload versions written to streams", func(t *testing.T) {
+		request("/updates", "proj")
+
+		assert.Equal(t, 5, versionWhileStreaming)
+		assert.Empty(t, connections.List(""))
+	})
+
+	t.Run("registers polls", func(t *testing.T) {
+		request("/poll", "proj")
+		request("/poll", "proj")
+
+		listed := connections.List("proj")
+		require.Len(t, listed, 1)
+		assert.Equal(t, model.PollingConnection, listed[0].Kind)
+		assert.NotNil(t, listed[0].LastHeartbeat)
+	})
+
+	t.Run("doesn't register requests for projects that don't exist", func(t *testing.T) {
+		request("/poll", "missing")
+
+		assert.Empty(t, connections.List("missing"))
+	})
+}
@@ -8,7 +8,7 @@
 //	❌ 	/flags	GET	stream.	SSE stream for flag data (older SDKs)
 //	✅ 	/sdk/flags	GET	sdk.	Polling endpoint for PHP SDK
 //	✅ 	/sdk/flags/{flagKey}	GET	sdk.	Polling endpoint for PHP SDK
-//	✅	/sdk/segments/{segmentKey}	GET	sdk.	Polling endpoint for PHP SDK (Segments are not used in dev server, so this always 404s)
+//	✅	/sdk/segments/{segmentKey}	GET	sdk.	Polling endpoint for PHP SDK
 //	✅	/meval/{contextBase64}	GET	clientstream.	SSE stream of "ping" and other events
 //	✅	/meval	REPORT	clientstream.	Same as above, but request body is the evaluation context JSON object (not in base64)
 //	✅	/mobile	POST	events.	For receiving events from mobile SDKs
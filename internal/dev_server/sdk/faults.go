@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+// InjectFaults makes the SDK endpoints misbehave the way the project's faults say, so that SDK retry and fallback
+// paths can be exercised on purpose. It goes after the middleware that puts the project key on the context.
+func InjectFaults(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		faults := model.FaultsFromContext(request.Context())
+		projectKey := projectKeyFromContext(request)
+		// CORS preflight requests are left alone, since browsers won't tell the SDK why they failed.
+		if faults == nil || projectKey == "" || request.Method == http.MethodOptions {
+			handler.ServeHTTP(writer, request)
+			return
+		}
+		ctx := request.Context()
+		for _, fault := range faults.Roll(projectKey) {
+			switch fault.Kind {
+			case model.FaultLatency:
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(fault.Latency()):
+				}
+			case model.FaultError:
+				http.Error(writer, "fault injected by the dev server", fault.Status)
+				return
+			case model.FaultDropStream:
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, fault.DropAfter())
+				defer cancel()
+			case model.FaultStallHeartbeats:
+				ctx = withStalledHeartbeats(ctx)
+			case model.FaultMalformed, model.FaultTruncated:
+				writer = &payloadFaultWriter{ResponseWriter: writer, kind: fault.Kind}
+			}
+		}
+		handler.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
+const stalledHeartbeatsContextKey = ctxKey("stalledHeartbeats")
+
+func withStalledHeartbeats(ctx context.Context) context.Context {
+	return context.WithValue(ctx, stalledHeartbeatsContextKey, true)
+}
+
+// heartbeatsStalled reports whether streams opened with ctx should stop sending heartbeats.
+func heartbeatsStalled(ctx context.Context) bool {
+	stalled, _ := ctx.Value(stalledHeartbeatsContextKey).(bool)
+	return stalled
+}
+
+// payloadFaultWriter corrupts the first payload written to the response: malformed payloads have their JSON broken,
+// and truncated payloads are cut in half with nothing written after them.
+type payloadFaultWriter struct {
+	http.ResponseWriter
+	kind      model.FaultKind
+	faulted   bool
+	truncated bool
+}
+
+func (w *payloadFaultWriter) Write(payload []byte) (int, error) {
+	switch {
+	case w.truncated:
+		return 0, io.ErrClosedPipe
+	case w.faulted:
+		return w.ResponseWriter.Write(payload)
+	}
+	w.faulted = true
+	if w.kind == model.FaultTruncated {
+		w.truncated = true
+		n, err := w.ResponseWriter.Write(payload[:len(payload)/2])
+		if err == nil {
+			err = io.ErrShortWrite
+		}
+		return n, err
+	}
+	if _, err := w.ResponseWriter.Write(malform(payload)); err != nil {
+		return 0, err
+	}
+	return len(payload), nil
+}
+
+func (w *payloadFaultWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// malform breaks the JSON in payload by replacing its first opening brace or bracket, which leaves the framing of
+// stream events intact so SDKs see an event they can't parse.
+func malform(payload []byte) []byte {
+	malformed := bytes.Clone(payload)
+	if i := bytes.IndexAny(malformed, "{["); i >= 0 {
+		malformed[i] = '<'
+	}
+	return malformed
+}
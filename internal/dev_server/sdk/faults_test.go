@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+)
+
+func TestInjectFaults(t *testing.T) {
+	const payload = `{"flags":{"my-flag":true}}`
+	store := mocks.NewMockStore(gomock.NewController(t))
+	store.EXPECT().GetDevProjectKeyForCredential(gomock.Any(), gomock.Any()).
+		Return("", model.NewErrNotFound("project", "for credential")).AnyTimes()
+	newServer := func(t *testing.T, fault model.Fault) *httptest.Server {
+		faults := model.NewFaults()
+		require.NoError(t, faults.Add("proj", fault))
+		router := mux.NewRouter()
+		router.Use(model.StoreMiddleware(store))
+		router.Use(model.FaultsMiddleware(faults))
+		router.Use(SetProjectKeyWhenPresent(credentialFromAuthorizationHeader))
+		router.Use(InjectFaults)
+		router.HandleFunc("/poll", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(payload))
+		})
+		router.HandleFunc("/stream", func(w http.ResponseWriter, r *http.Request) {
+			updateChan, errChan := OpenStream(r.Context(), w, Message{Event: TYPE_PUT, Data: []byte(payload)}.ToPayload())
+			defer close(updateChan)
+			<-errChan
+		})
+		router.HandleFunc("/heartbeats", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(strconv.FormatBool(heartbeatsStalled(r.Context()))))
+		})
+		server := httptest.NewServer(router)
+		t.Cleanup(server.Close)
+		return server
+	}
+	get := func(t *testing.T, server *httptest.Server, path, projectKey string) (*http.Response, string) {
+		request, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		request.Header.Set("Authorization", projectKey)
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		return response, string(body)
+	}
+
+	t.Run("responds with the status of error faults", func(t *testing.T) {
+		server := newServer(t, model.Fault{Kind: model.FaultError, Probability: 1, Status: http.StatusTooManyRequests})
+		response, _ := get(t, server, "/poll", "proj")
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	})
+
+	t.Run("leaves other projects alone", func(t *testing.T) {
+		server := newServer(t, model.Fault{Kind: model.FaultError, Probability: 1, Status: http.StatusServiceUnavailable})
+		response, body := get(t, server, "/poll", "other")
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, payload, body)
+	})
+
+	t.Run("delays responses by the latency", func(t *testing.T) {
+		server := newServer(t, model.Fault{Kind: model.FaultLatency, Probability: 1, LatencyMs: 50})
+		start := time.Now()
+		_, body := get(t, server, "/poll", "proj")
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+		assert.Equal(t, payload, body)
+	})
+
+	t.Run("malforms payloads", func(t *testing.T) {
+		server := newServer(t, model.Fault{Kind: model.FaultMalformed, Probability: 1})
+		_, body := get(t, server, "/poll", "proj")
+		assert.Equal(t, `<"flags":{"my-flag":true}}`, body)
+	})
+
+	t.Run("truncates payloads", func(t *testing.T) {
+		server := newServer(t, model.Fault{Kind: model.FaultTruncated, Probability: 1})
+		_, body := get(t, server, "/poll", "proj")
+		assert.Equal(t, payload[:len(payload)/2], body)
+	})
+
+	t.Run("drops streams", func(t *testing.T) {
+		server := newServer(t, model.Fault{Kind: model.FaultDropStream, Probability: 1, DropAfterMs: 50})
+		_, body := get(t, server, "/stream", "proj")
+		assert.Contains(t, body, payload)
+	})
+
+	t.Run("stalls heartbeats", func(t *testing.T) {
+		server := newServer(t, model.Fault{Kind: model.FaultStallHeartbeats, Probability: 1})
+		_, body := get(t, server, "/heartbeats", "proj")
+		assert.Equal(t, "true", body)
+	})
+}
@@ -6,7 +6,9 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
 	"github.com/launchdarkly/go-server-sdk/v7/subsystems"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 )
 
@@ -14,6 +16,7 @@ const (
 	fdv2ReasonUpToDate       = "up-to-date"
 	fdv2ReasonCantCatchup    = "cant-catchup"
 	fdv2ReasonPayloadMissing = "payload-missing"
+	fdv2ReasonStale          = "stale"
 	fdv2ReasonUpdate         = "update"
 )
 
@@ -44,38 +47,82 @@ func parseBasis(basis string) (string, int) {
 //
 // payloadID is the stable identifier for this payload (the project key).
 // currentVersion is the project's current PayloadVersion.
-// flags is the current flag state with overrides applied.
+// flagsData is the current flag and segment configuration with overrides applied.
 // basis is the raw ?basis query param from the SDK (empty string = no basis provided).
+// changeLog is the record of recent changes used to send stale clients a delta; it may be nil.
 //
-// Delta transfers are not supported: stale clients always receive a full payload.
-// Tracking the change history required for deltas is overkill for a local dev server.
-func buildInitialResponse(payloadID string, currentVersion int, flags model.FlagsState, basis string) (subsystems.PollingPayload, error) {
+// A stale basis still within the change log's window gets only the flags changed since then. Anything older, or
+// a basis that the log has no record of, gets the full payload.
+func buildInitialResponse(payloadID string, currentVersion int, flagsData adapters.FlagsData, basis string, changeLog *model.ChangeLog) (subsystems.PollingPayload, error) {
 	basisPayloadID, basisVersion := parseBasis(basis)
 	switch {
 	case basisVersion == 0:
-		return buildFullTransferResponse(payloadID, currentVersion, flags, fdv2ReasonPayloadMissing)
+		return buildFullTransferResponse(payloadID, currentVersion, flagsData, fdv2ReasonPayloadMissing)
 	case basisPayloadID == payloadID && basisVersion == currentVersion:
 		event, err := makeServerIntentEvent(payloadID, currentVersion, subsystems.IntentNone, fdv2ReasonUpToDate)
 		if err != nil {
 			return subsystems.PollingPayload{}, err
 		}
 		return subsystems.PollingPayload{Events: []subsystems.RawEvent{event}}, nil
+	case basisPayloadID == payloadID && changeLog != nil:
+		if flagKeys, ok := changeLog.FlagsChangedSince(payloadID, basisVersion, currentVersion); ok {
+			return buildChangesTransferResponse(payloadID, currentVersion, flagsData, flagKeys)
+		}
+		return buildFullTransferResponse(payloadID, currentVersion, flagsData, fdv2ReasonCantCatchup)
 	default:
 		// Payload ID mismatch, stale version, or version ahead of current (e.g. project recreated):
 		// we can't compute a delta — send the full payload.
-		return buildFullTransferResponse(payloadID, currentVersion, flags, fdv2ReasonCantCatchup)
+		return buildFullTransferResponse(payloadID, currentVersion, flagsData, fdv2ReasonCantCatchup)
 	}
 }
 
-func buildFullTransferResponse(payloadID string, version int, flags model.FlagsState, reason string) (subsystems.PollingPayload, error) {
+// buildChangesTransferResponse sends the given flags as a delta on top of the SDK's basis. Flags that no longer
+// exist are deleted.
+func buildChangesTransferResponse(payloadID string, version int, flagsData adapters.FlagsData, flagKeys []string) (subsystems.PollingPayload, error) {
+	intentEvent, err := makeServerIntentEvent(payloadID, version, subsystems.IntentTransferChanges, fdv2ReasonStale)
+	if err != nil {
+		return subsystems.PollingPayload{}, err
+	}
+	events := []subsystems.RawEvent{intentEvent}
+
+	for _, key := range flagKeys {
+		var event subsystems.RawEvent
+		if flag, ok := flagsData.Flags[key]; ok {
+			event, err = makePutObjectEvent(version, subsystems.FlagKind, key, flag)
+		} else {
+			event, err = makeDeleteObjectEvent(version, subsystems.FlagKind, key)
+		}
+		if err != nil {
+			return subsystems.PollingPayload{}, err
+		}
+		events = append(events, event)
+	}
+
+	transferredEvent, err := makePayloadTransferredEvent(payloadID, version)
+	if err != nil {
+		return subsystems.PollingPayload{}, err
+	}
+	events = append(events, transferredEvent)
+
+	return subsystems.PollingPayload{Events: events}, nil
+}
+
+func buildFullTransferResponse(payloadID string, version int, flagsData adapters.FlagsData, reason string) (subsystems.PollingPayload, error) {
 	intentEvent, err := makeServerIntentEvent(payloadID, version, subsystems.IntentTransferFull, reason)
 	if err != nil {
 		return subsystems.PollingPayload{}, err
 	}
 	events := []subsystems.RawEvent{intentEvent}
 
-	for key, flagState := range flags {
-		event, err := makePutObjectEvent(version, key, flagState)
+	for key, flag := range flagsData.Flags {
+		event, err := makePutObjectEvent(version, subsystems.FlagKind, key, flag)
+		if err != nil {
+			return subsystems.PollingPayload{}, err
+		}
+		events = append(events, event)
+	}
+	for key, segment := range flagsData.Segments {
+		event, err := makePutObjectEvent(version, subsystems.SegmentKind, key, segment)
 		if err != nil {
 			return subsystems.PollingPayload{}, err
 		}
@@ -106,14 +153,14 @@ func makeServerIntentEvent(payloadID string, target int, intentCode subsystems.I
 	return subsystems.RawEvent{Name: subsystems.EventServerIntent, Data: data}, nil
 }
 
-func makePutObjectEvent(version int, key string, flagState model.FlagState) (subsystems.RawEvent, error) {
-	object, err := json.Marshal(serverFlagFromFlagState(key, flagState))
+func makePutObjectEvent(version int, kind subsystems.ObjectKind, key string, item any) (subsystems.RawEvent, error) {
+	object, err := json.Marshal(item)
 	if err != nil {
 		return subsystems.RawEvent{}, err
 	}
 	data, err := json.Marshal(subsystems.PutObject{
 		Version: version,
-		Kind:    subsystems.FlagKind,
+		Kind:    kind,
 		Key:     key,
 		Object:  object,
 	})
@@ -123,14 +170,26 @@ func makePutObjectEvent(version int, key string, flagState model.FlagState) (sub
 	return subsystems.RawEvent{Name: subsystems.EventPutObject, Data: data}, nil
 }
 
+func makeDeleteObjectEvent(version int, kind subsystems.ObjectKind, key string) (subsystems.RawEvent, error) {
+	data, err := json.Marshal(subsystems.DeleteObject{
+		Version: version,
+		Kind:    kind,
+		Key:     key,
+	})
+	if err != nil {
+		return subsystems.RawEvent{}, err
+	}
+	return subsystems.RawEvent{Name: subsystems.EventDeleteObject, Data: data}, nil
+}
+
 // buildFlagChangeEvents builds the events sequence for a single flag update pushed over a stream:
 // server-intent(xfer-changes) + put-object(changed flag) + payload-transferred.
-func buildFlagChangeEvents(payloadID string, version int, flagKey string, flagState model.FlagState) ([]subsystems.RawEvent, error) {
+func buildFlagChangeEvents(payloadID string, version int, flag ldmodel.FeatureFlag) ([]subsystems.RawEvent, error) {
 	intentEvent, err := makeServerIntentEvent(payloadID, version, subsystems.IntentTransferChanges, fdv2ReasonUpdate)
 	if err != nil {
 		return nil, err
 	}
-	putEvent, err := makePutObjectEvent(version, flagKey, flagState)
+	putEvent, err := makePutObjectEvent(version, subsystems.FlagKind, flag.Key, flag)
 	if err != nil {
 		return nil, err
 	}
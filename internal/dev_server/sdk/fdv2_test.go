@@ -12,6 +12,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldbuilders"
 	"github.com/launchdarkly/go-server-sdk/v7/subsystems"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
@@ -48,12 +49,12 @@ func TestParseBasis(t *testing.T) {
 func TestBuildPollResponse(t *testing.T) {
 	payloadID := "test-project"
 	currentVersion := 5
-	flags := model.FlagsState{
+	flags := model.FlagsDataFromFlagsState(model.FlagsState{
 		"flag-1": model.FlagState{Value: ldvalue.Bool(true), Version: 2},
-	}
+	})
 
 	t.Run("no basis sends xfer-full with payload-missing", func(t *testing.T) {
-		resp, err := buildInitialResponse(payloadID, currentVersion, flags, "")
+		resp, err := buildInitialResponse(payloadID, currentVersion, flags, "", nil)
 		require.NoError(t, err)
 
 		require.GreaterOrEqual(t, len(resp.Events), 3) // server-intent + put-objects + payload-transferred
@@ -64,7 +65,7 @@ func TestBuildPollResponse(t *testing.T) {
 
 	t.Run("up-to-date basis sends none with up-to-date", func(t *testing.T) {
 		basis := fmt.Sprintf("(p:%s:%d)", payloadID, currentVersion)
-		resp, err := buildInitialResponse(payloadID, currentVersion, flags, basis)
+		resp, err := buildInitialResponse(payloadID, currentVersion, flags, basis, nil)
 		require.NoError(t, err)
 
 		require.Len(t, resp.Events, 1)
@@ -73,7 +74,7 @@ func TestBuildPollResponse(t *testing.T) {
 
 	t.Run("basis ahead of current version sends full transfer (e.g. project recreated)", func(t *testing.T) {
 		basis := fmt.Sprintf("(p:%s:%d)", payloadID, currentVersion+10)
-		resp, err := buildInitialResponse(payloadID, currentVersion, flags, basis)
+		resp, err := buildInitialResponse(payloadID, currentVersion, flags, basis, nil)
 		require.NoError(t, err)
 
 		require.GreaterOrEqual(t, len(resp.Events), 3)
@@ -83,7 +84,7 @@ func TestBuildPollResponse(t *testing.T) {
 
 	t.Run("stale basis sends xfer-full with cant-catchup", func(t *testing.T) {
 		basis := fmt.Sprintf("(p:%s:%d)", payloadID, currentVersion-1)
-		resp, err := buildInitialResponse(payloadID, currentVersion, flags, basis)
+		resp, err := buildInitialResponse(payloadID, currentVersion, flags, basis, nil)
 		require.NoError(t, err)
 
 		require.GreaterOrEqual(t, len(resp.Events), 3)
@@ -91,9 +92,44 @@ func TestBuildPollResponse(t *testing.T) {
 		assertPayloadTransferredEvent(t, resp.Events[len(resp.Events)-1], payloadID, currentVersion)
 	})
 
+	t.Run("stale basis within the change log sends xfer-changes with the changed flags", func(t *testing.T) {
+		changeLog := model.NewChangeLog(model.DefaultChangeLogCapacity)
+		changeLog.Handle(model.OverrideEvent{ProjectKey: payloadID, FlagKey: "flag-1", PayloadVersion: currentVersion - 1})
+		changeLog.Handle(model.OverrideEvent{ProjectKey: payloadID, FlagKey: "removed-flag", PayloadVersion: currentVersion})
+
+		basis := fmt.Sprintf("(p:%s:%d)", payloadID, currentVersion-2)
+		resp, err := buildInitialResponse(payloadID, currentVersion, flags, basis, changeLog)
+		require.NoError(t, err)
+
+		// server-intent + put-object + delete-object + payload-transferred
+		require.Len(t, resp.Events, 4)
+		assertServerIntentEvent(t, resp.Events[0], payloadID, currentVersion, subsystems.IntentTransferChanges, fdv2ReasonStale)
+		var put subsystems.PutObject
+		require.NoError(t, json.Unmarshal(resp.Events[1].Data, &put))
+		assert.Equal(t, subsystems.EventPutObject, resp.Events[1].Name)
+		assert.Equal(t, "flag-1", put.Key)
+		var deleted subsystems.DeleteObject
+		require.NoError(t, json.Unmarshal(resp.Events[2].Data, &deleted))
+		assert.Equal(t, subsystems.EventDeleteObject, resp.Events[2].Name)
+		assert.Equal(t, "removed-flag", deleted.Key)
+		assert.Equal(t, currentVersion, deleted.Version)
+		assertPayloadTransferredEvent(t, resp.Events[3], payloadID, currentVersion)
+	})
+
+	t.Run("stale basis older than the change log sends xfer-full with cant-catchup", func(t *testing.T) {
+		changeLog := model.NewChangeLog(model.DefaultChangeLogCapacity)
+		changeLog.Handle(model.OverrideEvent{ProjectKey: payloadID, FlagKey: "flag-1", PayloadVersion: currentVersion})
+
+		basis := fmt.Sprintf("(p:%s:%d)", payloadID, currentVersion-2)
+		resp, err := buildInitialResponse(payloadID, currentVersion, flags, basis, changeLog)
+		require.NoError(t, err)
+
+		assertServerIntentEvent(t, resp.Events[0], payloadID, currentVersion, subsystems.IntentTransferFull, fdv2ReasonCantCatchup)
+	})
+
 	t.Run("basis with wrong payload ID sends xfer-full", func(t *testing.T) {
 		basis := fmt.Sprintf("(p:%s:%d)", "other-project", currentVersion)
-		resp, err := buildInitialResponse(payloadID, currentVersion, flags, basis)
+		resp, err := buildInitialResponse(payloadID, currentVersion, flags, basis, nil)
 		require.NoError(t, err)
 
 		require.GreaterOrEqual(t, len(resp.Events), 3)
@@ -102,11 +138,11 @@ func TestBuildPollResponse(t *testing.T) {
 	})
 
 	t.Run("full transfer includes a put-object for each flag", func(t *testing.T) {
-		multiFlags := model.FlagsState{
+		multiFlags := model.FlagsDataFromFlagsState(model.FlagsState{
 			"flag-a": model.FlagState{Value: ldvalue.Bool(true), Version: 1},
 			"flag-b": model.FlagState{Value: ldvalue.String("hello"), Version: 2},
-		}
-		resp, err := buildInitialResponse(payloadID, currentVersion, multiFlags, "")
+		})
+		resp, err := buildInitialResponse(payloadID, currentVersion, multiFlags, "", nil)
 		require.NoError(t, err)
 
 		// server-intent + 2 put-objects + payload-transferred
@@ -124,6 +160,22 @@ func TestBuildPollResponse(t *testing.T) {
 		assert.True(t, putKeys["flag-a"])
 		assert.True(t, putKeys["flag-b"])
 	})
+
+	t.Run("full transfer includes a put-object for each segment", func(t *testing.T) {
+		withSegments := model.FlagsDataFromFlagsState(model.FlagsState{
+			"flag-a": model.FlagState{Value: ldvalue.Bool(true), Version: 1},
+		})
+		withSegments.Segments["segment-a"] = ldbuilders.NewSegmentBuilder("segment-a").Included("user-a").Build()
+		resp, err := buildInitialResponse(payloadID, currentVersion, withSegments, "", nil)
+		require.NoError(t, err)
+
+		// server-intent + flag put-object + segment put-object + payload-transferred
+		require.Len(t, resp.Events, 4)
+		var put subsystems.PutObject
+		require.NoError(t, json.Unmarshal(resp.Events[2].Data, &put))
+		assert.Equal(t, subsystems.SegmentKind, put.Kind)
+		assert.Equal(t, "segment-a", put.Key)
+	})
 }
 
 func TestPollV2Handler(t *testing.T) {
@@ -135,6 +187,8 @@ func TestPollV2Handler(t *testing.T) {
 	router.Use(model.ObserversMiddleware(observers))
 	router.Use(model.StoreMiddleware(store))
 	BindRoutes(router)
+	store.EXPECT().GetDevProjectKeyForCredential(gomock.Any(), exampleProjectKey).
+		Return("", model.NewErrNotFound("project", "for credential")).AnyTimes()
 
 	project := &model.Project{
 		Key:                  exampleProjectKey,
@@ -0,0 +1,30 @@
+package sdk
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+// ForwardEvents hands the events posted to an event endpoint to the event forwarder, when forwarding is on, before
+// the endpoint handles them as usual. Events are only forwarded for requests with a project key on the context.
+func ForwardEvents(kind model.EventPayloadKind, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		forwarder := model.EventForwarderFromContext(request.Context())
+		if forwarder == nil || request.Method != http.MethodPost {
+			handler.ServeHTTP(writer, request)
+			return
+		}
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			log.Printf("ForwardEvents: error reading request body: %v", err)
+		} else if projectKey := projectKeyFromContext(request); projectKey != "" {
+			forwarder.Enqueue(projectKey, kind, request.Header, body)
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+		handler.ServeHTTP(writer, request)
+	})
+}
@@ -9,7 +9,12 @@ import (
 
 func GetClientFlags(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	allFlags, err := GetAllFlagsFromContext(ctx)
+	ldCtx, err := GetContextFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	allFlags, err := GetFlagsForContextFromContext(ctx, ldCtx)
 	if err != nil {
 		WriteError(ctx, w, errors.Wrap(err, "failed to get flag state"))
 		return
@@ -10,20 +10,41 @@ import (
 
 func GetServerFlags(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	allFlags, err := GetAllFlagsFromContext(ctx)
+	flagsData, err := GetFlagsDataFromContext(ctx)
 	if err != nil {
 		WriteError(ctx, w, errors.Wrap(err, "failed to get flag state"))
 		return
 	}
 	var body interface{}
 	if flagKey, ok := mux.Vars(r)["flagKey"]; ok {
-		body, ok = ServerFlagsFromFlagsState(allFlags)[flagKey]
+		body, ok = flagsData.Flags[flagKey]
 		if !ok {
 			http.Error(w, "flag not found", http.StatusNotFound)
+			return
 		}
 	} else {
-		body = ServerFlagsFromFlagsState(allFlags)
+		body = ServerFlags(flagsData.Flags)
 	}
+	writeServerJson(w, r, body)
+}
+
+func GetServerSegment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	flagsData, err := GetFlagsDataFromContext(ctx)
+	if err != nil {
+		WriteError(ctx, w, errors.Wrap(err, "failed to get segment state"))
+		return
+	}
+	segment, ok := flagsData.Segments[mux.Vars(r)["segmentKey"]]
+	if !ok {
+		http.Error(w, "segment not found", http.StatusNotFound)
+		return
+	}
+	writeServerJson(w, r, segment)
+}
+
+func writeServerJson(w http.ResponseWriter, r *http.Request, body interface{}) {
+	ctx := r.Context()
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		WriteError(ctx, w, errors.Wrap(err, "failed to marshal flag state"))
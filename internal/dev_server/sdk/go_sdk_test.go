@@ -15,9 +15,11 @@ import (
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldbuilders"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
 	ldclient "github.com/launchdarkly/go-server-sdk/v7"
 	"github.com/launchdarkly/go-server-sdk/v7/interfaces"
-	"github.com/launchdarkly/go-server-sdk/v7/interfaces/flagstate"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	"github.com/launchdarkly/ldcli/internal/dev_server/adapters/mocks"
 	"github.com/launchdarkly/ldcli/internal/dev_server/db"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
@@ -45,29 +47,32 @@ func TestSDKRoutesViaGoSDK(t *testing.T) {
 	mockController := gomock.NewController(t)
 	ctx, api, sdk := mocks.WithMockApiAndSdk(ctx, mockController)
 
-	api.EXPECT().GetSdkKey(gomock.Any(), projectKey, environmentKey).Return(testSdkKey, nil).AnyTimes()
+	api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projectKey, environmentKey).
+		Return(adapters.EnvironmentCredentials{SdkKey: testSdkKey}, nil).AnyTimes()
 	api.EXPECT().GetAllFlags(gomock.Any(), projectKey).
 		Return(nil, nil). // Available variations are not used for evaluation
 		AnyTimes()
 
 	// Wire up sdk routes in test server
+	evaluations := model.NewEvaluations()
 	router := mux.NewRouter()
 	router.Use(model.StoreMiddleware(store))
 	router.Use(model.ObserversMiddleware(observers))
+	router.Use(model.EvaluationsMiddleware(evaluations))
 	BindRoutes(router)
 	require.NoError(t, err)
 	testServer := httptest.NewServer(router)
 
 	// Initialize project with all kinds of flags
-	allFlags := flagstate.NewAllFlagsBuilder().
-		AddFlag("boolFlag", flagstate.FlagState{Value: ldvalue.Bool(true)}).
-		AddFlag("stringFlag", flagstate.FlagState{Value: ldvalue.String("cool")}).
-		AddFlag("intFlag", flagstate.FlagState{Value: ldvalue.Int(123)}).
-		AddFlag("doubleFlag", flagstate.FlagState{Value: ldvalue.Float64(99.99)}).
-		AddFlag("jsonFlag", flagstate.FlagState{Value: ldvalue.CopyArbitraryValue(map[string]any{"cat": "hat"})}).
-		Build()
-
-	sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), testSdkKey).Return(allFlags, nil)
+	allFlags := mocks.FlagsDataFromValues(map[string]ldvalue.Value{
+		"boolFlag":   ldvalue.Bool(true),
+		"stringFlag": ldvalue.String("cool"),
+		"intFlag":    ldvalue.Int(123),
+		"doubleFlag": ldvalue.Float64(99.99),
+		"jsonFlag":   ldvalue.CopyArbitraryValue(map[string]any{"cat": "hat"}),
+	}, 1)
+
+	sdk.EXPECT().GetFlagsData(gomock.Any(), testSdkKey).Return(allFlags, nil)
 	_, err = model.CreateProject(ctx, projectKey, environmentKey, nil)
 	require.NoError(t, err)
 
@@ -76,7 +81,8 @@ func TestSDKRoutesViaGoSDK(t *testing.T) {
 	ldConfig.ServiceEndpoints.Streaming = testServer.URL
 	ldConfig.ServiceEndpoints.Events = testServer.URL
 	ldConfig.ServiceEndpoints.Polling = testServer.URL
-	ld, err := ldclient.MakeCustomClient(projectKey, ldConfig, time.Second)
+	// The SDK is configured with the source environment's SDK key, as an app would be, rather than the project key.
+	ld, err := ldclient.MakeCustomClient(testSdkKey, ldConfig, time.Second)
 	require.NoError(t, err)
 
 	ldContext := ldcontext.New(t.Name())
@@ -111,17 +117,35 @@ func TestSDKRoutesViaGoSDK(t *testing.T) {
 		assert.Equal(t, map[string]any{"cat": "hat"}, val.AsArbitraryValue())
 	})
 
+	t.Run("evaluations are summarized from the SDK's events", func(t *testing.T) {
+		ld.Flush()
+		require.Eventually(t, func() bool {
+			_, ok := evaluations.ForFlag(projectKey, "boolFlag")
+			return ok
+		}, time.Second, 10*time.Millisecond)
+
+		flag, _ := evaluations.ForFlag(projectKey, "boolFlag")
+		assert.Equal(t, 1, flag.Count)
+		assert.Equal(t, []string{"user"}, flag.ContextKinds)
+		require.Len(t, flag.Values, 1)
+		assert.Equal(t, ldvalue.Bool(true), flag.Values[0].Value)
+
+		contexts := evaluations.ForProject(projectKey).Contexts
+		require.Len(t, contexts, 1)
+		assert.Equal(t, ldContext.Key(), contexts[0].Key, "the context is seen in the SDK's index event")
+	})
+
 	// Mock scenario: we re-sync and the SDK returns new values and higher version numbers
-	updatedFlags := flagstate.NewAllFlagsBuilder().
-		AddFlag("boolFlag", flagstate.FlagState{Value: ldvalue.Bool(false), Version: 2}).
-		AddFlag("stringFlag", flagstate.FlagState{Value: ldvalue.String("pool"), Version: 2}).
-		AddFlag("intFlag", flagstate.FlagState{Value: ldvalue.Int(789), Version: 2}).
-		AddFlag("doubleFlag", flagstate.FlagState{Value: ldvalue.Float64(101.01), Version: 2}).
-		AddFlag("jsonFlag", flagstate.FlagState{Value: ldvalue.CopyArbitraryValue(map[string]any{"cat": "bababooey"}), Version: 2}).
-		Build()
-	valuesMap := updatedFlags.ToValuesMap()
+	valuesMap := map[string]ldvalue.Value{
+		"boolFlag":   ldvalue.Bool(false),
+		"stringFlag": ldvalue.String("pool"),
+		"intFlag":    ldvalue.Int(789),
+		"doubleFlag": ldvalue.Float64(101.01),
+		"jsonFlag":   ldvalue.CopyArbitraryValue(map[string]any{"cat": "bababooey"}),
+	}
+	updatedFlags := mocks.FlagsDataFromValues(valuesMap, 2)
 
-	sdk.EXPECT().GetAllFlagsState(gomock.Any(), gomock.Any(), testSdkKey).Return(updatedFlags, nil)
+	sdk.EXPECT().GetFlagsData(gomock.Any(), testSdkKey).Return(updatedFlags, nil)
 
 	// This test is testing the "put" payload in a roundabout way by verifying each of the flags are in there.
 	t.Run("Sync sends full flag payload for project", func(t *testing.T) {
@@ -163,3 +187,87 @@ func TestSDKRoutesViaGoSDK(t *testing.T) {
 		})
 	}
 }
+
+// TestServerSDKEvaluatesTargeting checks that server-side SDKs receive real flag configurations, so that targeting is
+// evaluated per context by the SDK, and that overrides still win for every context.
+func TestServerSDKEvaluatesTargeting(t *testing.T) {
+	const projectKey = "targeting-project"
+	const environmentKey = "test-environment"
+	const testSdkKey = "5678"
+
+	ctx := context.Background()
+	store, err := db.NewSqlite(ctx, "test_targeting.db")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.Remove("test_targeting.db"))
+	}()
+	ctx = model.ContextWithStore(ctx, store)
+	observers := model.NewObservers()
+	ctx = model.SetObserversOnContext(ctx, observers)
+	mockController := gomock.NewController(t)
+	ctx, api, sdk := mocks.WithMockApiAndSdk(ctx, mockController)
+
+	api.EXPECT().GetEnvironmentCredentials(gomock.Any(), projectKey, environmentKey).
+		Return(adapters.EnvironmentCredentials{SdkKey: testSdkKey}, nil).AnyTimes()
+	api.EXPECT().GetAllFlags(gomock.Any(), projectKey).Return(nil, nil).AnyTimes()
+	sdk.EXPECT().GetFlagsData(gomock.Any(), testSdkKey).Return(adapters.FlagsData{
+		Flags: map[string]ldmodel.FeatureFlag{
+			"betaFlag": ldbuilders.NewFlagBuilder("betaFlag").
+				Version(1).
+				On(true).
+				Variations(ldvalue.String("everyone"), ldvalue.String("beta")).
+				AddRule(ldbuilders.NewRuleBuilder().ID("beta-rule").Variation(1).
+					Clauses(ldbuilders.SegmentMatchClause("beta-users"))).
+				FallthroughVariation(0).
+				Build(),
+		},
+		Segments: map[string]ldmodel.Segment{
+			"beta-users": ldbuilders.NewSegmentBuilder("beta-users").Version(1).Included("beta-user").Build(),
+		},
+	}, nil)
+	_, err = model.CreateProject(ctx, projectKey, environmentKey, nil)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.Use(model.StoreMiddleware(store))
+	router.Use(model.ObserversMiddleware(observers))
+	router.Use(model.EvaluationsMiddleware(model.NewEvaluations()))
+	BindRoutes(router)
+	testServer := httptest.NewServer(router)
+	defer testServer.Close()
+
+	ldConfig := ldclient.Config{}
+	ldConfig.ServiceEndpoints.Streaming = testServer.URL
+	ldConfig.ServiceEndpoints.Events = testServer.URL
+	ldConfig.ServiceEndpoints.Polling = testServer.URL
+	ld, err := ldclient.MakeCustomClient(projectKey, ldConfig, time.Second)
+	require.NoError(t, err)
+	defer ld.Close()
+
+	betaUser := ldcontext.New("beta-user")
+	otherUser := ldcontext.New("other-user")
+
+	t.Run("contexts get their targeted values", func(t *testing.T) {
+		val, err := ld.StringVariation("betaFlag", betaUser, "bad")
+		require.NoError(t, err)
+		assert.Equal(t, "beta", val)
+
+		val, err = ld.StringVariation("betaFlag", otherUser, "bad")
+		require.NoError(t, err)
+		assert.Equal(t, "everyone", val)
+	})
+
+	t.Run("overrides win for every context", func(t *testing.T) {
+		flagUpdateChan := ld.GetFlagTracker().AddFlagValueChangeListener("betaFlag", betaUser, ldvalue.String("uh-oh"))
+		defer ld.GetFlagTracker().RemoveFlagValueChangeListener(flagUpdateChan)
+		_, err := model.UpsertOverride(ctx, projectKey, "betaFlag", ldvalue.String("overridden"))
+		require.NoError(t, err)
+		<-flagUpdateChan
+
+		for _, ldContext := range []ldcontext.Context{betaUser, otherUser} {
+			val, err := ld.StringVariation("betaFlag", ldContext, "bad")
+			require.NoError(t, err)
+			assert.Equal(t, "overridden", val)
+		}
+	})
+}
@@ -1,17 +1,25 @@
 package sdk
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldbuilders"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -35,6 +43,8 @@ func TestMobileAuth(t *testing.T) {
 	router.Use(model.ObserversMiddleware(observers))
 	router.Use(model.StoreMiddleware(store))
 	BindRoutes(router)
+	store.EXPECT().GetDevProjectKeyForCredential(gomock.Any(), exampleProjectKey).
+		Return("", model.NewErrNotFound("project", "for credential")).AnyTimes()
 
 	t.Run("given project key prefixed with api_key, it should authenticate successfully", func(t *testing.T) {
 		store.EXPECT().GetDevProject(gomock.Any(), exampleProjectKey).Return(exampleProject, nil)
@@ -59,4 +69,182 @@ func TestMobileAuth(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
+
+	t.Run("given the source environment's mobile key, it should serve the project", func(t *testing.T) {
+		store.EXPECT().GetDevProjectKeyForCredential(gomock.Any(), "mob-1234").Return(exampleProjectKey, nil)
+		store.EXPECT().GetDevProject(gomock.Any(), exampleProjectKey).Return(exampleProject, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), exampleProjectKey).Return(nil, nil)
+
+		req := httptest.NewRequest("GET", "/msdk/evalx/eyJrZXkiOiJib2FyZCBjYXQifQ==", nil)
+		req.Header.Set("Authorization", "mob-1234")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("given project:env, it should serve that environment of the project", func(t *testing.T) {
+		environmentKey := exampleProjectKey + ":staging"
+		environment := &model.Project{Key: environmentKey}
+		store.EXPECT().GetDevProjectKeyForCredential(gomock.Any(), environmentKey).Return("", model.NewErrNotFound("project", "for credential"))
+		store.EXPECT().GetDevProject(gomock.Any(), environmentKey).Return(environment, nil).Times(2)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), environmentKey).Return(nil, nil)
+
+		req := httptest.NewRequest("GET", "/msdk/evalx/eyJrZXkiOiJib2FyZCBjYXQifQ==", nil)
+		req.Header.Set("Authorization", environmentKey)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestClientFlagsPerContext(t *testing.T) {
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	observers := model.NewObservers()
+
+	router := mux.NewRouter()
+	router.Use(model.ObserversMiddleware(observers))
+	router.Use(model.StoreMiddleware(store))
+	BindRoutes(router)
+	store.EXPECT().GetDevProjectKeyForCredential(gomock.Any(), exampleProjectKey).
+		Return("", model.NewErrNotFound("project", "for credential")).AnyTimes()
+
+	project := &model.Project{
+		Key: exampleProjectKey,
+		FlagsData: adapters.FlagsData{
+			Flags: map[string]ldmodel.FeatureFlag{
+				"betaFlag": ldbuilders.NewFlagBuilder("betaFlag").
+					Version(1).
+					On(true).
+					Variations(ldvalue.String("everyone"), ldvalue.String("beta")).
+					AddTarget(1, "beta-user").
+					FallthroughVariation(0).
+					Build(),
+			},
+		},
+	}
+
+	evaluate := func(t *testing.T, req *http.Request) model.FlagsState {
+		store.EXPECT().GetDevProject(gomock.Any(), exampleProjectKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), exampleProjectKey).Return(nil, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var flagsState model.FlagsState
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flagsState))
+		return flagsState
+	}
+	encode := func(ldCtx ldcontext.Context) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(ldCtx.JSONString()))
+	}
+
+	t.Run("GET evaluates for the context on the path", func(t *testing.T) {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/sdk/evalx/%s/contexts/%s", exampleProjectKey, encode(ldcontext.New("beta-user"))), nil)
+		assert.Equal(t, ldvalue.String("beta"), evaluate(t, req)["betaFlag"].Value)
+
+		req = httptest.NewRequest("GET", fmt.Sprintf("/sdk/evalx/%s/contexts/%s", exampleProjectKey, encode(ldcontext.New("someone"))), nil)
+		assert.Equal(t, ldvalue.String("everyone"), evaluate(t, req)["betaFlag"].Value)
+	})
+
+	t.Run("REPORT evaluates for the context in the body", func(t *testing.T) {
+		req := httptest.NewRequest("REPORT", "/msdk/evalx/context", strings.NewReader(ldcontext.New("beta-user").JSONString()))
+		req.Header.Set("Authorization", exampleProjectKey)
+		assert.Equal(t, ldvalue.String("beta"), evaluate(t, req)["betaFlag"].Value)
+	})
+
+	t.Run("malformed context is a bad request", func(t *testing.T) {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/sdk/evalx/%s/contexts/not-a-context", exampleProjectKey), nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestClientSideEventsAreRecorded(t *testing.T) {
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	evaluations := model.NewEvaluations()
+
+	router := mux.NewRouter()
+	router.Use(model.ObserversMiddleware(model.NewObservers()))
+	router.Use(model.StoreMiddleware(store))
+	router.Use(model.EvaluationsMiddleware(evaluations))
+	BindRoutes(router)
+	store.EXPECT().GetDevProjectKeyForCredential(gomock.Any(), "client-side-id").Return(exampleProjectKey, nil)
+	store.EXPECT().GetDevProject(gomock.Any(), exampleProjectKey).Return(&model.Project{Key: exampleProjectKey}, nil)
+
+	body := `[
+		{"kind": "index", "creationDate": 1700000000000, "context": {"kind": "user", "key": "browser-user"}},
+		{"kind": "summary", "startDate": 1700000000000, "endDate": 1700000001000, "features": {
+			"my-flag": {"contextKinds": ["user"], "counters": [{"value": true, "variation": 0, "count": 2}]}
+		}}
+	]`
+	req := httptest.NewRequest(http.MethodPost, "/events/bulk/client-side-id", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	flag, ok := evaluations.ForFlag(exampleProjectKey, "my-flag")
+	require.True(t, ok, "the client-side SDK's evaluations should be recorded")
+	assert.Equal(t, 2, flag.Count)
+	contexts := evaluations.ForProject(exampleProjectKey).Contexts
+	require.Len(t, contexts, 1)
+	assert.Equal(t, "browser-user", contexts[0].Key)
+}
+
+func TestEventsForUnknownProjectsAreNotRecorded(t *testing.T) {
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	evaluations := model.NewEvaluations()
+
+	router := mux.NewRouter()
+	router.Use(model.ObserversMiddleware(model.NewObservers()))
+	router.Use(model.StoreMiddleware(store))
+	router.Use(model.EvaluationsMiddleware(evaluations))
+	BindRoutes(router)
+	store.EXPECT().GetDevProjectKeyForCredential(gomock.Any(), "not-a-project").
+		Return("", model.NewErrNotFound("project", "for credential"))
+	store.EXPECT().GetDevProject(gomock.Any(), "not-a-project").Return(nil, model.NewErrNotFound("project", "not-a-project"))
+
+	body := `[{"kind": "summary", "startDate": 1700000000000, "endDate": 1700000001000, "features": {
+		"my-flag": {"contextKinds": ["user"], "counters": [{"value": true, "variation": 0, "count": 2}]}
+	}}]`
+	req := httptest.NewRequest(http.MethodPost, "/events/bulk/not-a-project", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	_, ok := evaluations.ForFlag("not-a-project", "my-flag")
+	assert.False(t, ok, "events for a key that isn't a dev project shouldn't be recorded")
+}
+
+func TestSDKEventKindsAreBoundedInMetrics(t *testing.T) {
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	metrics := model.NewMetrics()
+
+	router := mux.NewRouter()
+	router.Use(model.ObserversMiddleware(model.NewObservers()))
+	router.Use(model.StoreMiddleware(store))
+	router.Use(model.EvaluationsMiddleware(model.NewEvaluations()))
+	router.Use(model.MetricsMiddleware(metrics))
+	BindRoutes(router)
+
+	body := `[{"kind": "summary"}, {"kind": "custom"}, {"kind": "made-up-by-the-sdk"}, {}]`
+	req := httptest.NewRequest(http.MethodPost, "/bulk", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	scrape := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(scrape, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, scrape.Body.String(), `ldcli_dev_server_sdk_events_received_total{kind="summary"} 1`)
+	assert.Contains(t, scrape.Body.String(), `ldcli_dev_server_sdk_events_received_total{kind="custom"} 1`)
+	assert.Contains(t, scrape.Body.String(), `ldcli_dev_server_sdk_events_received_total{kind="other"} 2`)
+	assert.NotContains(t, scrape.Body.String(), "made-up-by-the-sdk")
 }
@@ -0,0 +1,313 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+)
+
+// OFREP error codes, from the OpenFeature Remote Evaluation Protocol.
+const (
+	ofrepParseError     = "PARSE_ERROR"
+	ofrepInvalidContext = "INVALID_CONTEXT"
+	ofrepFlagNotFound   = "FLAG_NOT_FOUND"
+	ofrepTypeMismatch   = "TYPE_MISMATCH"
+)
+
+// ofrepReason is the reason of every OFREP evaluation: the dev server serves the values it has stored, so there is no
+// rule that matched to report.
+const ofrepReason = "STATIC"
+
+var OFREPCorsHeaders = handlers.CORS(
+	handlers.AllowedOrigins([]string{"*"}),
+	handlers.AllowedMethods([]string{"POST"}),
+	handlers.AllowedHeaders([]string{"Authorization", "Content-Type", "If-None-Match"}),
+	handlers.ExposedHeaders([]string{"ETag"}),
+	handlers.MaxAge(300),
+)
+
+type ofrepRequest struct {
+	Context map[string]ldvalue.Value `json:"context"`
+}
+
+// ofrepEvaluation is an OFREP evaluation result, or for evaluations that failed, its error code and details.
+type ofrepEvaluation struct {
+	Key          string         `json:"key"`
+	Value        *ldvalue.Value `json:"value,omitempty"`
+	Reason       string         `json:"reason,omitempty"`
+	Variant      string         `json:"variant,omitempty"`
+	ErrorCode    string         `json:"errorCode,omitempty"`
+	ErrorDetails string         `json:"errorDetails,omitempty"`
+}
+
+type ofrepBulkEvaluation struct {
+	Flags []ofrepEvaluation `json:"flags"`
+}
+
+type ofrepError struct {
+	ErrorCode    string `json:"errorCode"`
+	ErrorDetails string `json:"errorDetails"`
+}
+
+// OFREPEvaluateFlags serves OFREP bulk evaluation: every flag of the project evaluated for the request's context, with
+// the same overrides as GetClientFlags. Responses have an ETag, and requests with a matching If-None-Match get 304 Not
+// Modified, so that providers can poll cheaply.
+func OFREPEvaluateFlags(w http.ResponseWriter, r *http.Request) {
+	flagsState, variations, err := evaluateOFREPRequest(r)
+	if err != nil {
+		writeOFREPRequestError(w, r, "", err)
+		return
+	}
+
+	flagKeys := make([]string, 0, len(flagsState))
+	for flagKey := range flagsState {
+		flagKeys = append(flagKeys, flagKey)
+	}
+	sort.Strings(flagKeys)
+	response := ofrepBulkEvaluation{Flags: make([]ofrepEvaluation, 0, len(flagKeys))}
+	for _, flagKey := range flagKeys {
+		response.Flags = append(response.Flags, ofrepEvaluationFor(flagKey, flagsState[flagKey], variations[flagKey]))
+	}
+	body, err := json.Marshal(response)
+	if err != nil {
+		WriteError(r.Context(), w, errors.Wrap(err, "failed to marshal flag evaluations"))
+		return
+	}
+
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeOFREPJSON(w, http.StatusOK, body)
+}
+
+// OFREPEvaluateFlag serves OFREP single-flag evaluation.
+func OFREPEvaluateFlag(w http.ResponseWriter, r *http.Request) {
+	flagKey := mux.Vars(r)["flagKey"]
+	flagsState, variations, err := evaluateOFREPRequest(r)
+	if err != nil {
+		writeOFREPRequestError(w, r, flagKey, err)
+		return
+	}
+
+	status := http.StatusOK
+	var evaluation ofrepEvaluation
+	if flagState, ok := flagsState[flagKey]; ok {
+		evaluation = ofrepEvaluationFor(flagKey, flagState, variations[flagKey])
+		if evaluation.ErrorCode != "" {
+			status = http.StatusBadRequest
+		}
+	} else {
+		status = http.StatusNotFound
+		evaluation = ofrepEvaluation{
+			Key:          flagKey,
+			ErrorCode:    ofrepFlagNotFound,
+			ErrorDetails: fmt.Sprintf("flag %s not found in project %s", flagKey, GetProjectKeyFromContext(r.Context())),
+		}
+	}
+	body, err := json.Marshal(evaluation)
+	if err != nil {
+		WriteError(r.Context(), w, errors.Wrap(err, "failed to marshal flag evaluation"))
+		return
+	}
+	writeOFREPJSON(w, status, body)
+}
+
+// ofrepContextError is an OFREP evaluation request whose context can't be used.
+type ofrepContextError struct {
+	code string
+	err  error
+}
+
+func (e ofrepContextError) Error() string {
+	return e.err.Error()
+}
+
+// evaluateOFREPRequest returns the project's flags evaluated for the request's context, and their available
+// variations.
+func evaluateOFREPRequest(r *http.Request) (model.FlagsState, map[string][]model.Variation, error) {
+	ctx := r.Context()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to read request")
+	}
+	var request ofrepRequest
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			return nil, nil, ofrepContextError{code: ofrepParseError, err: errors.Wrap(err, "unable to parse request")}
+		}
+	}
+	ldCtx, err := ldContextFromOFREP(request.Context)
+	if err != nil {
+		return nil, nil, ofrepContextError{code: ofrepInvalidContext, err: err}
+	}
+
+	flagsState, err := GetFlagsForContextFromContext(ctx, ldCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+	variations, err := model.StoreFromContext(ctx).GetAvailableVariationsForProject(ctx, GetProjectKeyFromContext(ctx))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to get available variations")
+	}
+	return flagsState, variations, nil
+}
+
+// ldContextFromOFREP converts an OpenFeature evaluation context the way LaunchDarkly's OpenFeature providers do: the
+// targeting key is the context key, "kind" picks the context kind (user by default), and a "multi" kind holds a
+// context of each kind under its name. Contexts without a targeting key get the flags served for the project's own
+// context.
+func ldContextFromOFREP(attributes map[string]ldvalue.Value) (*ldcontext.Context, error) {
+	kind := attributes["kind"].StringValue()
+	if kind != string(ldcontext.MultiKind) {
+		builder, ok := ldContextBuilderFromOFREP(kind, attributes)
+		if !ok {
+			return nil, nil
+		}
+		ldCtx, err := builder.TryBuild()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid context")
+		}
+		return &ldCtx, nil
+	}
+
+	multi := ldcontext.NewMultiBuilder()
+	for name, value := range attributes {
+		if value.Type() != ldvalue.ObjectType {
+			continue
+		}
+		nested := make(map[string]ldvalue.Value, value.Count())
+		for key, attribute := range value.AsValueMap().AsMap() {
+			nested[key] = attribute
+		}
+		builder, ok := ldContextBuilderFromOFREP(name, nested)
+		if !ok {
+			return nil, errors.Errorf("invalid context: the %s context has no targeting key", name)
+		}
+		multi.Add(builder.Build())
+	}
+	ldCtx, err := multi.TryBuild()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid context")
+	}
+	return &ldCtx, nil
+}
+
+func ldContextBuilderFromOFREP(kind string, attributes map[string]ldvalue.Value) (*ldcontext.Builder, bool) {
+	key := attributes["targetingKey"].StringValue()
+	if key == "" {
+		key = attributes["key"].StringValue()
+	}
+	if key == "" {
+		return nil, false
+	}
+	builder := ldcontext.NewBuilder(key)
+	if kind != "" {
+		builder.Kind(ldcontext.Kind(kind))
+	}
+	for name, value := range attributes {
+		switch name {
+		case "targetingKey", "key", "kind":
+		case "name":
+			builder.Name(value.StringValue())
+		case "anonymous":
+			builder.Anonymous(value.BoolValue())
+		case "privateAttributes":
+			for _, attribute := range value.AsValueArray().AsSlice() {
+				builder.Private(attribute.StringValue())
+			}
+		default:
+			builder.SetValue(name, value)
+		}
+	}
+	return builder, true
+}
+
+// ofrepEvaluationFor describes the flag's value, named after the variation that has it. A value whose type isn't the
+// type of the flag's variations, as an override of another type gives, is a type mismatch.
+func ofrepEvaluationFor(flagKey string, flagState model.FlagState, variations []model.Variation) ofrepEvaluation {
+	value := flagState.Value
+	if flagType, ok := variationsType(variations); ok && value.Type() != flagType {
+		return ofrepEvaluation{
+			Key:          flagKey,
+			ErrorCode:    ofrepTypeMismatch,
+			ErrorDetails: fmt.Sprintf("flag %s is a %s flag but is served the %s %s", flagKey, flagType, value.Type(), value.JSONString()),
+		}
+	}
+	evaluation := ofrepEvaluation{Key: flagKey, Value: &value, Reason: ofrepReason}
+	for _, variation := range variations {
+		if variation.Name != nil && *variation.Name != "" && variation.Value.Equal(value) {
+			evaluation.Variant = *variation.Name
+			break
+		}
+	}
+	return evaluation
+}
+
+// variationsType is the type that all of the variations have, if they have one.
+func variationsType(variations []model.Variation) (ldvalue.ValueType, bool) {
+	if len(variations) == 0 {
+		return ldvalue.NullType, false
+	}
+	valueType := variations[0].Value.Type()
+	for _, variation := range variations[1:] {
+		if variation.Value.Type() != valueType {
+			return ldvalue.NullType, false
+		}
+	}
+	return valueType, valueType != ldvalue.NullType
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeOFREPRequestError answers requests that couldn't be evaluated at all. Unknown projects get 401 Unauthorized
+// rather than 404 Not Found, which OFREP providers read as an unknown flag.
+func writeOFREPRequestError(w http.ResponseWriter, r *http.Request, flagKey string, err error) {
+	var contextErr ofrepContextError
+	switch {
+	case errors.As(err, &contextErr):
+		var body []byte
+		if flagKey != "" {
+			body, _ = json.Marshal(ofrepEvaluation{Key: flagKey, ErrorCode: contextErr.code, ErrorDetails: contextErr.Error()})
+		} else {
+			body, _ = json.Marshal(ofrepError{ErrorCode: contextErr.code, ErrorDetails: contextErr.Error()})
+		}
+		writeOFREPJSON(w, http.StatusBadRequest, body)
+	case errors.As(err, &model.ErrNotFound{}):
+		projectKey := GetProjectKeyFromContext(r.Context())
+		log.Println(err.Error())
+		log.Printf("To add your project to the dev server, call `ldcli dev-server add-project --project %s --source {SOURCE_ENV_KEY}", projectKey)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	default:
+		WriteError(r.Context(), w, err)
+	}
+}
+
+func writeOFREPJSON(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,169 @@
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldbuilders"
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
+	"github.com/launchdarkly/ldcli/internal/dev_server/model/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOFREP(t *testing.T) {
+	mockController := gomock.NewController(t)
+	store := mocks.NewMockStore(mockController)
+	observers := model.NewObservers()
+
+	router := mux.NewRouter()
+	router.Use(model.ObserversMiddleware(observers))
+	router.Use(model.StoreMiddleware(store))
+	BindRoutes(router)
+	store.EXPECT().GetDevProjectKeyForCredential(gomock.Any(), exampleProjectKey).
+		Return("", model.NewErrNotFound("project", "for credential")).AnyTimes()
+
+	project := &model.Project{
+		Key: exampleProjectKey,
+		FlagsData: adapters.FlagsData{
+			Flags: map[string]ldmodel.FeatureFlag{
+				"betaFlag": ldbuilders.NewFlagBuilder("betaFlag").
+					Version(1).
+					On(true).
+					Variations(ldvalue.String("everyone"), ldvalue.String("beta")).
+					AddTarget(1, "beta-user").
+					FallthroughVariation(0).
+					Build(),
+				"newCheckout": ldbuilders.NewFlagBuilder("newCheckout").
+					Version(1).
+					On(true).
+					Variations(ldvalue.Bool(true), ldvalue.Bool(false)).
+					FallthroughVariation(1).
+					Build(),
+			},
+		},
+	}
+	beta := "Beta"
+	variations := map[string][]model.Variation{
+		"betaFlag":    {{Id: "0", Value: ldvalue.String("everyone")}, {Id: "1", Name: &beta, Value: ldvalue.String("beta")}},
+		"newCheckout": {{Id: "0", Value: ldvalue.Bool(true)}, {Id: "1", Value: ldvalue.Bool(false)}},
+	}
+
+	evaluate := func(t *testing.T, path, body string, overrides model.Overrides, header http.Header) *httptest.ResponseRecorder {
+		store.EXPECT().GetDevProject(gomock.Any(), exampleProjectKey).Return(project, nil)
+		store.EXPECT().GetOverridesForProject(gomock.Any(), exampleProjectKey).Return(overrides, nil)
+		store.EXPECT().GetAvailableVariationsForProject(gomock.Any(), exampleProjectKey).Return(variations, nil)
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		req.Header.Set("Authorization", "Bearer "+exampleProjectKey)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("bulk evaluation evaluates every flag for the context", func(t *testing.T) {
+		rec := evaluate(t, "/ofrep/v1/evaluate/flags", `{"context": {"targetingKey": "beta-user"}}`, nil, nil)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+		assert.JSONEq(t, `{"flags": [
+			{"key": "betaFlag", "value": "beta", "reason": "STATIC", "variant": "Beta"},
+			{"key": "newCheckout", "value": false, "reason": "STATIC"}
+		]}`, rec.Body.String())
+	})
+
+	t.Run("bulk evaluation is not modified while the ETag matches", func(t *testing.T) {
+		first := evaluate(t, "/ofrep/v1/evaluate/flags", `{"context": {"targetingKey": "someone"}}`, nil, nil)
+		etag := first.Header().Get("ETag")
+
+		rec := evaluate(t, "/ofrep/v1/evaluate/flags", `{"context": {"targetingKey": "someone"}}`, nil, http.Header{"If-None-Match": {etag}})
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+
+		overrides := model.Overrides{{ProjectKey: exampleProjectKey, FlagKey: "newCheckout", Value: ldvalue.Bool(true), Active: true}}
+		rec = evaluate(t, "/ofrep/v1/evaluate/flags", `{"context": {"targetingKey": "someone"}}`, overrides, http.Header{"If-None-Match": {etag}})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+	})
+
+	t.Run("single flag evaluation applies overrides", func(t *testing.T) {
+		overrides := model.Overrides{{ProjectKey: exampleProjectKey, FlagKey: "newCheckout", Value: ldvalue.Bool(true), Active: true}}
+		rec := evaluate(t, "/ofrep/v1/evaluate/flags/newCheckout", `{"context": {"targetingKey": "someone"}}`, overrides, nil)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"key": "newCheckout", "value": true, "reason": "STATIC"}`, rec.Body.String())
+	})
+
+	t.Run("unknown flags are not found", func(t *testing.T) {
+		rec := evaluate(t, "/ofrep/v1/evaluate/flags/missing", `{"context": {"targetingKey": "someone"}}`, nil, nil)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		var evaluation map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &evaluation))
+		assert.Equal(t, "missing", evaluation["key"])
+		assert.Equal(t, "FLAG_NOT_FOUND", evaluation["errorCode"])
+	})
+
+	t.Run("overrides of another type than the flag's variations are type mismatches", func(t *testing.T) {
+		overrides := model.Overrides{{ProjectKey: exampleProjectKey, FlagKey: "newCheckout", Value: ldvalue.String("yes"), Active: true}}
+		rec := evaluate(t, "/ofrep/v1/evaluate/flags/newCheckout", `{"context": {"targetingKey": "someone"}}`, overrides, nil)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		var evaluation map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &evaluation))
+		assert.Equal(t, "TYPE_MISMATCH", evaluation["errorCode"])
+		assert.NotContains(t, evaluation, "value")
+	})
+
+	t.Run("malformed requests are parse errors", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/ofrep/v1/evaluate/flags", strings.NewReader(`{"context":`))
+		req.Header.Set("Authorization", exampleProjectKey)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.JSONEq(t, `{"errorCode": "PARSE_ERROR", "errorDetails": "unable to parse request: unexpected end of JSON input"}`, rec.Body.String())
+	})
+}
+
+func TestLDContextFromOFREP(t *testing.T) {
+	parse := func(t *testing.T, attributes string) (*ldcontext.Context, error) {
+		var values map[string]ldvalue.Value
+		require.NoError(t, json.Unmarshal([]byte(attributes), &values))
+		return ldContextFromOFREP(values)
+	}
+
+	t.Run("the targeting key is the key of a user context", func(t *testing.T) {
+		ldCtx, err := parse(t, `{"targetingKey": "alice", "name": "Alice", "plan": "pro"}`)
+		require.NoError(t, err)
+		assert.Equal(t, ldcontext.NewBuilder("alice").Name("Alice").SetString("plan", "pro").Build(), *ldCtx)
+	})
+
+	t.Run("multi contexts have a context of each kind", func(t *testing.T) {
+		ldCtx, err := parse(t, `{"kind": "multi", "user": {"targetingKey": "alice"}, "org": {"key": "acme"}}`)
+		require.NoError(t, err)
+		assert.Equal(t, ldcontext.NewMulti(ldcontext.New("alice"), ldcontext.NewWithKind("org", "acme")), *ldCtx)
+	})
+
+	t.Run("contexts without a targeting key are the project's context", func(t *testing.T) {
+		ldCtx, err := parse(t, `{"plan": "pro"}`)
+		require.NoError(t, err)
+		assert.Nil(t, ldCtx)
+	})
+
+	t.Run("invalid kinds are an error", func(t *testing.T) {
+		_, err := parse(t, `{"targetingKey": "alice", "kind": "not a kind!"}`)
+		assert.Error(t, err)
+	})
+}
@@ -19,13 +19,13 @@ func PollV2(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	allFlags, err := project.GetFlagStateWithOverridesForProject(ctx)
+	flagsData, err := project.GetFlagsDataWithOverridesForProject(ctx)
 	if err != nil {
 		WriteError(ctx, w, errors.Wrap(err, "failed to get flag state"))
 		return
 	}
 
-	response, err := buildInitialResponse(projectKey, project.PayloadVersion, allFlags, r.URL.Query().Get("basis"))
+	response, err := buildInitialResponse(projectKey, project.PayloadVersion, flagsData, r.URL.Query().Get("basis"), model.ChangeLogFromContext(ctx))
 	if err != nil {
 		WriteError(ctx, w, errors.Wrap(err, "failed to build poll response"))
 		return
@@ -39,12 +39,12 @@ func PollV2(w http.ResponseWriter, r *http.Request) {
 
 func LatestAll(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	allFlags, err := GetAllFlagsFromContext(ctx)
+	flagsData, err := GetFlagsDataFromContext(ctx)
 	if err != nil {
 		WriteError(ctx, w, errors.Wrap(err, "failed to get flag state"))
 		return
 	}
-	serverFlags := ServerAllPayloadFromFlagsState(allFlags)
+	serverFlags := ServerAllPayloadFromFlagsData(flagsData)
 	enc := json.NewEncoder(w)
 	err = enc.Encode(serverFlags.Data)
 	if err != nil {
@@ -2,10 +2,14 @@ package sdk
 
 import (
 	"context"
+	"log"
 	"net/http"
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 )
 
 type ctxKey string
@@ -19,16 +23,23 @@ func GetProjectKeyFromContext(ctx context.Context) string {
 	return ctx.Value(projectKeyContextKey).(string)
 }
 
+// projectKeyFromContext reads the project key of endpoints that have already put it on the request context, and is
+// empty for those that haven't.
+func projectKeyFromContext(request *http.Request) string {
+	projectKey, _ := request.Context().Value(projectKeyContextKey).(string)
+	return projectKey
+}
+
 func GetProjectKeyFromEnvIdParameter(pathParameter string) func(handler http.Handler) http.Handler {
 	return func(handler http.Handler) http.Handler {
 		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-			projectKey, ok := mux.Vars(request)[pathParameter]
+			credential, ok := mux.Vars(request)[pathParameter]
 			if !ok {
 				http.Error(writer, "project key not on path", http.StatusNotFound)
 				return
 			}
 			ctx := request.Context()
-			ctx = SetProjectKeyOnContext(ctx, projectKey)
+			ctx = SetProjectKeyOnContext(ctx, resolveProjectKey(ctx, credential))
 			request = request.WithContext(ctx)
 			handler.ServeHTTP(writer, request)
 		})
@@ -38,14 +49,57 @@ func GetProjectKeyFromEnvIdParameter(pathParameter string) func(handler http.Han
 func GetProjectKeyFromAuthorizationHeader(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 		ctx := request.Context()
-		projectKey := request.Header.Get("Authorization")
-		projectKey = strings.TrimPrefix(projectKey, "api_key ") // some sdks set this as a prefix
-		if projectKey == "" {
+		credential := credentialFromAuthorizationHeader(request)
+		if credential == "" {
 			http.Error(writer, "project key not on Authorization header", http.StatusUnauthorized)
 			return
 		}
-		ctx = SetProjectKeyOnContext(ctx, projectKey)
+		ctx = SetProjectKeyOnContext(ctx, resolveProjectKey(ctx, credential))
 		request = request.WithContext(ctx)
 		handler.ServeHTTP(writer, request)
 	})
 }
+
+// SetProjectKeyWhenPresent puts the project key on the context of requests that carry a credential, and passes on
+// those that don't, for endpoints like the event ones that serve SDKs either way.
+func SetProjectKeyWhenPresent(credentialFrom func(*http.Request) string) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if credential := credentialFrom(request); credential != "" {
+				ctx := SetProjectKeyOnContext(request.Context(), resolveProjectKey(request.Context(), credential))
+				request = request.WithContext(ctx)
+			}
+			handler.ServeHTTP(writer, request)
+		})
+	}
+}
+
+func credentialFromAuthorizationHeader(request *http.Request) string {
+	credential := request.Header.Get("Authorization")
+	credential = strings.TrimPrefix(credential, "Bearer ") // OFREP providers send bearer tokens
+	return strings.TrimPrefix(credential, "api_key ")      // some sdks set this as a prefix
+}
+
+// credentialFromEnvIdParameter reads the credential of client-side endpoints, which carry it on the path.
+func credentialFromEnvIdParameter(request *http.Request) string {
+	return mux.Vars(request)["envId"]
+}
+
+// resolveProjectKey maps an SDK's credential to a dev project. SDKs may be configured with the SDK key, mobile key or
+// client-side ID of a project's source environment, or with the project key itself, or project:env to pick one of
+// the project's environments.
+func resolveProjectKey(ctx context.Context, credential string) string {
+	projectKey, err := model.StoreFromContext(ctx).GetDevProjectKeyForCredential(ctx, credential)
+	if err == nil {
+		return projectKey
+	}
+	if !errors.As(err, &model.ErrNotFound{}) {
+		log.Printf("unable to look up the project for an SDK credential: %v", err)
+		return credential
+	}
+	projectKey, err = model.ResolveEnvironmentProjectKey(ctx, credential)
+	if err != nil {
+		log.Printf("unable to look up the environment for an SDK credential: %v", err)
+	}
+	return projectKey
+}
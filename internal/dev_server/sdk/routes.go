@@ -4,50 +4,96 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+
+	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 )
 
 var DevNull = ConstantResponseHandler(http.StatusAccepted, "")
 
 func BindRoutes(router *mux.Router) {
-	// events
-	router.HandleFunc("/bulk", SdkEventsReceiveHandler)
-	router.HandleFunc("/diagnostic", DevNull)
-	router.Methods(http.MethodPost, http.MethodOptions).Path("/events/bulk/{envId}").Handler(EventsCorsHeaders(DevNull))
-	router.Methods(http.MethodPost, http.MethodOptions).Path("/events/diagnostic/{envId}").Handler(EventsCorsHeaders(DevNull))
-	router.HandleFunc("/mobile", DevNull)
-	router.HandleFunc("/mobile/events", DevNull)
-	router.HandleFunc("/mobile/events/bulk", SdkEventsReceiveHandler)
-	router.HandleFunc("/mobile/events/diagnostic", DevNull)
-
-	router.Handle("/all", GetProjectKeyFromAuthorizationHeader(http.HandlerFunc(StreamServerAllPayload)))
-	router.Handle("/sdk/latest-all", GetProjectKeyFromAuthorizationHeader(http.HandlerFunc(LatestAll)))
-	router.Handle("/sdk/poll", GetProjectKeyFromAuthorizationHeader(http.HandlerFunc(PollV2)))
-	router.Handle("/sdk/stream", GetProjectKeyFromAuthorizationHeader(http.HandlerFunc(StreamV2)))
+	// Every SDK endpoint goes through InjectFaults once its project key is known, so that the project's faults apply.
+	withProjectKey := func(handler http.HandlerFunc) http.Handler {
+		return GetProjectKeyFromAuthorizationHeader(InjectFaults(handler))
+	}
+	// Streaming and polling endpoints register the SDKs they serve in the connection registry.
+	stream := func(handler http.HandlerFunc) http.Handler {
+		return withProjectKey(TrackConnections(model.StreamingConnection)(handler).ServeHTTP)
+	}
+	poll := func(handler http.HandlerFunc) http.Handler {
+		return withProjectKey(TrackConnections(model.PollingConnection)(handler).ServeHTTP)
+	}
+
+	// events, which are also forwarded to LaunchDarkly when event forwarding is on
+	bulk := func(handler http.Handler) http.Handler {
+		return SetProjectKeyWhenPresent(credentialFromAuthorizationHeader)(InjectFaults(ForwardEvents(model.AnalyticsEvents, handler)))
+	}
+	diagnostic := func(handler http.Handler) http.Handler {
+		return SetProjectKeyWhenPresent(credentialFromAuthorizationHeader)(InjectFaults(ForwardEvents(model.DiagnosticEvents, handler)))
+	}
+	clientSideEvents := func(kind model.EventPayloadKind, handler http.Handler) http.Handler {
+		return EventsCorsHeaders(SetProjectKeyWhenPresent(credentialFromEnvIdParameter)(InjectFaults(ForwardEvents(kind, handler))))
+	}
+	router.Handle("/bulk", bulk(http.HandlerFunc(SdkEventsReceiveHandler)))
+	router.Handle("/diagnostic", diagnostic(DevNull))
+	router.Methods(http.MethodPost, http.MethodOptions).Path("/events/bulk/{envId}").Handler(clientSideEvents(model.AnalyticsEvents, http.HandlerFunc(SdkEventsReceiveHandler)))
+	router.Methods(http.MethodPost, http.MethodOptions).Path("/events/diagnostic/{envId}").Handler(clientSideEvents(model.DiagnosticEvents, DevNull))
+	router.Handle("/mobile", bulk(http.HandlerFunc(SdkEventsReceiveHandler)))
+	router.Handle("/mobile/events", bulk(http.HandlerFunc(SdkEventsReceiveHandler)))
+	router.Handle("/mobile/events/bulk", bulk(http.HandlerFunc(SdkEventsReceiveHandler)))
+	router.Handle("/mobile/events/diagnostic", diagnostic(DevNull))
+
+	router.Handle("/all", stream(StreamServerAllPayload))
+	router.Handle("/sdk/latest-all", poll(LatestAll))
+	router.Handle("/sdk/poll", poll(PollV2))
+	router.Handle("/sdk/stream", stream(StreamV2))
 
 	router.PathPrefix("/sdk/flags/{flagKey}").
 		Methods(http.MethodGet).
-		Handler(GetProjectKeyFromAuthorizationHeader(http.HandlerFunc(GetServerFlags)))
+		Handler(poll(GetServerFlags))
 	router.PathPrefix("/sdk/flags").
 		Methods(http.MethodGet).
-		Handler(GetProjectKeyFromAuthorizationHeader(http.HandlerFunc(GetServerFlags)))
+		Handler(poll(GetServerFlags))
+	router.Path("/sdk/segments/{segmentKey}").
+		Methods(http.MethodGet).
+		Handler(poll(GetServerSegment))
 
-	router.PathPrefix("/meval").Handler(GetProjectKeyFromAuthorizationHeader(http.HandlerFunc(StreamClientFlags)))
-	router.PathPrefix("/msdk/evalx").Handler(GetProjectKeyFromAuthorizationHeader(http.HandlerFunc(GetClientFlags)))
+	// Client-side and mobile SDKs send their context base64url-encoded on the path for GET and in the body for REPORT.
+	router.Path("/meval/{context}").Methods(http.MethodGet).Handler(stream(StreamClientFlags))
+	router.PathPrefix("/meval").Handler(stream(StreamClientFlags))
+	router.Path("/msdk/evalx/{kind:contexts|users}/{context}").Methods(http.MethodGet).Handler(poll(GetClientFlags))
+	router.Path("/msdk/evalx/{context}").Methods(http.MethodGet).Handler(poll(GetClientFlags))
+	router.PathPrefix("/msdk/evalx").Handler(poll(GetClientFlags))
 
 	evalRouter := router.PathPrefix("/eval").Subrouter()
 	evalRouter.Use(CorsHeaders)
 	evalRouter.Use(GetProjectKeyFromEnvIdParameter("envId"))
+	evalRouter.Use(InjectFaults)
+	evalRouter.Use(TrackConnections(model.StreamingConnection))
+	evalRouter.Path("/{envId}/{context}").
+		Methods(http.MethodGet, http.MethodOptions).
+		HandlerFunc(StreamClientFlags)
 	evalRouter.PathPrefix("/{envId}").
 		Methods(http.MethodGet, "REPORT", http.MethodOptions).
 		HandlerFunc(StreamClientFlags)
 
+	// OpenFeature Remote Evaluation Protocol, for OpenFeature SDKs with an OFREP provider.
+	ofrep := func(handler http.HandlerFunc) http.Handler {
+		return OFREPCorsHeaders(poll(handler))
+	}
+	router.Path("/ofrep/v1/evaluate/flags").Methods(http.MethodPost, http.MethodOptions).Handler(ofrep(OFREPEvaluateFlags))
+	router.Path("/ofrep/v1/evaluate/flags/{flagKey}").Methods(http.MethodPost, http.MethodOptions).Handler(ofrep(OFREPEvaluateFlag))
+
 	goalsRouter := router.Path("/sdk/goals/{envId}").Subrouter()
 	goalsRouter.Use(CorsHeaders)
 	goalsRouter.Use(GetProjectKeyFromEnvIdParameter("envId"))
+	goalsRouter.Use(InjectFaults)
 	goalsRouter.Methods(http.MethodGet, http.MethodOptions).HandlerFunc(ConstantResponseHandler(http.StatusOK, "[]"))
 
 	evalXRouter := router.PathPrefix("/sdk/evalx/{envId}").Subrouter()
 	evalXRouter.Use(CorsHeaders)
 	evalXRouter.Use(GetProjectKeyFromEnvIdParameter("envId"))
+	evalXRouter.Use(InjectFaults)
+	evalXRouter.Use(TrackConnections(model.PollingConnection))
+	evalXRouter.Path("/{kind:contexts|users}/{context}").Methods(http.MethodGet, http.MethodOptions).HandlerFunc(GetClientFlags)
 	evalXRouter.Methods(http.MethodGet, http.MethodOptions, "REPORT").HandlerFunc(GetClientFlags)
 }
@@ -6,6 +6,8 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/pkg/errors"
+
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 )
 
@@ -28,10 +30,47 @@ func SdkEventsReceiveHandler(writer http.ResponseWriter, request *http.Request)
 		log.Printf("SdkEventsReceiveHandler: error unmarshaling request body: %v", err)
 	}
 
+	// Events are only attributed to a project when the SDK sends the key of one that exists.
+	projectKey := projectKeyFromContext(request)
+	if projectKey != "" && !devProjectExists(request, projectKey) {
+		projectKey = ""
+	}
+	evaluations := model.EvaluationsFromContext(request.Context())
+	metrics := model.MetricsFromContext(request.Context())
 	for _, msg := range arr {
+		if projectKey != "" {
+			evaluations.Record(projectKey, msg)
+		}
+		if metrics != nil {
+			var event SDKEventBase
+			_ = json.Unmarshal(msg, &event)
+			metrics.SDKEventReceived(sdkEventKind(event.Kind))
+		}
 		observers.Notify(msg)
 	}
 
 	writer.Header().Set("Content-Type", "application/json")
 	writer.WriteHeader(http.StatusAccepted)
 }
+
+// sdkEventKind maps an event's kind to one of the kinds that metrics are labelled with, so that SDKs can't add labels.
+func sdkEventKind(kind string) string {
+	switch kind {
+	case "feature", "summary", "index", "identify", "custom":
+		return kind
+	default:
+		return "other"
+	}
+}
+
+func devProjectExists(request *http.Request, projectKey string) bool {
+	ctx := request.Context()
+	_, err := model.StoreFromContext(ctx).GetDevProject(ctx, projectKey)
+	if err != nil {
+		if !errors.As(err, &model.ErrNotFound{}) {
+			log.Printf("unable to get project %s to record its SDK events: %v", projectKey, err)
+		}
+		return false
+	}
+	return true
+}
@@ -5,6 +5,8 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
+	"github.com/launchdarkly/ldcli/internal/dev_server/adapters"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 	"github.com/pkg/errors"
 )
@@ -39,3 +41,38 @@ func GetAllFlagsFromContext(ctx context.Context) (model.FlagsState, error) {
 	}
 	return allFlags, nil
 }
+
+// GetFlagsForContextFromContext evaluates the project's flags for the context an SDK identified with, falling back
+// to the project's own flag state when the request didn't carry one.
+func GetFlagsForContextFromContext(ctx context.Context, ldCtx *ldcontext.Context) (model.FlagsState, error) {
+	if ldCtx == nil {
+		return GetAllFlagsFromContext(ctx)
+	}
+	store := model.StoreFromContext(ctx)
+	projectKey := GetProjectKeyFromContext(ctx)
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return model.FlagsState{}, errors.Wrap(err, "unable to get dev project")
+	}
+	allFlags, err := project.GetFlagStateWithOverridesForContext(ctx, *ldCtx)
+	if err != nil {
+		return model.FlagsState{}, errors.Wrap(err, "unable to get flags for context")
+	}
+	return allFlags, nil
+}
+
+// GetFlagsDataFromContext returns the flag and segment configuration, with overrides applied, that is served to
+// server-side SDKs.
+func GetFlagsDataFromContext(ctx context.Context) (adapters.FlagsData, error) {
+	store := model.StoreFromContext(ctx)
+	projectKey := GetProjectKeyFromContext(ctx)
+	project, err := store.GetDevProject(ctx, projectKey)
+	if err != nil {
+		return adapters.FlagsData{}, errors.Wrap(err, "unable to get dev project")
+	}
+	flagsData, err := project.GetFlagsDataWithOverridesForProject(ctx)
+	if err != nil {
+		return adapters.FlagsData{}, errors.Wrap(err, "unable to get flags for project")
+	}
+	return flagsData, nil
+}
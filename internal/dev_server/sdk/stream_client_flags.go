@@ -1,10 +1,12 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
 
+	"github.com/launchdarkly/go-sdk-common/v3/ldcontext"
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 	"github.com/pkg/errors"
@@ -12,7 +14,12 @@ import (
 
 func StreamClientFlags(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	allFlags, err := GetAllFlagsFromContext(ctx)
+	ldCtx, err := GetContextFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	allFlags, err := GetFlagsForContextFromContext(ctx, ldCtx)
 	if err != nil {
 		WriteError(ctx, w, errors.Wrap(err, "failed to get flag state"))
 		return
@@ -23,13 +30,14 @@ func StreamClientFlags(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	updateChan, doneChan := OpenStream(
+		r.Context(),
 		w,
-		r.Context().Done(),
 		Message{Event: TYPE_PUT, Data: jsonBody}.ToPayload(),
 	)
 	defer close(updateChan)
+	defer model.MetricsFromContext(ctx).StreamOpened("StreamClientFlags")()
 	projectKey := GetProjectKeyFromContext(ctx)
-	observer := clientFlagsObserver{updateChan, projectKey}
+	observer := clientFlagsObserver{ctx, updateChan, projectKey, ldCtx}
 	observers := model.GetObserversFromContext(ctx)
 	observerId := observers.RegisterObserver(observer)
 	defer func() {
@@ -46,37 +54,75 @@ func StreamClientFlags(w http.ResponseWriter, r *http.Request) {
 }
 
 type clientFlagsObserver struct {
-	updateChan chan<- []byte
+	ctx        context.Context
+	updateChan chan<- StreamUpdate
 	projectKey string
+	ldCtx      *ldcontext.Context
 }
 
 func (c clientFlagsObserver) Handle(event interface{}) {
 	switch event := event.(type) {
 	case model.OverrideEvent:
-		err := SendMessage(c.updateChan, TYPE_PATCH, clientFlag{
+		if event.ProjectKey != c.projectKey {
+			return
+		}
+		flagState, err := c.flagStateForContext(event.FlagKey, event.FlagState)
+		if err != nil {
+			log.Printf("unable to evaluate flag %s for stream context: %+v", event.FlagKey, err)
+			return
+		}
+		err = SendMessage(c.updateChan, event.PayloadVersion, TYPE_PATCH, clientFlag{
 			Key:     event.FlagKey,
-			Version: event.FlagState.Version,
-			Value:   event.FlagState.Value,
+			Version: flagState.Version,
+			Value:   flagState.Value,
 		})
 		if err != nil {
 			panic(errors.Wrap(err, "failed to marshal flag state in observer"))
 		}
 	case model.SyncEvent:
+		if event.ProjectKey != c.projectKey {
+			return
+		}
+		allFlagsState, err := c.flagsStateForContext(event.AllFlagsState)
+		if err != nil {
+			log.Printf("unable to evaluate flags for stream context: %+v", err)
+			return
+		}
 		clientFlags := clientFlags{}
-		for flagKey, flagState := range event.AllFlagsState {
+		for flagKey, flagState := range allFlagsState {
 			clientFlags[flagKey] = clientFlag{
 				Version: flagState.Version,
 				Value:   flagState.Value,
 			}
 		}
 
-		err := SendMessage(c.updateChan, TYPE_PUT, clientFlags)
+		err = SendMessage(c.updateChan, event.PayloadVersion, TYPE_PUT, clientFlags)
 		if err != nil {
 			panic(errors.Wrap(err, "failed to marshal flag state in observer"))
 		}
 	}
 }
 
+// flagsStateForContext re-evaluates the project's flags when the stream was opened for a specific context, since
+// events carry the flag state of the project's own context.
+func (c clientFlagsObserver) flagsStateForContext(projectFlagsState model.FlagsState) (model.FlagsState, error) {
+	if c.ldCtx == nil {
+		return projectFlagsState, nil
+	}
+	return GetFlagsForContextFromContext(c.ctx, c.ldCtx)
+}
+
+func (c clientFlagsObserver) flagStateForContext(flagKey string, projectFlagState model.FlagState) (model.FlagState, error) {
+	if c.ldCtx == nil {
+		return projectFlagState, nil
+	}
+	allFlagsState, err := GetFlagsForContextFromContext(c.ctx, c.ldCtx)
+	if err != nil {
+		return model.FlagState{}, err
+	}
+	return allFlagsState[flagKey], nil
+}
+
 type clientFlag struct {
 	Key     string        `json:"key,omitempty"`
 	Version int           `json:"version"`
@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -21,22 +22,23 @@ func StreamV2(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	allFlags, err := project.GetFlagStateWithOverridesForProject(ctx)
+	flagsData, err := project.GetFlagsDataWithOverridesForProject(ctx)
 	if err != nil {
 		WriteError(ctx, w, errors.Wrap(err, "failed to get flag state"))
 		return
 	}
 
-	initialPayload, err := buildInitialResponse(projectKey, project.PayloadVersion, allFlags, r.URL.Query().Get("basis"))
+	initialPayload, err := buildInitialResponse(projectKey, project.PayloadVersion, flagsData, r.URL.Query().Get("basis"), model.ChangeLogFromContext(ctx))
 	if err != nil {
 		WriteError(ctx, w, errors.Wrap(err, "failed to build initial payload"))
 		return
 	}
 
-	updateChan, doneChan := OpenStream(w, r.Context().Done(), fdv2SSEPayload(initialPayload.Events))
+	updateChan, doneChan := OpenStream(r.Context(), w, fdv2SSEPayload(initialPayload.Events))
 	defer close(updateChan)
+	defer model.MetricsFromContext(ctx).StreamOpened("StreamV2")()
 
-	observer := fdv2StreamObserver{updateChan: updateChan, projectKey: projectKey}
+	observer := fdv2StreamObserver{ctx: ctx, updateChan: updateChan, projectKey: projectKey}
 	observerID := model.GetObserversFromContext(ctx).RegisterObserver(observer)
 	defer func() {
 		if ok := model.GetObserversFromContext(ctx).DeregisterObserver(observerID); !ok {
@@ -61,7 +63,8 @@ func fdv2SSEPayload(events []subsystems.RawEvent) []byte {
 }
 
 type fdv2StreamObserver struct {
-	updateChan chan<- []byte
+	ctx        context.Context
+	updateChan chan<- StreamUpdate
 	projectKey string
 }
 
@@ -71,19 +74,29 @@ func (o fdv2StreamObserver) Handle(event interface{}) {
 		if event.ProjectKey != o.projectKey {
 			return
 		}
-		events, err := buildFlagChangeEvents(o.projectKey, event.PayloadVersion, event.FlagKey, event.FlagState)
+		flagsData, err := GetFlagsDataFromContext(o.ctx)
+		if err != nil {
+			log.Printf("unable to get flag %s for fdv2 stream: %+v", event.FlagKey, err)
+			return
+		}
+		events, err := buildFlagChangeEvents(o.projectKey, event.PayloadVersion, flagsData.Flags[event.FlagKey])
 		if err != nil {
 			panic(errors.Wrap(err, "failed to build flag change events in fdv2 stream observer"))
 		}
-		o.updateChan <- fdv2SSEPayload(events)
+		o.updateChan <- StreamUpdate{Payload: fdv2SSEPayload(events), PayloadVersion: &event.PayloadVersion}
 	case model.SyncEvent:
 		if event.ProjectKey != o.projectKey {
 			return
 		}
-		payload, err := buildFullTransferResponse(o.projectKey, event.PayloadVersion, event.AllFlagsState, fdv2ReasonCantCatchup)
+		flagsData, err := GetFlagsDataFromContext(o.ctx)
+		if err != nil {
+			log.Printf("unable to get flags for fdv2 stream: %+v", err)
+			return
+		}
+		payload, err := buildFullTransferResponse(o.projectKey, event.PayloadVersion, flagsData, fdv2ReasonCantCatchup)
 		if err != nil {
 			panic(errors.Wrap(err, "failed to build full transfer in fdv2 stream observer"))
 		}
-		o.updateChan <- fdv2SSEPayload(payload.Events)
+		o.updateChan <- StreamUpdate{Payload: fdv2SSEPayload(payload.Events), PayloadVersion: &event.PayloadVersion}
 	}
 }
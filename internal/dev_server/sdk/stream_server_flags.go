@@ -1,11 +1,13 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 
+	"github.com/launchdarkly/go-server-sdk-evaluation/v3/ldmodel"
 	"github.com/launchdarkly/ldcli/internal/dev_server/model"
 	"github.com/pkg/errors"
 )
@@ -13,24 +15,25 @@ import (
 func StreamServerAllPayload(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	projectKey := GetProjectKeyFromContext(ctx)
-	allFlags, err := GetAllFlagsFromContext(ctx)
+	flagsData, err := GetFlagsDataFromContext(ctx)
 	if err != nil {
 		WriteError(ctx, w, errors.Wrap(err, "failed to get flag state"))
 		return
 	}
-	serverFlags := ServerAllPayloadFromFlagsState(allFlags)
+	serverFlags := ServerAllPayloadFromFlagsData(flagsData)
 	jsonBody, err := json.Marshal(serverFlags)
 	if err != nil {
 		WriteError(ctx, w, errors.Wrap(err, "failed to marshal flag state"))
 		return
 	}
 	updateChan, doneChan := OpenStream(
+		r.Context(),
 		w,
-		r.Context().Done(),
 		Message{Event: TYPE_PUT, Data: jsonBody}.ToPayload(),
 	)
 	defer close(updateChan)
-	observer := serverFlagsObserver{updateChan, projectKey}
+	defer model.MetricsFromContext(ctx).StreamOpened("StreamServerAllPayload")()
+	observer := serverFlagsObserver{ctx, updateChan, projectKey}
 	observers := model.GetObserversFromContext(ctx)
 	observerId := observers.RegisterObserver(observer)
 	defer func() {
@@ -46,8 +49,11 @@ func StreamServerAllPayload(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// serverFlagsObserver re-reads the project's flag configuration on each event, since events only carry the flag
+// state of the project's own context.
 type serverFlagsObserver struct {
-	updateChan chan<- []byte
+	ctx        context.Context
+	updateChan chan<- StreamUpdate
 	projectKey string
 }
 
@@ -58,9 +64,14 @@ func (c serverFlagsObserver) Handle(event interface{}) {
 			return
 		}
 
-		err := SendMessage(c.updateChan, TYPE_PATCH, serverSidePatchData{
+		flagsData, err := GetFlagsDataFromContext(c.ctx)
+		if err != nil {
+			log.Printf("unable to get flag %s for server stream: %+v", event.FlagKey, err)
+			return
+		}
+		err = SendMessage(c.updateChan, event.PayloadVersion, TYPE_PATCH, serverSidePatchData{
 			Path: fmt.Sprintf("/flags/%s", event.FlagKey),
-			Data: serverFlagFromFlagState(event.FlagKey, event.FlagState),
+			Data: flagsData.Flags[event.FlagKey],
 		})
 		if err != nil {
 			panic(errors.Wrap(err, "failed to marshal flag state in observer"))
@@ -70,7 +81,12 @@ func (c serverFlagsObserver) Handle(event interface{}) {
 			return
 		}
 
-		err := SendMessage(c.updateChan, TYPE_PUT, ServerAllPayloadFromFlagsState(event.AllFlagsState))
+		flagsData, err := GetFlagsDataFromContext(c.ctx)
+		if err != nil {
+			log.Printf("unable to get flags for server stream: %+v", err)
+			return
+		}
+		err = SendMessage(c.updateChan, event.PayloadVersion, TYPE_PUT, ServerAllPayloadFromFlagsData(flagsData))
 		if err != nil {
 			panic(errors.Wrap(err, "failed to marshal flag state in observer"))
 		}
@@ -78,6 +94,6 @@ func (c serverFlagsObserver) Handle(event interface{}) {
 }
 
 type serverSidePatchData struct {
-	Path string     `json:"path"`
-	Data ServerFlag `json:"data"`
+	Path string              `json:"path"`
+	Data ldmodel.FeatureFlag `json:"data"`
 }
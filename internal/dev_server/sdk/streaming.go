@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -21,6 +22,13 @@ type Message struct {
 	Data  []byte
 }
 
+// StreamUpdate is a payload to write to a stream. Payloads that send the project's flags carry the payload version they
+// were built from, which is recorded as the stream's last payload version once the payload is written.
+type StreamUpdate struct {
+	Payload        []byte
+	PayloadVersion *int
+}
+
 func (m Message) ToPayload() []byte {
 	payload := []byte(fmt.Sprintf("event:%s\ndata:", m.Event))
 	payload = append(payload, m.Data...)
@@ -28,11 +36,11 @@ func (m Message) ToPayload() []byte {
 	return payload
 }
 
-// OpenStream sets SSE headers, writes initialPayload, and starts the SSE loop.
-// Each []byte sent to the returned channel is written verbatim to the response.
-func OpenStream(w http.ResponseWriter, done <-chan struct{}, initialPayload []byte) (chan<- []byte, <-chan error) {
+// OpenStream sets SSE headers, writes initialPayload, and starts the SSE loop, which runs until ctx is done.
+// The payload of each update sent to the returned channel is written verbatim to the response.
+func OpenStream(ctx context.Context, w http.ResponseWriter, initialPayload []byte) (chan<- StreamUpdate, <-chan error) {
 	errChan := make(chan error)
-	updateChan := make(chan []byte, 10)
+	updateChan := make(chan StreamUpdate, 10)
 	go func() {
 		var err error
 		defer func() {
@@ -51,23 +59,32 @@ func OpenStream(w http.ResponseWriter, done <-chan struct{}, initialPayload []by
 				return errors.Wrap(err, "unable to write response")
 			}
 			flusher.Flush()
-			ticker := time.NewTicker(time.Minute)
+			var heartbeats <-chan time.Time
+			if !heartbeatsStalled(ctx) {
+				ticker := time.NewTicker(time.Minute)
+				defer ticker.Stop()
+				heartbeats = ticker.C
+			}
 		loop:
 			for {
 				select {
-				case <-ticker.C:
+				case <-heartbeats:
 					_, err = w.Write([]byte(":\n\n"))
 					if err != nil {
 						return errors.Wrap(err, "unable to write response")
 					}
 					flusher.Flush()
-				case payload := <-updateChan:
-					_, err = w.Write(payload)
+					streamHeartbeatSent(ctx)
+				case update := <-updateChan:
+					_, err = w.Write(update.Payload)
 					if err != nil {
 						return errors.Wrap(err, "unable to write response")
 					}
 					flusher.Flush()
-				case <-done:
+					if update.PayloadVersion != nil {
+						streamPayloadSent(ctx, *update.PayloadVersion)
+					}
+				case <-ctx.Done():
 					break loop
 				}
 			}
@@ -77,8 +94,10 @@ func OpenStream(w http.ResponseWriter, done <-chan struct{}, initialPayload []by
 	return updateChan, errChan
 }
 
+// SendMessage sends the project's flags, as of payloadVersion, to the stream.
 func SendMessage(
-	updateChan chan<- []byte,
+	updateChan chan<- StreamUpdate,
+	payloadVersion int,
 	msgType MessageType,
 	data interface{},
 ) error {
@@ -87,10 +106,13 @@ func SendMessage(
 		return err
 	}
 
-	updateChan <- Message{
-		Event: msgType,
-		Data:  payload,
-	}.ToPayload()
+	updateChan <- StreamUpdate{
+		Payload: Message{
+			Event: msgType,
+			Data:  payload,
+		}.ToPayload(),
+		PayloadVersion: &payloadVersion,
+	}
 
 	return nil
 }
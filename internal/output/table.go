@@ -2,6 +2,7 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"text/tabwriter"
@@ -68,6 +69,18 @@ func formatTimestamp(v interface{}) string {
 	}
 }
 
+// jsonValue formats a value as compact JSON, so that strings stand out from other values and objects stay readable.
+func jsonValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return defaultFormat(v)
+	}
+	return string(data)
+}
+
 // listColumnRegistry maps resource names to their list-view column definitions.
 var listColumnRegistry = map[string][]ColumnDef{
 	"flags": {
@@ -98,6 +111,12 @@ var listColumnRegistry = map[string][]ColumnDef{
 		{Header: "NAME", Field: "name"},
 		{Header: "CREATED", Field: "creationDate", Format: formatTimestamp},
 	},
+	"snapshot-diffs": {
+		{Header: "FLAG", Field: "flagKey"},
+		{Header: "FIELD", Field: "field"},
+		{Header: "FROM", Field: "from", Format: jsonValue},
+		{Header: "TO", Field: "to", Format: jsonValue},
+	},
 }
 
 // singularColumnRegistry maps resource names to their singular-view column definitions.
@@ -155,6 +155,36 @@ func TestTableOutput(t *testing.T) {
 		assert.Contains(t, lines[1], expected)
 	})
 
+	t.Run("snapshot diffs list shows flag, field, from, to", func(t *testing.T) {
+		cols := GetListColumns("snapshot-diffs")
+		items := []resource{
+			{
+				"flagKey": "new-checkout",
+				"field":   "override",
+				"from":    true,
+			},
+			{
+				"flagKey": "banner",
+				"field":   "value",
+				"from":    "welcome",
+				"to":      "sale",
+			},
+		}
+
+		result := TableOutput(items, cols)
+		lines := strings.Split(result, "\n")
+
+		assert.Len(t, lines, 3)
+		assert.Contains(t, lines[0], "FLAG")
+		assert.Contains(t, lines[0], "FIELD")
+		assert.Contains(t, lines[0], "FROM")
+		assert.Contains(t, lines[0], "TO")
+		assert.Contains(t, lines[1], "new-checkout")
+		assert.Contains(t, lines[1], "true")
+		assert.Contains(t, lines[2], `"welcome"`)
+		assert.Contains(t, lines[2], `"sale"`)
+	})
+
 	t.Run("handles nil field values gracefully", func(t *testing.T) {
 		cols := GetListColumns("flags")
 		items := []resource{
@@ -361,6 +391,20 @@ func TestFormatTimestamp(t *testing.T) {
 	})
 }
 
+func TestJSONValue(t *testing.T) {
+	t.Run("quotes strings", func(t *testing.T) {
+		assert.Equal(t, `"sale"`, jsonValue("sale"))
+	})
+
+	t.Run("formats objects as compact JSON", func(t *testing.T) {
+		assert.Equal(t, `{"key":"dev","kind":"user"}`, jsonValue(map[string]interface{}{"kind": "user", "key": "dev"}))
+	})
+
+	t.Run("nil returns empty string", func(t *testing.T) {
+		assert.Equal(t, "", jsonValue(nil))
+	})
+}
+
 func TestTableOutputEdgeCases(t *testing.T) {
 	t.Run("empty items produces header only", func(t *testing.T) {
 		cols := GetListColumns("flags")